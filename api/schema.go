@@ -0,0 +1,10 @@
+// Package api provides the JSON schema of the saucectl configuration file.
+package api
+
+import _ "embed"
+
+// Schema is the JSON schema of the saucectl configuration file, as shipped
+// with this version of saucectl.
+//
+//go:embed saucectl.schema.json
+var Schema []byte
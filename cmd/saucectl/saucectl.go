@@ -16,13 +16,16 @@ import (
 	"github.com/saucelabs/saucectl/internal/cmd/artifacts"
 	"github.com/saucelabs/saucectl/internal/cmd/builds"
 	"github.com/saucelabs/saucectl/internal/cmd/completion"
+	"github.com/saucelabs/saucectl/internal/cmd/config"
 	"github.com/saucelabs/saucectl/internal/cmd/configure"
 	"github.com/saucelabs/saucectl/internal/cmd/devices"
 	"github.com/saucelabs/saucectl/internal/cmd/ini"
+	"github.com/saucelabs/saucectl/internal/cmd/insights"
 	"github.com/saucelabs/saucectl/internal/cmd/jobs"
 	"github.com/saucelabs/saucectl/internal/cmd/run"
 	"github.com/saucelabs/saucectl/internal/cmd/signup"
 	"github.com/saucelabs/saucectl/internal/cmd/storage"
+	"github.com/saucelabs/saucectl/internal/secret"
 	"github.com/saucelabs/saucectl/internal/version"
 	"github.com/spf13/cobra"
 )
@@ -64,12 +67,14 @@ func main() {
 	cmd.AddCommand(
 		run.Command(),
 		configure.Command(),
+		config.Command(cmd.PersistentPreRun),
 		ini.Command(cmd.PersistentPreRun),
 		signup.Command(),
 		completion.Command(),
 		storage.Command(cmd.PersistentPreRun),
 		artifacts.Command(cmd.PersistentPreRun),
 		jobs.Command(cmd.PersistentPreRun),
+		insights.Command(cmd.PersistentPreRun),
 		apit.Command(cmd.PersistentPreRun),
 		builds.Command(cmd.PersistentPreRun),
 		devices.Command(cmd.PersistentPreRun),
@@ -95,7 +100,9 @@ func setupLogging(verbose bool, noColor bool) {
 		return time.Now().In(time.Local)
 	}
 
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: timeFormat, NoColor: noColor})
+	// Secrets resolved from the config must never show up in the logs.
+	out := secret.NewMaskWriter(os.Stdout)
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: out, TimeFormat: timeFormat, NoColor: noColor})
 }
 
 // newContext returns a new context that is canceled when a SIGINT is received.
@@ -1,8 +1,11 @@
 package ci
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"strings"
+	"text/template"
 )
 
 type CI struct {
@@ -11,6 +14,13 @@ type CI struct {
 	Repo     string
 	Ref      string // branch or tag
 	SHA      string
+
+	PipelineID    string
+	JobID         string
+	PR            string // pull/merge request number
+	Author        string
+	Branch        string
+	CommitMessage string
 }
 
 // Provider represents a CI Provider.
@@ -100,131 +110,215 @@ func GetCI(provider Provider) CI {
 	switch provider {
 	case AppVeyor:
 		return CI{
-			Provider: provider,
-			URL:      fmt.Sprintf("%s/project/%s/%s/builds/%s", os.Getenv("APPVEYOR_URL"), os.Getenv("APPVEYOR_ACCOUNT_NAME"), os.Getenv("APPVEYOR_PROJECT_NAME"), os.Getenv("APPVEYOR_BUILD_ID")),
-			Repo:     os.Getenv("APPVEYOR_REPO_NAME"),
-			Ref:      os.Getenv("APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH"),
-			SHA:      os.Getenv("APPVEYOR_REPO_COMMIT"),
+			Provider:      provider,
+			URL:           fmt.Sprintf("%s/project/%s/%s/builds/%s", os.Getenv("APPVEYOR_URL"), os.Getenv("APPVEYOR_ACCOUNT_NAME"), os.Getenv("APPVEYOR_PROJECT_NAME"), os.Getenv("APPVEYOR_BUILD_ID")),
+			Repo:          os.Getenv("APPVEYOR_REPO_NAME"),
+			Ref:           os.Getenv("APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH"),
+			SHA:           os.Getenv("APPVEYOR_REPO_COMMIT"),
+			PipelineID:    os.Getenv("APPVEYOR_BUILD_ID"),
+			JobID:         os.Getenv("APPVEYOR_JOB_ID"),
+			PR:            os.Getenv("APPVEYOR_PULL_REQUEST_NUMBER"),
+			Author:        os.Getenv("APPVEYOR_REPO_COMMIT_AUTHOR"),
+			Branch:        firstEnv("APPVEYOR_PULL_REQUEST_HEAD_REPO_BRANCH", "APPVEYOR_REPO_BRANCH"),
+			CommitMessage: os.Getenv("APPVEYOR_REPO_COMMIT_MESSAGE"),
 		}
 	case AWS:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("CODEBUILD_PUBLIC_BUILD_URL"),
-			Repo:     os.Getenv("CODEBUILD_SOURCE_REPO_URL"),
-			Ref:      os.Getenv("CODEBUILD_SOURCE_VERSION"),
-			SHA:      os.Getenv("CODEBUILD_RESOLVED_SOURCE_VERSION"),
+			Provider:   provider,
+			URL:        os.Getenv("CODEBUILD_PUBLIC_BUILD_URL"),
+			Repo:       os.Getenv("CODEBUILD_SOURCE_REPO_URL"),
+			Ref:        os.Getenv("CODEBUILD_SOURCE_VERSION"),
+			SHA:        os.Getenv("CODEBUILD_RESOLVED_SOURCE_VERSION"),
+			PipelineID: os.Getenv("CODEBUILD_BUILD_ID"),
+			JobID:      os.Getenv("CODEBUILD_BUILD_NUMBER"),
+			PR:         codebuildPR(os.Getenv("CODEBUILD_WEBHOOK_TRIGGER")),
+			Author:     os.Getenv("CODEBUILD_WEBHOOK_ACTOR_ACCOUNT_ID"),
+			Branch:     strings.TrimPrefix(os.Getenv("CODEBUILD_WEBHOOK_HEAD_REF"), "refs/heads/"),
 		}
 	case Azure:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("BUILD_REPOSITORY_URI"),
-			Repo:     os.Getenv("SYSTEM_PULLREQUEST_SOURCEREPOSITORYURI"),
-			Ref:      os.Getenv("BUILD_SOURCEBRANCHNAME"),
-			SHA:      os.Getenv("BUILD_SOURCEVERSION"),
+			Provider:      provider,
+			URL:           os.Getenv("BUILD_REPOSITORY_URI"),
+			Repo:          os.Getenv("SYSTEM_PULLREQUEST_SOURCEREPOSITORYURI"),
+			Ref:           os.Getenv("BUILD_SOURCEBRANCHNAME"),
+			SHA:           os.Getenv("BUILD_SOURCEVERSION"),
+			PipelineID:    os.Getenv("BUILD_BUILDID"),
+			JobID:         os.Getenv("SYSTEM_JOBID"),
+			PR:            firstEnv("SYSTEM_PULLREQUEST_PULLREQUESTNUMBER", "SYSTEM_PULLREQUEST_PULLREQUESTID"),
+			Author:        os.Getenv("BUILD_REQUESTEDFOR"),
+			Branch:        firstEnv("SYSTEM_PULLREQUEST_SOURCEBRANCH", "BUILD_SOURCEBRANCHNAME"),
+			CommitMessage: os.Getenv("BUILD_SOURCEVERSIONMESSAGE"),
 		}
 	case Bamboo:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("bamboo_resultsUrl"),
-			Ref:      os.Getenv("bamboo_planRepository_branchDisplayName"),
+			Provider:   provider,
+			URL:        os.Getenv("bamboo_resultsUrl"),
+			Ref:        os.Getenv("bamboo_planRepository_branchDisplayName"),
+			SHA:        os.Getenv("bamboo_planRepository_revision"),
+			PipelineID: os.Getenv("bamboo_buildNumber"),
+			JobID:      os.Getenv("bamboo_buildResultKey"),
+			PR:         os.Getenv("bamboo_repository_pr_key"),
+			Author:     os.Getenv("bamboo_ManualBuildTriggerReason_userName"),
+			Branch:     os.Getenv("bamboo_planRepository_branchName"),
 		}
 	case Bitbucket:
 		return CI{
-			Provider: provider,
-			URL:      fmt.Sprintf("https://bitbucket.org/%s/addon/pipelines/home#!/results/%s", os.Getenv("BITBUCKET_REPO_FULL_NAME"), os.Getenv("BITBUCKET_BUILD_NUMBER")),
-			Repo:     os.Getenv("BITBUCKET_REPO_FULL_NAME"),
-			Ref:      os.Getenv("BITBUCKET_BRANCH"),
-			SHA:      os.Getenv("BITBUCKET_COMMIT"),
+			Provider:   provider,
+			URL:        fmt.Sprintf("https://bitbucket.org/%s/addon/pipelines/home#!/results/%s", os.Getenv("BITBUCKET_REPO_FULL_NAME"), os.Getenv("BITBUCKET_BUILD_NUMBER")),
+			Repo:       os.Getenv("BITBUCKET_REPO_FULL_NAME"),
+			Ref:        os.Getenv("BITBUCKET_BRANCH"),
+			SHA:        os.Getenv("BITBUCKET_COMMIT"),
+			PipelineID: os.Getenv("BITBUCKET_PIPELINE_UUID"),
+			JobID:      os.Getenv("BITBUCKET_STEP_UUID"),
+			PR:         os.Getenv("BITBUCKET_PR_ID"),
+			Branch:     os.Getenv("BITBUCKET_BRANCH"),
 		}
 	case Buildkite:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("BUILDKITE_BUILD_URL"),
-			Repo:     os.Getenv("BUILDKITE_REPO"),
-			Ref:      os.Getenv("BUILDKITE_BRANCH"),
-			SHA:      os.Getenv("BUILDKITE_COMMIT"),
+			Provider:      provider,
+			URL:           os.Getenv("BUILDKITE_BUILD_URL"),
+			Repo:          os.Getenv("BUILDKITE_REPO"),
+			Ref:           os.Getenv("BUILDKITE_BRANCH"),
+			SHA:           os.Getenv("BUILDKITE_COMMIT"),
+			PipelineID:    os.Getenv("BUILDKITE_BUILD_ID"),
+			JobID:         os.Getenv("BUILDKITE_JOB_ID"),
+			PR:            prNumber(os.Getenv("BUILDKITE_PULL_REQUEST")),
+			Author:        os.Getenv("BUILDKITE_BUILD_AUTHOR"),
+			Branch:        os.Getenv("BUILDKITE_BRANCH"),
+			CommitMessage: os.Getenv("BUILDKITE_MESSAGE"),
 		}
 	case Buddy:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("BUDDY_PIPELINE_URL"),
-			Repo:     os.Getenv("BUDDY_PROJECT_URL"),
-			Ref:      os.Getenv("BUDDY_RUN_BRANCH"),
-			SHA:      os.Getenv("BUDDY_RUN_COMMIT"),
+			Provider:      provider,
+			URL:           os.Getenv("BUDDY_PIPELINE_URL"),
+			Repo:          os.Getenv("BUDDY_PROJECT_URL"),
+			Ref:           os.Getenv("BUDDY_RUN_BRANCH"),
+			SHA:           os.Getenv("BUDDY_RUN_COMMIT"),
+			PipelineID:    os.Getenv("BUDDY_PIPELINE_ID"),
+			JobID:         os.Getenv("BUDDY_EXECUTION_ID"),
+			PR:            os.Getenv("BUDDY_EXECUTION_PULL_REQUEST_NO"),
+			Author:        os.Getenv("BUDDY_EXECUTION_REVISION_COMMITTER_NAME"),
+			Branch:        firstEnv("BUDDY_EXECUTION_BRANCH", "BUDDY_RUN_BRANCH"),
+			CommitMessage: os.Getenv("BUDDY_EXECUTION_REVISION_MESSAGE"),
 		}
 	case Circle:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("CIRCLE_BUILD_URL"),
-			Repo:     os.Getenv("CIRCLE_REPOSITORY_URL"),
-			Ref:      os.Getenv("CIRCLE_BRANCH"),
-			SHA:      os.Getenv("CIRCLE_SHA1"),
+			Provider:   provider,
+			URL:        os.Getenv("CIRCLE_BUILD_URL"),
+			Repo:       os.Getenv("CIRCLE_REPOSITORY_URL"),
+			Ref:        os.Getenv("CIRCLE_BRANCH"),
+			SHA:        os.Getenv("CIRCLE_SHA1"),
+			PipelineID: os.Getenv("CIRCLE_WORKFLOW_ID"),
+			JobID:      os.Getenv("CIRCLE_BUILD_NUM"),
+			PR:         firstNonEmpty(os.Getenv("CIRCLE_PR_NUMBER"), lastPathSegment(os.Getenv("CIRCLE_PULL_REQUEST"))),
+			Author:     os.Getenv("CIRCLE_USERNAME"),
+			Branch:     os.Getenv("CIRCLE_BRANCH"),
 		}
 	case CodeShip:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("CI_BUILD_URL"),
-			Repo:     os.Getenv("CI_REPO_NAME"),
-			Ref:      os.Getenv("CI_BRANCH"),
-			SHA:      os.Getenv("CI_COMMIT_ID"),
+			Provider:      provider,
+			URL:           os.Getenv("CI_BUILD_URL"),
+			Repo:          os.Getenv("CI_REPO_NAME"),
+			Ref:           os.Getenv("CI_BRANCH"),
+			SHA:           os.Getenv("CI_COMMIT_ID"),
+			PipelineID:    os.Getenv("CI_BUILD_ID"),
+			PR:            prNumber(os.Getenv("CI_PR_NUMBER")),
+			Author:        os.Getenv("CI_COMMITTER_NAME"),
+			Branch:        os.Getenv("CI_BRANCH"),
+			CommitMessage: os.Getenv("CI_COMMIT_MESSAGE"),
 		}
 	case Drone:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("DRONE_BUILD_LINK"),
-			Repo:     os.Getenv("DRONE_REPO"),
-			Ref:      os.Getenv("DRONE_BRANCH"),
-			SHA:      os.Getenv("DRONE_COMMIT_SHA"),
+			Provider:      provider,
+			URL:           os.Getenv("DRONE_BUILD_LINK"),
+			Repo:          os.Getenv("DRONE_REPO"),
+			Ref:           os.Getenv("DRONE_BRANCH"),
+			SHA:           os.Getenv("DRONE_COMMIT_SHA"),
+			PipelineID:    os.Getenv("DRONE_BUILD_NUMBER"),
+			JobID:         os.Getenv("DRONE_STEP_NUMBER"),
+			PR:            os.Getenv("DRONE_PULL_REQUEST"),
+			Author:        os.Getenv("DRONE_COMMIT_AUTHOR"),
+			Branch:        firstEnv("DRONE_SOURCE_BRANCH", "DRONE_BRANCH"),
+			CommitMessage: os.Getenv("DRONE_COMMIT_MESSAGE"),
 		}
 	case GitHub:
 		return CI{
-			Provider: provider,
-			URL:      fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")),
-			Repo:     os.Getenv("GITHUB_REPOSITORY"),
-			Ref:      os.Getenv("GITHUB_REF_NAME"),
-			SHA:      os.Getenv("GITHUB_SHA"),
+			Provider:   provider,
+			URL:        fmt.Sprintf("%s/%s/actions/runs/%s", os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_RUN_ID")),
+			Repo:       os.Getenv("GITHUB_REPOSITORY"),
+			Ref:        os.Getenv("GITHUB_REF_NAME"),
+			SHA:        os.Getenv("GITHUB_SHA"),
+			PipelineID: os.Getenv("GITHUB_RUN_ID"),
+			JobID:      os.Getenv("GITHUB_JOB"),
+			PR:         githubPR(os.Getenv("GITHUB_REF")),
+			Author:     os.Getenv("GITHUB_ACTOR"),
+			Branch:     firstEnv("GITHUB_HEAD_REF", "GITHUB_REF_NAME"),
 		}
 	case GitLab:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("CI_JOB_URL"),
-			Repo:     os.Getenv("CI_PROJECT_PATH"),
-			Ref:      os.Getenv("CI_COMMIT_REF_NAME"),
-			SHA:      os.Getenv("CI_COMMIT_SHA"),
+			Provider:      provider,
+			URL:           os.Getenv("CI_JOB_URL"),
+			Repo:          os.Getenv("CI_PROJECT_PATH"),
+			Ref:           os.Getenv("CI_COMMIT_REF_NAME"),
+			SHA:           os.Getenv("CI_COMMIT_SHA"),
+			PipelineID:    os.Getenv("CI_PIPELINE_ID"),
+			JobID:         os.Getenv("CI_JOB_ID"),
+			PR:            os.Getenv("CI_MERGE_REQUEST_IID"),
+			Author:        os.Getenv("CI_COMMIT_AUTHOR"),
+			Branch:        firstEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_BRANCH"),
+			CommitMessage: os.Getenv("CI_COMMIT_MESSAGE"),
 		}
 	case Gitpod:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("GITPOD_WORKSPACE_URL"),
-			Repo:     os.Getenv("GITPOD_REPO_ROOT"),
+			Provider:   provider,
+			URL:        os.Getenv("GITPOD_WORKSPACE_URL"),
+			Repo:       os.Getenv("GITPOD_REPO_ROOT"),
+			PipelineID: os.Getenv("GITPOD_WORKSPACE_ID"),
+			Author:     os.Getenv("GITPOD_GIT_USER_NAME"),
 		}
 	case Jenkins:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("JOB_URL"),
-			Repo:     os.Getenv("GIT_URL"),
-			Ref:      os.Getenv("GIT_BRANCH"),
-			SHA:      os.Getenv("GIT_COMMIT"),
+			Provider:   provider,
+			URL:        os.Getenv("JOB_URL"),
+			Repo:       os.Getenv("GIT_URL"),
+			Ref:        os.Getenv("GIT_BRANCH"),
+			SHA:        os.Getenv("GIT_COMMIT"),
+			PipelineID: os.Getenv("BUILD_ID"),
+			JobID:      os.Getenv("JOB_NAME"),
+			PR:         os.Getenv("CHANGE_ID"),
+			Author:     os.Getenv("CHANGE_AUTHOR"),
+			Branch:     firstEnv("CHANGE_BRANCH", "BRANCH_NAME", "GIT_BRANCH"),
 		}
 	case Semaphore:
 		return CI{
-			Provider: provider,
-			URL:      fmt.Sprintf("%s/workflows/%s?pipeline_id=%s", os.Getenv("SEMAPHORE_ORGANIZATION_URL"), os.Getenv("SEMAPHORE_PROJECT_ID"), os.Getenv("SEMAPHORE_JOB_ID")),
-			Repo:     os.Getenv("SEMAPHORE_GIT_URL"),
-			Ref:      os.Getenv("SEMAPHORE_GIT_WORKING_BRANCH"),
-			SHA:      os.Getenv("SEMAPHORE_GIT_SHA"),
+			Provider:   provider,
+			URL:        fmt.Sprintf("%s/workflows/%s?pipeline_id=%s", os.Getenv("SEMAPHORE_ORGANIZATION_URL"), os.Getenv("SEMAPHORE_PROJECT_ID"), os.Getenv("SEMAPHORE_JOB_ID")),
+			Repo:       os.Getenv("SEMAPHORE_GIT_URL"),
+			Ref:        os.Getenv("SEMAPHORE_GIT_WORKING_BRANCH"),
+			SHA:        os.Getenv("SEMAPHORE_GIT_SHA"),
+			PipelineID: os.Getenv("SEMAPHORE_PIPELINE_ID"),
+			JobID:      os.Getenv("SEMAPHORE_JOB_ID"),
+			PR:         os.Getenv("SEMAPHORE_GIT_PR_NUMBER"),
+			Author:     os.Getenv("SEMAPHORE_GIT_COMMIT_AUTHOR"),
+			Branch:     firstEnv("SEMAPHORE_GIT_PR_BRANCH", "SEMAPHORE_GIT_BRANCH"),
 		}
 	case Travis:
 		return CI{
-			Provider: provider,
-			URL:      os.Getenv("TRAVIS_BUILD_WEB_URL"),
-			Repo:     os.Getenv("TRAVIS_REPO_SLUG"),
-			Ref:      os.Getenv("TRAVIS_BRANCH"),
-			SHA:      os.Getenv("TRAVIS_COMMIT"),
+			Provider:      provider,
+			URL:           os.Getenv("TRAVIS_BUILD_WEB_URL"),
+			Repo:          os.Getenv("TRAVIS_REPO_SLUG"),
+			Ref:           os.Getenv("TRAVIS_BRANCH"),
+			SHA:           os.Getenv("TRAVIS_COMMIT"),
+			PipelineID:    os.Getenv("TRAVIS_BUILD_ID"),
+			JobID:         os.Getenv("TRAVIS_JOB_ID"),
+			PR:            prNumber(os.Getenv("TRAVIS_PULL_REQUEST")),
+			Branch:        firstEnv("TRAVIS_PULL_REQUEST_BRANCH", "TRAVIS_BRANCH"),
+			CommitMessage: os.Getenv("TRAVIS_COMMIT_MESSAGE"),
 		}
 	case TeamCity:
 		return CI{
-			Provider: provider,
+			Provider:   provider,
+			PipelineID: os.Getenv("BUILD_NUMBER"),
 		}
 	}
 
@@ -257,6 +351,113 @@ func GetTags() []string {
 	return tags
 }
 
+// ShortSHA returns the SHA truncated to 7 characters.
+func (c CI) ShortSHA() string {
+	return shortenSHA(c.SHA)
+}
+
+// CustomData returns the CI details as key-value pairs, omitting empty values.
+func (c CI) CustomData() map[string]string {
+	data := map[string]string{
+		"provider":      c.Provider.Name,
+		"url":           c.URL,
+		"repo":          c.Repo,
+		"ref":           c.Ref,
+		"sha":           c.SHA,
+		"pipelineId":    c.PipelineID,
+		"jobId":         c.JobID,
+		"pr":            c.PR,
+		"author":        c.Author,
+		"branch":        c.Branch,
+		"commitMessage": c.CommitMessage,
+	}
+	for k, v := range data {
+		if v == "" {
+			delete(data, k)
+		}
+	}
+
+	return data
+}
+
+// RenderBuildName renders the given build name template with the CI details,
+// e.g. "{{.Repo}}#{{.PR}}-{{.ShortSHA}}". Build names that aren't templates
+// are returned as-is.
+func RenderBuildName(name string, c CI) (string, error) {
+	if !strings.Contains(name, "{{") {
+		return name, nil
+	}
+
+	tmpl, err := template.New("build").Option("missingkey=error").Parse(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid build name template: %w", err)
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, c); err != nil {
+		return "", fmt.Errorf("failed to render build name: %w", err)
+	}
+
+	return b.String(), nil
+}
+
+// firstEnv returns the value of the first environment variable that is set
+// and not empty.
+func firstEnv(keys ...string) string {
+	for _, k := range keys {
+		if v := os.Getenv(k); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// prNumber normalizes the pull request number, since some providers set the
+// value to "false" if the build wasn't triggered by a pull request.
+func prNumber(s string) string {
+	if s == "false" {
+		return ""
+	}
+	return s
+}
+
+// githubPR extracts the pull request number from a GitHub ref, e.g.
+// "refs/pull/42/merge".
+func githubPR(ref string) string {
+	parts := strings.Split(ref, "/")
+	if len(parts) == 4 && parts[0] == "refs" && parts[1] == "pull" {
+		return parts[2]
+	}
+	return ""
+}
+
+// codebuildPR extracts the pull request number from an AWS CodeBuild webhook
+// trigger, e.g. "pr/42".
+func codebuildPR(trigger string) string {
+	if pr, ok := strings.CutPrefix(trigger, "pr/"); ok {
+		return pr
+	}
+	return ""
+}
+
+// lastPathSegment returns the last segment of a URL path, e.g. the pull
+// request number of "https://github.com/org/repo/pull/42".
+func lastPathSegment(url string) string {
+	if url == "" {
+		return ""
+	}
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
 // shortenSHA truncates a given SHA string to 7 characters.
 // If the input SHA is already shorter than 8 characters, it is returned as-is.
 func shortenSHA(sha string) string {
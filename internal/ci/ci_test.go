@@ -1,9 +1,75 @@
 package ci
 
-import "fmt"
+import (
+	"fmt"
+	"testing"
+)
 
 func ExampleGetCI_github() {
 	ci := GetCI(GitHub)
 	fmt.Println(ci.Provider.Name)
 	// Output: GitHub
 }
+
+func TestGetCI_GitHubPullRequest(t *testing.T) {
+	t.Setenv("GITHUB_RUN_ID", "1234")
+	t.Setenv("GITHUB_JOB", "e2e")
+	t.Setenv("GITHUB_REF", "refs/pull/42/merge")
+	t.Setenv("GITHUB_HEAD_REF", "feature")
+	t.Setenv("GITHUB_REF_NAME", "42/merge")
+	t.Setenv("GITHUB_ACTOR", "octocat")
+
+	c := GetCI(GitHub)
+
+	if c.PipelineID != "1234" || c.JobID != "e2e" || c.PR != "42" || c.Branch != "feature" || c.Author != "octocat" {
+		t.Errorf("GetCI() got unexpected CI details: %+v", c)
+	}
+}
+
+func TestRenderBuildName(t *testing.T) {
+	c := CI{
+		Repo: "saucelabs/saucectl",
+		PR:   "42",
+		SHA:  "0123456789abcdef",
+	}
+
+	tests := []struct {
+		name    string
+		build   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "plain name",
+			build: "nightly",
+			want:  "nightly",
+		},
+		{
+			name:  "template",
+			build: "{{.Repo}}#{{.PR}}-{{.ShortSHA}}",
+			want:  "saucelabs/saucectl#42-0123456",
+		},
+		{
+			name:    "unknown field",
+			build:   "{{.Nope}}",
+			wantErr: true,
+		},
+		{
+			name:    "malformed",
+			build:   "{{.Repo",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderBuildName(tt.build, c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RenderBuildName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RenderBuildName() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -21,3 +22,16 @@ func FullName(cmd *cobra.Command) string {
 
 	return strings.TrimSpace(name)
 }
+
+// ParseTime parses either an RFC 3339 timestamp or a duration, the latter
+// being interpreted as relative to now, i.e. "24h" means 24 hours ago.
+// An empty string results in a zero time.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
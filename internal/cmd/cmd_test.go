@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  time.Time{},
+		},
+		{
+			name:  "relative duration",
+			input: "36h",
+			want:  time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "timestamp",
+			input: "2024-01-15T08:30:00Z",
+			want:  time.Date(2024, 1, 15, 8, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "garbage",
+			input:   "yesterday",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTime(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTime() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTime() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
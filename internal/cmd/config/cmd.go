@@ -0,0 +1,35 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+var cfgFilePath string
+
+func Command(preRun func(cmd *cobra.Command, args []string)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "config",
+		Short:            "Interact with saucectl configuration files",
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if preRun != nil {
+				preRun(cmd, args)
+			}
+		},
+	}
+
+	defaultCfgPath := filepath.Join(".sauce", "config.yml")
+	cmd.PersistentFlags().StringVarP(&cfgFilePath, "config", "c", defaultCfgPath, "Specifies which config file to use")
+
+	cmd.AddCommand(
+		RenderCommand(),
+		ValidateCommand(),
+		ExplainCommand(),
+		MigrateCommand(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+func ExplainCommand() *cobra.Command {
+	var out string
+	var kind string
+
+	cmd := &cobra.Command{
+		Use:   "explain [path]",
+		Short: "Show the documentation, type and default of a config key, e.g. suites.browserName",
+		Long: `Show the documentation, type and default of a config key, e.g. suites.browserName.
+The kind of config is read from the config file, unless specified with --kind.`,
+		SilenceUsage: true,
+		Args:         cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			tracker := usage.DefaultClient
+
+			go func() {
+				tracker.Collect(
+					cmds.FullName(cmd),
+					usage.Flags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			if out != JSONOutput && out != TextOutput {
+				return errors.New("unknown output format")
+			}
+
+			var apiVersion string
+			if kind == "" {
+				d, err := config.Describe(cfgFilePath)
+				if err != nil {
+					return fmt.Errorf("unable to determine the kind of config, use --kind instead: %w", err)
+				}
+				kind, apiVersion = d.Kind, d.APIVersion
+			}
+
+			path := ""
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			return explain(out, kind, apiVersion, path)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&out, "out", "o", "text", "Output format to the console. Options: text, json.")
+	flags.StringVar(&kind, "kind", "", "The kind of config, e.g. cypress. Defaults to the kind of the config file.")
+
+	return cmd
+}
+
+func explain(outputFormat, kind, apiVersion, path string) error {
+	doc, err := config.Explain(kind, apiVersion, path)
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat {
+	case JSONOutput:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("failed to render output: %w", err)
+		}
+	case TextOutput:
+		renderKeyDoc(kind, doc)
+	}
+
+	return nil
+}
+
+func renderKeyDoc(kind string, doc config.KeyDoc) {
+	key := doc.Path
+	if key == "" {
+		key = fmt.Sprintf("(%s config)", kind)
+	}
+	fmt.Printf("KEY:         %s\n", key)
+	if doc.Type != "" {
+		fmt.Printf("TYPE:        %s\n", doc.Type)
+	}
+	if doc.Default != nil {
+		fmt.Printf("DEFAULT:     %v\n", doc.Default)
+	}
+	if len(doc.Values) > 0 {
+		var values []string
+		for _, v := range doc.Values {
+			values = append(values, fmt.Sprintf("%q", v))
+		}
+		fmt.Printf("VALUES:      %s\n", strings.Join(values, ", "))
+	}
+	if doc.Description != "" {
+		fmt.Printf("DESCRIPTION: %s\n", doc.Description)
+	}
+	if len(doc.Keys) > 0 {
+		fmt.Println("KEYS:")
+		for _, k := range doc.Keys {
+			fmt.Printf("  %s\n", k)
+		}
+	}
+}
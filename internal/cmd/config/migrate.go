@@ -0,0 +1,114 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+func MigrateCommand() *cobra.Command {
+	var out string
+	var write bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Rewrite the config from deprecated formats to the current one",
+		Long: `Rewrite the config from deprecated formats to the current one, e.g. Cypress v1alpha configs or deprecated fields.
+Comments are preserved. The migrated config is printed, unless --write is set.
+With --out json, the changes and the migrated config are printed as a single JSON document.`,
+		SilenceUsage: true,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			tracker := usage.DefaultClient
+
+			go func() {
+				tracker.Collect(
+					cmds.FullName(cmd),
+					usage.Flags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if out != JSONOutput && out != TextOutput {
+				return errors.New("unknown output format")
+			}
+			return migrate(out, write)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&out, "out", "o", "text", "Output format of the applied changes. Options: text, json.")
+	flags.BoolVarP(&write, "write", "w", false, "Overwrite the config file instead of printing the migrated config.")
+
+	return cmd
+}
+
+// migrateOutput is the JSON output of the migrate command.
+type migrateOutput struct {
+	Changes []config.Change `json:"changes"`
+	// Config is the migrated config, unless it was written to the file.
+	Config string `json:"config,omitempty"`
+}
+
+func migrate(outputFormat string, write bool) error {
+	d, err := config.Describe(cfgFilePath)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(cfgFilePath)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(cfgFilePath)
+	if err != nil {
+		return err
+	}
+
+	migrated, changes, err := config.Migrate(b, d)
+	if err != nil {
+		return fmt.Errorf("failed to migrate config: %w", err)
+	}
+
+	if write && len(changes) > 0 {
+		if err := os.WriteFile(cfgFilePath, migrated, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+
+	switch outputFormat {
+	case JSONOutput:
+		o := migrateOutput{Changes: changes}
+		if o.Changes == nil {
+			o.Changes = []config.Change{}
+		}
+		if !write {
+			o.Config = string(migrated)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(o); err != nil {
+			return fmt.Errorf("failed to render output: %w", err)
+		}
+	case TextOutput:
+		if !write {
+			fmt.Print(string(migrated))
+		}
+		// The migrated config may be printed to stdout, so changes are
+		// reported on stderr.
+		if len(changes) == 0 {
+			fmt.Fprintf(os.Stderr, "%s is up to date.\n", cfgFilePath)
+		}
+		for _, c := range changes {
+			fmt.Fprintf(os.Stderr, "- %s\n", c)
+		}
+	}
+
+	return nil
+}
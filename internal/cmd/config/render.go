@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+func RenderCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "render",
+		Short:        "Print the config with all extends and include directives resolved",
+		SilenceUsage: true,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			tracker := usage.DefaultClient
+
+			go func() {
+				tracker.Collect(
+					cmds.FullName(cmd),
+					usage.Flags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			b, err := config.Resolve(cfgFilePath)
+			if err != nil {
+				return fmt.Errorf("failed to resolve config: %w", err)
+			}
+
+			fmt.Print(string(b))
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+const (
+	JSONOutput = "json"
+	TextOutput = "text"
+)
+
+func ValidateCommand() *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:          "validate",
+		Short:        "Validate the config against the schema of this saucectl version. Works offline.",
+		SilenceUsage: true,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			tracker := usage.DefaultClient
+
+			go func() {
+				tracker.Collect(
+					cmds.FullName(cmd),
+					usage.Flags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if out != JSONOutput && out != TextOutput {
+				return errors.New("unknown output format")
+			}
+			return validate(out)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&out, "out", "o", "text", "Output format to the console. Options: text, json.")
+
+	return cmd
+}
+
+func validate(outputFormat string) error {
+	issues, err := config.CheckSchema(cfgFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to validate config: %w", err)
+	}
+
+	switch outputFormat {
+	case JSONOutput:
+		if issues == nil {
+			issues = []config.SchemaIssue{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(issues); err != nil {
+			return fmt.Errorf("failed to render output: %w", err)
+		}
+	case TextOutput:
+		if len(issues) == 0 {
+			fmt.Printf("%s is valid.\n", cfgFilePath)
+		}
+		for _, i := range issues {
+			fmt.Println(i)
+		}
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d issue(s) in %s", len(issues), cfgFilePath)
+	}
+	return nil
+}
@@ -691,7 +691,8 @@ func checkEmulators(vmds []vmd.VirtualDevice, emulator config.Emulator) (config.
 		return config.Emulator{}, []error{fmt.Errorf("emulator: %s does not exists", emulator.Name)}
 	}
 	for _, p := range emulator.PlatformVersions {
-		if !sliceContainsString(d.OSVersion, p) {
+		// Version constraints, e.g. "latest", are kept as is and resolved at run time.
+		if _, err := vmd.ResolveVersions(d.OSVersion, []string{p}); err != nil {
 			errs = append(errs, fmt.Errorf("emulator: %s does not support platform %s", emulator.Name, p))
 		}
 	}
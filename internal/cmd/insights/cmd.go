@@ -0,0 +1,59 @@
+package insights
+
+import (
+	"errors"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/credentials"
+	"github.com/saucelabs/saucectl/internal/http"
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/region"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	insightsService insights.Service
+	insightsTimeout = 1 * time.Minute
+)
+
+func Command(preRun func(cmd *cobra.Command, args []string)) *cobra.Command {
+	var regio string
+
+	cmd := &cobra.Command{
+		Use:              "insights",
+		Short:            "Interact with test insights",
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if preRun != nil {
+				preRun(cmd, args)
+			}
+
+			reg := region.FromString(regio)
+			if reg == region.None {
+				return errors.New("invalid region")
+			}
+			if reg == region.Staging {
+				usage.DefaultClient.Enabled = false
+			}
+
+			creds := credentials.Get()
+			url := reg.APIBaseURL()
+			insightsClient := http.NewInsightsService(url, creds, insightsTimeout)
+
+			insightsService = &insightsClient
+
+			return nil
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVarP(&regio, "region", "r", "us-west-1", "The Sauce Labs region. Options: us-west-1, eu-central-1.")
+
+	cmd.AddCommand(
+		TestsCommand(),
+	)
+
+	return cmd
+}
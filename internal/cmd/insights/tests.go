@@ -0,0 +1,183 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/tables"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+const (
+	JSONOutput = "json"
+	TextOutput = "text"
+)
+
+func TestsCommand() *cobra.Command {
+	var out string
+	var suite string
+	var since string
+	var until string
+	var lastN int
+	var top int
+	opts := insights.ListTestRunsOptions{}
+
+	cmd := &cobra.Command{
+		Use:          "tests",
+		Short:        "Show the history of tests, e.g. failure rate and flakiness",
+		SilenceUsage: true,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			tracker := usage.DefaultClient
+
+			go func() {
+				tracker.Collect(
+					cmds.FullName(cmd),
+					usage.Flags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if out != JSONOutput && out != TextOutput {
+				return errors.New("unknown output format")
+			}
+			if lastN < 0 {
+				return errors.New("invalid number of last results")
+			}
+			if top < 0 {
+				return errors.New("invalid top")
+			}
+			if opts.Limit <= 0 {
+				return errors.New("invalid limit")
+			}
+
+			now := time.Now()
+			var err error
+			if opts.Since, err = cmds.ParseTime(since, now); err != nil {
+				return fmt.Errorf("invalid since: %w", err)
+			}
+			if opts.Until, err = cmds.ParseTime(until, now); err != nil {
+				return fmt.Errorf("invalid until: %w", err)
+			}
+			if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+				return errors.New("until must not be before since")
+			}
+
+			return tests(cmd.Context(), out, opts, suite, lastN, top)
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.StringVarP(&out, "out", "o", "text", "Output format to the console. Options: text, json.")
+	flags.StringVar(&opts.Name, "name", "", "Only show tests with the given name.")
+	flags.StringVar(&suite, "suite", "", "Only show tests of suites whose name contains the given text.")
+	flags.StringVar(&since, "since", "168h", "Only consider test runs started after this time. Accepts RFC 3339 timestamps (2006-01-02T15:04:05Z) or durations relative to now (24h).")
+	flags.StringVar(&until, "until", "", "Only consider test runs started before this time. Accepts RFC 3339 timestamps (2006-01-02T15:04:05Z) or durations relative to now (24h).")
+	flags.IntVar(&opts.Limit, "limit", 1000, "Maximum number of test runs to analyze.")
+	flags.IntVar(&lastN, "last", 5, "Number of most recent results to show per test.")
+	flags.IntVar(&top, "top", 0, "Only show the given number of worst tests. Shows all tests if 0.")
+
+	return cmd
+}
+
+func tests(ctx context.Context, format string, opts insights.ListTestRunsOptions, suite string, lastN, top int) error {
+	runs, err := listTestRuns(ctx, opts, suite)
+	if err != nil {
+		return fmt.Errorf("failed to get test runs: %w", err)
+	}
+
+	stats := insights.Summarize(runs, lastN)
+	if top > 0 && len(stats) > top {
+		stats = stats[:top]
+	}
+
+	switch format {
+	case JSONOutput:
+		if err := json.NewEncoder(os.Stdout).Encode(stats); err != nil {
+			return fmt.Errorf("failed to render output: %w", err)
+		}
+	case TextOutput:
+		renderTable(stats)
+	}
+
+	return nil
+}
+
+// listTestRuns returns up to opts.Limit test runs of suites whose name contains
+// suite. The API can't filter by suite, so test runs are fetched page by page
+// until enough of them match or no more test runs are left.
+func listTestRuns(ctx context.Context, opts insights.ListTestRunsOptions, suite string) ([]insights.TestRun, error) {
+	if suite == "" {
+		return insightsService.ListTestRuns(ctx, opts)
+	}
+
+	var runs []insights.TestRun
+	seen := map[string]bool{}
+	for {
+		page, err := insightsService.ListTestRuns(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		fresh := false
+		for _, r := range page {
+			if seen[r.ID] {
+				continue
+			}
+			seen[r.ID] = true
+			fresh = true
+			if r.SauceJob != nil && strings.Contains(r.SauceJob.Name, suite) {
+				runs = append(runs, r)
+			}
+			if len(runs) == opts.Limit {
+				return runs, nil
+			}
+		}
+		// A partial page means there's nothing left to fetch. A page without
+		// any new test runs means the offset wasn't applied.
+		if !fresh || len(page) < opts.Limit {
+			return runs, nil
+		}
+		opts.Offset += len(page)
+	}
+}
+
+func renderTable(stats []insights.TestStats) {
+	if len(stats) == 0 {
+		println("Cannot find any test runs")
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetStyle(tables.DefaultTableStyle)
+	t.SuppressEmptyColumns()
+
+	t.AppendHeader(table.Row{
+		"Suite", "Test", "Runs", "Failure Rate", "Flakiness", "Avg. Duration", "Last Results",
+	})
+
+	for _, s := range stats {
+		// the order of values must match the order of the header
+		t.AppendRow(table.Row{
+			s.Suite,
+			s.Name,
+			s.Runs,
+			fmt.Sprintf("%.1f%%", s.FailureRate*100),
+			fmt.Sprintf("%.1f%%", s.Flakiness*100),
+			s.AvgDuration.String(),
+			strings.Join(s.LastResults, " "),
+		})
+	}
+	t.AppendFooter(table.Row{
+		fmt.Sprintf("%d tests in total", len(stats)),
+	})
+
+	fmt.Println(t.Render())
+}
@@ -0,0 +1,113 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpServices "github.com/saucelabs/saucectl/internal/http"
+	"github.com/saucelabs/saucectl/internal/iam"
+	"github.com/saucelabs/saucectl/internal/insights"
+)
+
+type pagedService struct {
+	insights.Service
+	runs    []insights.TestRun
+	offsets []int
+}
+
+func (s *pagedService) ListTestRuns(_ context.Context, opts insights.ListTestRunsOptions) ([]insights.TestRun, error) {
+	s.offsets = append(s.offsets, opts.Offset)
+	end := min(opts.Offset+opts.Limit, len(s.runs))
+	return s.runs[min(opts.Offset, end):end], nil
+}
+
+func Test_listTestRuns(t *testing.T) {
+	var runs []insights.TestRun
+	for i := 0; i < 7; i++ {
+		suite := "firefox"
+		if i%3 == 0 {
+			suite = "chrome"
+		}
+		runs = append(runs, insights.TestRun{ID: fmt.Sprint(i), Name: fmt.Sprintf("test %d", i), SauceJob: &insights.Job{Name: suite}})
+	}
+
+	tests := []struct {
+		name        string
+		suite       string
+		limit       int
+		wantNames   []string
+		wantOffsets []int
+	}{
+		{
+			name:        "without suite",
+			limit:       2,
+			wantNames:   []string{"test 0", "test 1"},
+			wantOffsets: []int{0},
+		},
+		{
+			name:        "pages until limit is reached",
+			suite:       "chrome",
+			limit:       2,
+			wantNames:   []string{"test 0", "test 3"},
+			wantOffsets: []int{0, 2},
+		},
+		{
+			name:        "pages until test runs are exhausted",
+			suite:       "chrome",
+			limit:       4,
+			wantNames:   []string{"test 0", "test 3", "test 6"},
+			wantOffsets: []int{0, 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &pagedService{runs: runs}
+			insightsService = svc
+
+			got, err := listTestRuns(context.Background(), insights.ListTestRunsOptions{Limit: tt.limit}, tt.suite)
+			if err != nil {
+				t.Fatalf("listTestRuns() error = %v", err)
+			}
+
+			var names []string
+			for _, r := range got {
+				names = append(names, r.Name)
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tt.wantNames) {
+				t.Errorf("listTestRuns() got = %v, want %v", names, tt.wantNames)
+			}
+			if fmt.Sprint(svc.offsets) != fmt.Sprint(tt.wantOffsets) {
+				t.Errorf("offsets got = %v, want %v", svc.offsets, tt.wantOffsets)
+			}
+		})
+	}
+}
+
+func Test_listTestRuns_IgnoredOffset(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, fmt.Sprintf("limit=%s offset=%s", q.Get("limit"), q.Get("offset")))
+		// The same page is returned regardless of the offset.
+		_, _ = w.Write([]byte(`{"items":[{"id":"1","name":"login","sauce_job":{"name":"firefox"}},{"id":"2","name":"logout","sauce_job":{"name":"chrome"}}]}`))
+	}))
+	defer ts.Close()
+
+	svc := httpServices.NewInsightsService(ts.URL, iam.Credentials{}, time.Second)
+	insightsService = &svc
+
+	got, err := listTestRuns(context.Background(), insights.ListTestRunsOptions{Limit: 2}, "chrome")
+	if err != nil {
+		t.Fatalf("listTestRuns() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Name != "logout" {
+		t.Errorf("listTestRuns() got = %v, want only logout", got)
+	}
+	if want := []string{"limit=2 offset=", "limit=2 offset=2"}; fmt.Sprint(queries) != fmt.Sprint(want) {
+		t.Errorf("queries got = %v, want %v", queries, want)
+	}
+}
@@ -10,15 +10,21 @@ import (
 	"github.com/saucelabs/saucectl/internal/http"
 	"github.com/saucelabs/saucectl/internal/iam"
 	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/job"
 	"github.com/saucelabs/saucectl/internal/region"
+	"github.com/saucelabs/saucectl/internal/saucecloud"
 	"github.com/spf13/cobra"
 )
 
 var (
-	jobService      insights.Service
-	userService     iam.UserService
-	insightsTimeout = 1 * time.Minute
-	iamTimeout      = 1 * time.Minute
+	jobService          insights.Service
+	userService         iam.UserService
+	artifactService     job.Service
+	insightsTimeout     = 1 * time.Minute
+	iamTimeout          = 1 * time.Minute
+	rdcTimeout          = 1 * time.Minute
+	restoTimeout        = 1 * time.Minute
+	testComposerTimeout = 1 * time.Minute
 )
 
 func Command(preRun func(cmd *cobra.Command, args []string)) *cobra.Command {
@@ -49,6 +55,17 @@ func Command(preRun func(cmd *cobra.Command, args []string)) *cobra.Command {
 
 			jobService = &insightsClient
 			userService = &iamClient
+			artifactService = saucecloud.JobService{
+				Resto: http.NewResto(
+					reg, creds.Username, creds.AccessKey, restoTimeout,
+				),
+				RDC: http.NewRDCService(
+					reg, creds.Username, creds.AccessKey, rdcTimeout,
+				),
+				TestComposer: http.NewTestComposer(
+					url, creds, testComposerTimeout,
+				),
+			}
 
 			return nil
 		},
@@ -58,6 +75,7 @@ func Command(preRun func(cmd *cobra.Command, args []string)) *cobra.Command {
 	flags.StringVarP(&regio, "region", "r", "us-west-1", "The Sauce Labs region. Options: us-west-1, eu-central-1.")
 
 	cmd.AddCommand(
+		CompareCommand(),
 		GetCommand(),
 		ListCommand(),
 	)
@@ -0,0 +1,286 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/junit"
+	"github.com/saucelabs/saucectl/internal/retry"
+	"github.com/saucelabs/saucectl/internal/saucereport"
+	"github.com/saucelabs/saucectl/internal/tables"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+// Comparison represents the differences between two jobs.
+type Comparison struct {
+	JobA          job.Job         `json:"jobA"`
+	JobB          job.Job         `json:"jobB"`
+	Attributes    []AttributeDiff `json:"attributes"`
+	Tests         []TestDiff      `json:"tests"`
+	DurationDelta time.Duration   `json:"durationDelta"`
+}
+
+// AttributeDiff represents a job attribute (e.g. browser version) that differs
+// between two jobs.
+type AttributeDiff struct {
+	Name string `json:"name"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+}
+
+// TestDiff represents a test whose outcome or duration differs between two
+// jobs. An empty status indicates that the test is absent from that job.
+type TestDiff struct {
+	Name          string        `json:"name"`
+	StatusA       string        `json:"statusA"`
+	StatusB       string        `json:"statusB"`
+	DurationDelta time.Duration `json:"durationDelta"`
+}
+
+// StatusChanged returns true if the test outcome differs between both jobs.
+func (d TestDiff) StatusChanged() bool {
+	return d.StatusA != d.StatusB
+}
+
+func CompareCommand() *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:          "compare <jobA> <jobB>",
+		Short:        "Compare two jobs",
+		SilenceUsage: true,
+		Args: func(_ *cobra.Command, args []string) error {
+			if len(args) != 2 || args[0] == "" || args[1] == "" {
+				return errors.New("two job IDs must be specified")
+			}
+			return nil
+		},
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			tracker := usage.DefaultClient
+
+			go func() {
+				tracker.Collect(
+					cmds.FullName(cmd),
+					usage.Flags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if out != JSONOutput && out != TextOutput {
+				return errors.New("unknown output format")
+			}
+			return compare(cmd.Context(), args[0], args[1], out)
+		},
+	}
+	flags := cmd.PersistentFlags()
+	flags.StringVarP(&out, "out", "o", "text", "Output format to the console. Options: text, json.")
+
+	return cmd
+}
+
+func compare(ctx context.Context, idA, idB, outputFormat string) error {
+	jobA, err := jobService.ReadJob(ctx, idA)
+	if err != nil {
+		return fmt.Errorf("failed to get job %s: %w", idA, err)
+	}
+	jobB, err := jobService.ReadJob(ctx, idB)
+	if err != nil {
+		return fmt.Errorf("failed to get job %s: %w", idB, err)
+	}
+
+	testsA, err := testResults(ctx, jobA)
+	if err != nil {
+		return fmt.Errorf("failed to get test results for job %s: %w", idA, err)
+	}
+	testsB, err := testResults(ctx, jobB)
+	if err != nil {
+		return fmt.Errorf("failed to get test results for job %s: %w", idB, err)
+	}
+
+	c := compareJobs(jobA, jobB, testsA, testsB)
+
+	switch outputFormat {
+	case JSONOutput:
+		if err := renderJSON(c); err != nil {
+			return fmt.Errorf("failed to render output: %w", err)
+		}
+	case TextOutput:
+		renderComparison(c)
+	}
+
+	return nil
+}
+
+// testResults fetches the test report of the given job and returns its test
+// runs. The sauce test report is preferred over the JUnit report, since it's
+// more detailed. Jobs without either report yield no results.
+func testResults(ctx context.Context, j job.Job) ([]insights.TestRun, error) {
+	names, err := artifactService.ArtifactNames(ctx, j.ID, j.IsRDC)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range names {
+		if n != saucereport.FileName {
+			continue
+		}
+		content, err := artifactService.Artifact(ctx, j.ID, n, j.IsRDC, retry.CreateOptions())
+		if err != nil {
+			return nil, err
+		}
+		report, err := saucereport.Parse(content)
+		if err != nil {
+			return nil, err
+		}
+		return reportTests(report.Suites, ""), nil
+	}
+
+	for _, n := range names {
+		if n != junit.FileName {
+			continue
+		}
+		content, err := artifactService.Artifact(ctx, j.ID, n, j.IsRDC, retry.CreateOptions())
+		if err != nil {
+			return nil, err
+		}
+		report, err := junit.Parse(content)
+		if err != nil {
+			return nil, err
+		}
+		return insights.FromJUnit(report, j.ID, j.Name, insights.Details{}, j.IsRDC), nil
+	}
+
+	return nil, nil
+}
+
+// compareJobs compares the metadata and test results of two jobs. Only
+// attributes and tests that differ are part of the result.
+func compareJobs(a, b job.Job, testsA, testsB []insights.TestRun) Comparison {
+	c := Comparison{
+		JobA:          a,
+		JobB:          b,
+		DurationDelta: b.Duration - a.Duration,
+	}
+
+	attrs := []AttributeDiff{
+		{Name: "Platform", A: a.OS, B: b.OS},
+		{Name: "Platform Version", A: a.OSVersion, B: b.OSVersion},
+		{Name: "Browser", A: a.BrowserName, B: b.BrowserName},
+		{Name: "Browser Version", A: a.BrowserVersion, B: b.BrowserVersion},
+		{Name: "Device", A: a.DeviceName, B: b.DeviceName},
+		{Name: "Framework", A: a.Framework, B: b.Framework},
+		{Name: "Status", A: a.TotalStatus(), B: b.TotalStatus()},
+	}
+	for _, attr := range attrs {
+		if attr.A != attr.B {
+			c.Attributes = append(c.Attributes, attr)
+		}
+	}
+
+	diffs := map[string]*TestDiff{}
+	for _, t := range testsA {
+		diffs[t.Name] = &TestDiff{
+			Name:          t.Name,
+			StatusA:       t.Status,
+			DurationDelta: -time.Duration(t.Duration) * time.Second,
+		}
+	}
+	for _, t := range testsB {
+		d, ok := diffs[t.Name]
+		if !ok {
+			d = &TestDiff{Name: t.Name}
+			diffs[t.Name] = d
+		}
+		d.StatusB = t.Status
+		d.DurationDelta += time.Duration(t.Duration) * time.Second
+	}
+
+	for _, d := range diffs {
+		if d.StatusChanged() || d.DurationDelta != 0 {
+			c.Tests = append(c.Tests, *d)
+		}
+	}
+	sort.Slice(c.Tests, func(i, j int) bool {
+		return c.Tests[i].Name < c.Tests[j].Name
+	})
+
+	return c
+}
+
+// suitePathSeparator separates the names of nested suites and tests.
+const suitePathSeparator = " > "
+
+// reportTests returns the tests of the given sauce report suites. Since test
+// names are only unique within a suite, each test is named after the path of
+// its suites, e.g. "cart > checkout > pays".
+func reportTests(suites []saucereport.Suite, parent string) []insights.TestRun {
+	var runs []insights.TestRun
+	for _, s := range suites {
+		path := s.Name
+		if parent != "" {
+			path = parent + suitePathSeparator + s.Name
+		}
+		for _, t := range s.Tests {
+			runs = append(runs, insights.TestRun{
+				Name:     path + suitePathSeparator + t.Name,
+				Status:   t.Status,
+				Duration: t.Duration,
+			})
+		}
+		runs = append(runs, reportTests(s.Suites, path)...)
+	}
+	return runs
+}
+
+func renderComparison(c Comparison) {
+	t := table.NewWriter()
+	t.SetStyle(tables.DefaultTableStyle)
+
+	t.AppendHeader(table.Row{"Attribute", c.JobA.ID, c.JobB.ID})
+	for _, attr := range c.Attributes {
+		t.AppendRow(table.Row{attr.Name, attr.A, attr.B})
+	}
+	t.AppendFooter(table.Row{"Duration Delta", "", c.DurationDelta.String()})
+
+	fmt.Println(t.Render())
+
+	if len(c.Tests) == 0 {
+		println("No differences in test results")
+		return
+	}
+
+	t = table.NewWriter()
+	t.SetStyle(tables.DefaultTableStyle)
+
+	t.AppendHeader(table.Row{"Test", c.JobA.ID, c.JobB.ID, "Duration Delta"})
+	for _, d := range c.Tests {
+		// the order of values must match the order of the header
+		t.AppendRow(table.Row{
+			d.Name,
+			orMissing(d.StatusA),
+			orMissing(d.StatusB),
+			d.DurationDelta.String(),
+		})
+	}
+	t.AppendFooter(table.Row{
+		fmt.Sprintf("%d tests differ", len(c.Tests)),
+	})
+
+	fmt.Println(t.Render())
+}
+
+func orMissing(status string) string {
+	if status == "" {
+		return "missing"
+	}
+	return status
+}
@@ -0,0 +1,93 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/saucereport"
+)
+
+func Test_compareJobs(t *testing.T) {
+	a := job.Job{
+		ID:             "a",
+		Status:         job.StateComplete,
+		Passed:         true,
+		OS:             "Windows 11",
+		BrowserName:    "chrome",
+		BrowserVersion: "120",
+		Framework:      "playwright",
+		Duration:       60 * time.Second,
+	}
+	b := job.Job{
+		ID:             "b",
+		Status:         job.StateComplete,
+		Passed:         false,
+		OS:             "Windows 11",
+		BrowserName:    "chrome",
+		BrowserVersion: "121",
+		Framework:      "playwright",
+		Duration:       75 * time.Second,
+	}
+	testsA := []insights.TestRun{
+		{Name: "login", Status: insights.StatePassed, Duration: 10},
+		{Name: "logout", Status: insights.StatePassed, Duration: 5},
+		{Name: "removed", Status: insights.StatePassed, Duration: 1},
+		{Name: "cart > open", Status: insights.StatePassed, Duration: 3},
+		{Name: "checkout > open", Status: insights.StatePassed, Duration: 4},
+	}
+	testsB := []insights.TestRun{
+		{Name: "login", Status: insights.StateFailed, Duration: 12},
+		{Name: "logout", Status: insights.StatePassed, Duration: 5},
+		{Name: "added", Status: insights.StatePassed, Duration: 2},
+		{Name: "cart > open", Status: insights.StatePassed, Duration: 3},
+		{Name: "checkout > open", Status: insights.StateFailed, Duration: 4},
+	}
+
+	got := compareJobs(a, b, testsA, testsB)
+
+	want := Comparison{
+		JobA: a,
+		JobB: b,
+		Attributes: []AttributeDiff{
+			{Name: "Browser Version", A: "120", B: "121"},
+			{Name: "Status", A: job.StatePassed, B: job.StateFailed},
+		},
+		Tests: []TestDiff{
+			{Name: "added", StatusB: insights.StatePassed, DurationDelta: 2 * time.Second},
+			{Name: "checkout > open", StatusA: insights.StatePassed, StatusB: insights.StateFailed},
+			{Name: "login", StatusA: insights.StatePassed, StatusB: insights.StateFailed, DurationDelta: 2 * time.Second},
+			{Name: "removed", StatusA: insights.StatePassed, DurationDelta: -1 * time.Second},
+		},
+		DurationDelta: 15 * time.Second,
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("compareJobs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func Test_reportTests(t *testing.T) {
+	suites := []saucereport.Suite{
+		{
+			Name:  "cart",
+			Tests: []saucereport.Test{{Name: "open", Status: saucereport.StatusPassed, Duration: 3}},
+			Suites: []saucereport.Suite{
+				{
+					Name:  "checkout",
+					Tests: []saucereport.Test{{Name: "open", Status: saucereport.StatusFailed, Duration: 4}},
+				},
+			},
+		},
+	}
+
+	want := []insights.TestRun{
+		{Name: "cart > open", Status: insights.StatePassed, Duration: 3},
+		{Name: "cart > checkout > open", Status: insights.StateFailed, Duration: 4},
+	}
+	if diff := cmp.Diff(want, reportTests(suites, "")); diff != "" {
+		t.Errorf("reportTests() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -2,11 +2,13 @@ package jobs
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
@@ -20,6 +22,7 @@ import (
 const (
 	JSONOutput = "json"
 	TextOutput = "text"
+	CSVOutput  = "csv"
 )
 
 func ListCommand() *cobra.Command {
@@ -28,6 +31,10 @@ func ListCommand() *cobra.Command {
 	var size int
 	var status string
 	var jobSource string
+	var all bool
+	var since string
+	var until string
+	opts := insights.ListJobsOptions{}
 
 	cmd := &cobra.Command{
 		Use: "list",
@@ -54,7 +61,10 @@ func ListCommand() *cobra.Command {
 			if size < 0 {
 				return errors.New("invalid size")
 			}
-			if out != JSONOutput && out != TextOutput {
+			if all && size == 0 {
+				return errors.New("size must be greater than 0 when listing all pages")
+			}
+			if out != JSONOutput && out != TextOutput && out != CSVOutput {
 				return errors.New("unknown output format")
 			}
 			var isStatusValid bool
@@ -73,34 +83,51 @@ func ListCommand() *cobra.Command {
 				return errors.New("invalid job resource. Options: vdc, rdc, api")
 			}
 
-			return list(cmd.Context(), out, page, size, status, job.Source(jobSource))
+			now := time.Now()
+			var err error
+			if opts.Since, err = cmds.ParseTime(since, now); err != nil {
+				return fmt.Errorf("invalid since: %w", err)
+			}
+			if opts.Until, err = cmds.ParseTime(until, now); err != nil {
+				return fmt.Errorf("invalid until: %w", err)
+			}
+			if !opts.Since.IsZero() && !opts.Until.IsZero() && opts.Until.Before(opts.Since) {
+				return errors.New("until must not be before since")
+			}
+
+			opts.Page = page
+			opts.Size = size
+			opts.Status = status
+			opts.Source = src
+
+			return list(cmd.Context(), out, opts, all)
 		},
 	}
 	flags := cmd.PersistentFlags()
-	flags.StringVarP(&out, "out", "o", "text", "Output format to the console. Options: text, json.")
+	flags.StringVarP(&out, "out", "o", "text", "Output format to the console. Options: text, json, csv.")
 	flags.IntVarP(&page, "page", "p", 0, "Page for pagination. Default is 0.")
 	flags.IntVarP(&size, "size", "s", 20, "Per page for pagination. Default is 20.")
 	flags.StringVar(&status, "status", "", "Filter job using status. Options: passed, failed, error, complete, in progress, queued.")
 	flags.StringVar(&jobSource, "source", "", "Job source from saucelabs. Options: vdc, rdc, api.")
+	flags.StringVar(&opts.Name, "name", "", "Filter jobs whose name contains the given text.")
+	flags.StringVar(&opts.Build, "build", "", "Filter jobs by build name.")
+	flags.StringSliceVar(&opts.Tags, "tags", []string{}, "Filter jobs by tags. Jobs must have all of the given tags.")
+	flags.StringVar(&opts.Framework, "framework", "", "Filter jobs by framework, e.g. playwright, cypress, espresso.")
+	flags.StringVar(&since, "since", "", "Only list jobs started after this time. Accepts RFC 3339 timestamps (2006-01-02T15:04:05Z) or durations relative to now (24h).")
+	flags.StringVar(&until, "until", "", "Only list jobs started before this time. Accepts RFC 3339 timestamps (2006-01-02T15:04:05Z) or durations relative to now (24h).")
+	flags.BoolVar(&all, "all", false, "Fetch all pages, starting at --page.")
 
 	return cmd
 }
 
-func list(ctx context.Context, format string, page int, size int, status string, source job.Source) error {
+func list(ctx context.Context, format string, opts insights.ListJobsOptions, all bool) error {
 	user, err := userService.User(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
+	opts.UserID = user.ID
 
-	opts := insights.ListJobsOptions{
-		UserID: user.ID,
-		Page:   page,
-		Size:   size,
-		Status: status,
-		Source: source,
-	}
-
-	jobs, err := jobService.ListJobs(ctx, opts)
+	jobs, err := listJobs(ctx, opts, all)
 	if err != nil {
 		return fmt.Errorf("failed to get jobs: %w", err)
 	}
@@ -110,6 +137,10 @@ func list(ctx context.Context, format string, page int, size int, status string,
 		if err := renderJSON(jobs); err != nil {
 			return fmt.Errorf("failed to render output: %w", err)
 		}
+	case "csv":
+		if err := renderCSV(jobs); err != nil {
+			return fmt.Errorf("failed to render output: %w", err)
+		}
 	case "text":
 		renderTable(jobs)
 	}
@@ -117,6 +148,34 @@ func list(ctx context.Context, format string, page int, size int, status string,
 	return nil
 }
 
+// listJobs returns the jobs of the requested page, or of all pages starting at
+// the requested one if all is set.
+func listJobs(ctx context.Context, opts insights.ListJobsOptions, all bool) ([]job.Job, error) {
+	var jobs []job.Job
+	seen := map[string]bool{}
+	for {
+		page, err := jobService.ListJobs(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		fresh := false
+		for _, j := range page {
+			if !seen[j.ID] {
+				seen[j.ID] = true
+				fresh = true
+				jobs = append(jobs, j)
+			}
+		}
+
+		// A partial page means there's nothing left to fetch. A page without
+		// any new jobs means the page wasn't applied.
+		if !all || !fresh || len(page) < opts.Size {
+			return jobs, nil
+		}
+		opts.Page++
+	}
+}
+
 func renderTable(jobs []job.Job) {
 	if len(jobs) == 0 {
 		println("Cannot find any jobs")
@@ -151,6 +210,34 @@ func renderTable(jobs []job.Job) {
 	fmt.Println(t.Render())
 }
 
+func renderCSV(jobs []job.Job) error {
+	w := csv.NewWriter(os.Stdout)
+
+	if err := w.Write([]string{
+		"ID", "Name", "Status", "Platform", "Framework", "Browser", "Device",
+	}); err != nil {
+		return err
+	}
+
+	for _, item := range jobs {
+		// the order of values must match the order of the header
+		if err := w.Write([]string{
+			item.ID,
+			item.Name,
+			item.Status,
+			item.OS,
+			item.Framework,
+			item.BrowserName,
+			item.DeviceName,
+		}); err != nil {
+			return err
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
 func renderJSON(val any) error {
 	return json.NewEncoder(os.Stdout).Encode(val)
 }
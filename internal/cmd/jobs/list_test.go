@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	httpServices "github.com/saucelabs/saucectl/internal/http"
+	"github.com/saucelabs/saucectl/internal/iam"
+	"github.com/saucelabs/saucectl/internal/insights"
+)
+
+func Test_listJobs_IgnoredPage(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, fmt.Sprintf("page=%s size=%s", q.Get("page"), q.Get("size")))
+		// The same page is returned regardless of the requested one.
+		_, _ = w.Write([]byte(`{"jobs":[{"id":"1","name":"login"},{"id":"2","name":"logout"}]}`))
+	}))
+	defer ts.Close()
+
+	svc := httpServices.NewInsightsService(ts.URL, iam.Credentials{}, time.Second)
+	jobService = &svc
+
+	got, err := listJobs(context.Background(), insights.ListJobsOptions{Page: 3, Size: 2}, true)
+	if err != nil {
+		t.Fatalf("listJobs() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("listJobs() got %d jobs, want 2", len(got))
+	}
+	if want := []string{"page=3 size=2", "page=4 size=2"}; fmt.Sprint(queries) != fmt.Sprint(want) {
+		t.Errorf("queries got = %v, want %v", queries, want)
+	}
+}
@@ -1,16 +1,18 @@
 package run
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"github.com/rs/zerolog/log"
-	"github.com/saucelabs/saucectl/internal/ci"
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/cucumber"
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/http"
+	"github.com/saucelabs/saucectl/internal/insights"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/saucecloud"
 	"github.com/saucelabs/saucectl/internal/saucecloud/retry"
@@ -89,8 +91,17 @@ func runCucumber(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 	}
 
 	regio := region.FromString(p.Sauce.Region)
-	if !gFlags.noAutoTagging {
-		p.Sauce.Metadata.Tags = append(p.Sauce.Metadata.Tags, ci.GetTags()...)
+	// Dry runs fetch the history as well, so that they print the same shard
+	// plan as an actual run.
+	if p.ShardsByDuration() {
+		p.ScenarioDurations = fetchScenarioDurations(cmd.Context(), regio, p.Suites)
+	}
+	if err := cucumber.ShardSuites(&p); err != nil {
+		return 1, err
+	}
+
+	if err := applyCIMetadata(&p.Sauce.Metadata); err != nil {
+		return 1, err
 	}
 
 	tracker := usage.DefaultClient
@@ -155,7 +166,9 @@ func runCucumber(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 			ShowConsoleLog:         p.ShowConsoleLog,
 			Reporters:              createReporters(p.Reporters, gFlags.async),
 			Async:                  gFlags.async,
-			FailFast:               gFlags.failFast,
+			FailFast:               failFast(p.Sauce.FailFast),
+			ConfigUpload:           p.Sauce.ConfigUpload,
+			RetryPolicy:            p.Sauce.RetryPolicy,
 			MetadataSearchStrategy: framework.NewSearchStrategy(p.Playwright.Version, p.RootDir),
 			NPMDependencies:        p.Npm.Dependencies,
 			Retrier: &retry.SauceReportRetrier{
@@ -170,6 +183,46 @@ func runCucumber(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 	return r.RunProject(cmd.Context())
 }
 
+// scenarioHistoryPages is the maximum number of pages of test runs that are
+// fetched to estimate scenario durations.
+const scenarioHistoryPages = 5
+
+// fetchScenarioDurations returns the average durations of the scenarios of the
+// project's suites that ran within the last week.
+//
+// Durations are only known for runs that were uploaded along with the path of
+// their suites, i.e. their feature file. Without any history, shards are
+// balanced by the number of scenarios instead.
+func fetchScenarioDurations(ctx context.Context, regio region.Region, suites []cucumber.Suite) map[cucumber.ScenarioKey]time.Duration {
+	insightsClient := http.NewInsightsService(regio.APIBaseURL(), regio.Credentials(), insightsTimeout)
+	opts := insights.ListTestRunsOptions{
+		// Cucumber runs on the playwright runner.
+		Framework: "playwright",
+		Since:     time.Now().Add(-7 * 24 * time.Hour),
+		Limit:     1000,
+	}
+
+	var runs []insights.TestRun
+	for i := 0; i < scenarioHistoryPages; i++ {
+		page, err := insightsClient.ListTestRuns(ctx, opts)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to fetch previous scenario durations. Shards will be balanced by number of scenarios.")
+			return nil
+		}
+		runs = append(runs, page...)
+		if len(page) < opts.Limit {
+			break
+		}
+		opts.Offset += len(page)
+	}
+
+	durations := cucumber.ScenarioDurations(runs, suites)
+	if len(durations) == 0 {
+		log.Info().Msg("No previous scenario durations found. Shards will be balanced by number of scenarios.")
+	}
+	return durations
+}
+
 func applyCucumberFlags(p *cucumber.Project) error {
 	if gFlags.selectedSuite != "" {
 		if err := cucumber.FilterSuites(p, gFlags.selectedSuite); err != nil {
@@ -8,7 +8,6 @@ import (
 	"github.com/saucelabs/saucectl/internal/http"
 
 	"github.com/rs/zerolog/log"
-	"github.com/saucelabs/saucectl/internal/ci"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/cypress"
 	"github.com/saucelabs/saucectl/internal/flags"
@@ -113,9 +112,11 @@ func runCypress(cmd *cobra.Command, cflags cypressFlags, isCLIDriven bool) (int,
 	}
 
 	p.SetDefaults()
-	if !gFlags.noAutoTagging {
-		p.AppendTags(ci.GetTags())
+	sauceCfg := p.GetSauceCfg()
+	if err := applyCIMetadata(&sauceCfg.Metadata); err != nil {
+		return 1, err
 	}
+	p.SetMetadata(sauceCfg.Metadata)
 
 	if err := p.Validate(); err != nil {
 		return 1, err
@@ -188,7 +189,9 @@ func runCypress(cmd *cobra.Command, cflags cypressFlags, isCLIDriven bool) (int,
 			ShowConsoleLog:         p.IsShowConsoleLog(),
 			Reporters:              createReporters(p.GetReporters(), gFlags.async),
 			Async:                  gFlags.async,
-			FailFast:               gFlags.failFast,
+			FailFast:               failFast(p.GetSauceCfg().FailFast),
+			ConfigUpload:           p.GetSauceCfg().ConfigUpload,
+			RetryPolicy:            p.GetSauceCfg().RetryPolicy,
 			MetadataSearchStrategy: framework.NewSearchStrategy(p.GetVersion(), p.GetRootDir()),
 			NPMDependencies:        p.GetNpm().Dependencies,
 			Retrier: &retry.SauceReportRetrier{
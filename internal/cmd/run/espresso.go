@@ -8,7 +8,6 @@ import (
 	"github.com/saucelabs/saucectl/internal/http"
 
 	"github.com/rs/zerolog/log"
-	"github.com/saucelabs/saucectl/internal/ci"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/espresso"
 	"github.com/saucelabs/saucectl/internal/flags"
@@ -17,6 +16,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/saucecloud"
 	"github.com/saucelabs/saucectl/internal/saucecloud/retry"
 	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/saucelabs/saucectl/internal/vmd"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -69,6 +69,7 @@ func NewEspressoCmd() *cobra.Command {
 	sc.String("testOptions.annotation", "suite::testOptions::annotation", "", "Include tests based on the annotation. Requires --name to be set.")
 	sc.String("testOptions.notAnnotation", "suite::testOptions::notAnnotation", "", "Run all tests except those with this annotation. Requires --name to be set.")
 	sc.Int("testOptions.numShards", "suite::testOptions::numShards", 0, "Total number of shards. Requires --name to be set.")
+	sc.String("shard", "suite::shard", "", "When shard is configured as concurrency, saucectl automatically splits the test classes of the test app by concurrency so that they can easily run in parallel. Requires --name to be set.")
 	sc.Bool("testOptions.useTestOrchestrator", "suite::testOptions::useTestOrchestrator", false, "Set the instrumentation to start with Test Orchestrator. Requires --name to be set.")
 
 	// Emulators and Devices
@@ -110,11 +111,18 @@ func runEspresso(cmd *cobra.Command, espressoFlags espressoFlags, isCLIDriven bo
 	if err := espresso.Validate(p); err != nil {
 		return 1, err
 	}
+	if err := espresso.ShardSuites(&p); err != nil {
+		return 1, err
+	}
 
 	regio := region.FromString(p.Sauce.Region)
 
-	if !gFlags.noAutoTagging {
-		p.Sauce.Metadata.Tags = append(p.Sauce.Metadata.Tags, ci.GetTags()...)
+	warnRealDeviceCustomData(p.Sauce.Metadata, p.Suites, func(s espresso.Suite) []config.Device {
+		return s.Devices
+	})
+
+	if err := applyCIMetadata(&p.Sauce.Metadata); err != nil {
+		return 1, err
 	}
 
 	tracker := usage.DefaultClient
@@ -150,6 +158,13 @@ func runEspressoInCloud(ctx context.Context, p espresso.Project, regio region.Re
 
 	creds := regio.Credentials()
 	restoClient := http.NewResto(regio, creds.Username, creds.AccessKey, 0)
+
+	if err := config.ResolveSuiteVirtualDevices(ctx, &restoClient, vmd.AndroidEmulator, p.Suites, func(s *espresso.Suite) *[]config.Emulator {
+		return &s.Emulators
+	}); err != nil {
+		return 1, err
+	}
+
 	testcompClient := http.NewTestComposer(regio.APIBaseURL(), creds, testComposerTimeout)
 	webdriverClient := http.NewWebdriver(regio, creds, webdriverTimeout)
 	appsClient := *http.NewAppStore(regio.APIBaseURL(), creds.Username, creds.AccessKey, gFlags.appStoreTimeout)
@@ -182,7 +197,9 @@ func runEspressoInCloud(ctx context.Context, p espresso.Project, regio region.Re
 			Reporters:       createReporters(p.Reporters, gFlags.async),
 			Framework:       framework.Framework{Name: espresso.Kind},
 			Async:           gFlags.async,
-			FailFast:        gFlags.failFast,
+			FailFast:        failFast(p.Sauce.FailFast),
+			ConfigUpload:    p.Sauce.ConfigUpload,
+			RetryPolicy:     p.Sauce.RetryPolicy,
 			Retrier: &retry.JunitRetrier{
 				JobService: jobService,
 			},
@@ -9,7 +9,6 @@ import (
 	"github.com/saucelabs/saucectl/internal/http"
 
 	"github.com/rs/zerolog/log"
-	"github.com/saucelabs/saucectl/internal/ci"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
@@ -66,6 +65,7 @@ func NewPlaywrightCmd() *cobra.Command {
 	// Playwright
 	sc.String("playwright.version", "playwright::version", "", "The Playwright version to use")
 	sc.String("playwright.configFile", "playwright::configFile", "", "The path to playwright config file")
+	sc.String("playwright.configExport", "playwright::configExport", "", "The path to a JSON export of the playwright config, which is used to create a suite per playwright project")
 
 	// Playwright Test Options
 	sc.Bool("headless", "suite::params::headless", false, "Run tests in headless mode")
@@ -78,6 +78,7 @@ func NewPlaywrightCmd() *cobra.Command {
 	sc.Int("maxFailures", "suite::params::maxFailures", 0, "Stop after the first N test failures")
 	sc.Int("numShards", "suite::numShards", 0, "Split tests across N number of shards")
 	sc.String("project", "suite::params::project", "", "Specify playwright project")
+	sc.Bool("splitProjects", "suite::splitProjects", false, "Create a suite per playwright project of the config export")
 	sc.StringSlice("excludedTestFiles", "suite::excludedTestFiles", []string{}, "Exclude test files to skip the tests, using regex")
 	sc.Bool("updateSnapshots", "suite::params::updateSnapshots", false, "Whether to update expected snapshots with the actual results produced by the test run.")
 	sc.Int("workers", "suite::params::workers", 1, "Set the maximum number of parallel worker processes (Default: 1).")
@@ -134,8 +135,8 @@ func runPlaywright(cmd *cobra.Command, pf playwrightFlags, isCLIDriven bool) (in
 		return 1, errors.New(msg.NoFrameworkSupport)
 	}
 
-	if !gFlags.noAutoTagging {
-		p.Sauce.Metadata.Tags = append(p.Sauce.Metadata.Tags, ci.GetTags()...)
+	if err := applyCIMetadata(&p.Sauce.Metadata); err != nil {
+		return 1, err
 	}
 
 	tracker := usage.DefaultClient
@@ -200,7 +201,9 @@ func runPlaywright(cmd *cobra.Command, pf playwrightFlags, isCLIDriven bool) (in
 			ShowConsoleLog:         p.ShowConsoleLog,
 			Reporters:              createReporters(p.Reporters, gFlags.async),
 			Async:                  gFlags.async,
-			FailFast:               gFlags.failFast,
+			FailFast:               failFast(p.Sauce.FailFast),
+			ConfigUpload:           p.Sauce.ConfigUpload,
+			RetryPolicy:            p.Sauce.RetryPolicy,
 			MetadataSearchStrategy: framework.NewSearchStrategy(p.Playwright.Version, p.RootDir),
 			NPMDependencies:        p.Npm.Dependencies,
 			Retrier: &retry.SauceReportRetrier{
@@ -3,13 +3,16 @@ package run
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/http"
 
 	"github.com/rs/zerolog/log"
-	"github.com/saucelabs/saucectl/internal/ci"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
@@ -24,9 +27,15 @@ import (
 	"github.com/spf13/pflag"
 )
 
+type replayFlags struct {
+	convert   string
+	overwrite bool
+}
+
 // NewReplayCmd creates the 'run' command for replay.
 func NewReplayCmd() *cobra.Command {
 	sc := flags.SnakeCharmer{Fmap: map[string]*pflag.Flag{}}
+	var rflags replayFlags
 
 	cmd := &cobra.Command{
 		Use:              "replay",
@@ -43,7 +52,7 @@ func NewReplayCmd() *cobra.Command {
 			// Test patterns are passed in via positional args.
 			viper.Set("suite::recordings", args)
 
-			exitCode, err := runReplay(cmd, true)
+			exitCode, err := runReplay(cmd, rflags, true)
 			if err != nil {
 				log.Err(err).Msg("failed to execute run command")
 			}
@@ -61,10 +70,13 @@ func NewReplayCmd() *cobra.Command {
 	sc.String("browserVersion", "suite::browserVersion", "", "Set the browser version to use. If not specified, the latest version will be used.")
 	sc.String("platform", "suite::platform", "", "Run against this platform.")
 
+	cmd.Flags().StringVar(&rflags.convert, "convert", "", fmt.Sprintf("Convert the recordings to scripts instead of running them. Scripts are written next to the recordings. Choose from: %s.", strings.Join(replay.ScriptFormats, ", ")))
+	cmd.Flags().BoolVar(&rflags.overwrite, "overwrite", false, "Overwrite existing scripts when converting recordings.")
+
 	return cmd
 }
 
-func runReplay(cmd *cobra.Command, isCLIDriven bool) (int, error) {
+func runReplay(cmd *cobra.Command, rflags replayFlags, isCLIDriven bool) (int, error) {
 	if !isCLIDriven {
 		config.ValidateSchema(gFlags.cfgFilePath)
 	}
@@ -89,13 +101,21 @@ func runReplay(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 	}
 	p.Suites = ss
 
+	if rflags.convert != "" {
+		return convertRecordings(p.Suites, rflags.convert, rflags.overwrite)
+	}
+
+	if err := replay.ValidateRecordings(p.Suites); err != nil {
+		return 1, err
+	}
+
 	regio := region.FromString(p.Sauce.Region)
 	if regio == region.USEast4 {
 		return 1, errors.New(msg.NoFrameworkSupport)
 	}
 
-	if !gFlags.noAutoTagging {
-		p.Sauce.Metadata.Tags = append(p.Sauce.Metadata.Tags, ci.GetTags()...)
+	if err := applyCIMetadata(&p.Sauce.Metadata); err != nil {
+		return 1, err
 	}
 
 	tracker := usage.DefaultClient
@@ -120,6 +140,37 @@ func runReplay(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 	return runPuppeteerReplayInSauce(cmd.Context(), p, regio)
 }
 
+// convertRecordings converts the recordings of the suites to scripts in the
+// given format. Existing scripts are only replaced if overwrite is set.
+func convertRecordings(suites []replay.Suite, format string, overwrite bool) (int, error) {
+	if !slices.Contains(replay.ScriptFormats, format) {
+		return 1, fmt.Errorf("unsupported script format %q, use one of: %s", format, strings.Join(replay.ScriptFormats, ", "))
+	}
+
+	for _, s := range suites {
+		out := strings.TrimSuffix(s.Recording, filepath.Ext(s.Recording)) + replay.ScriptExtension(format)
+		if _, err := os.Stat(out); err == nil && !overwrite {
+			return 1, fmt.Errorf("script %q already exists, use --overwrite to replace it", out)
+		}
+
+		r, err := replay.ReadRecording(s.Recording)
+		if err != nil {
+			return 1, err
+		}
+		script, err := replay.Convert(r, format)
+		if err != nil {
+			return 1, fmt.Errorf("failed to convert recording %q: %w", s.Recording, err)
+		}
+
+		if err := os.WriteFile(out, []byte(script), 0644); err != nil {
+			return 1, fmt.Errorf("failed to write script: %w", err)
+		}
+		log.Info().Str("recording", s.Recording).Str("script", out).Msg("Converted recording.")
+	}
+
+	return 0, nil
+}
+
 func runPuppeteerReplayInSauce(ctx context.Context, p replay.Project, regio region.Region) (int, error) {
 	log.Info().
 		Str("region", regio.String()).
@@ -158,7 +209,9 @@ func runPuppeteerReplayInSauce(ctx context.Context, p replay.Project, regio regi
 			ShowConsoleLog:         p.ShowConsoleLog,
 			Reporters:              createReporters(p.Reporters, gFlags.async),
 			Async:                  gFlags.async,
-			FailFast:               gFlags.failFast,
+			FailFast:               failFast(p.Sauce.FailFast),
+			ConfigUpload:           p.Sauce.ConfigUpload,
+			RetryPolicy:            p.Sauce.RetryPolicy,
 			MetadataSearchStrategy: framework.ExactStrategy{},
 			Retrier:                &retry.BasicRetrier{},
 		},
@@ -12,6 +12,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/rs/zerolog/log"
 	"github.com/saucelabs/saucectl/internal/report/buildtable"
+	"github.com/saucelabs/saucectl/internal/report/gitlab"
 	"github.com/saucelabs/saucectl/internal/report/json"
 	"github.com/saucelabs/saucectl/internal/report/junit"
 	"github.com/saucelabs/saucectl/internal/report/spotlight"
@@ -20,6 +21,7 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/saucelabs/saucectl/internal/apitest"
+	"github.com/saucelabs/saucectl/internal/ci"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/credentials"
 	"github.com/saucelabs/saucectl/internal/cucumber"
@@ -135,6 +137,9 @@ func Command() *cobra.Command {
 	sc.Bool("reporters.json.enabled", "reporters::json::enabled", false, "Toggle saucectl's JSON test result reporting on/off. This only affects the reports that saucectl itself generates as a summary of your tests.")
 	sc.String("reporters.json.filename", "reporters::json::filename", "saucectl-report.json", "Specifies the report filename.")
 	sc.String("reporters.json.webhookURL", "reporters::json::webhookURL", "", "Specifies the webhook URL. When saucectl test is finished, it'll send a HTTP POST payload to the configured webhook URL.")
+	sc.Bool("reporters.pullRequest.enabled", "reporters::pullRequest::enabled", false, "Toggle reporting of test results to the pull request (GitHub) or merge request (GitLab) on/off.")
+	sc.String("reporters.pullRequest.name", "reporters::pullRequest::name", "saucectl", "Specifies the name of the commit status or check run.")
+	sc.Bool("reporters.pullRequest.checkRun", "reporters::pullRequest::checkRun", false, "Report a check run with annotations for failed tests instead of a commit status. GitHub only.")
 
 	cmd.PersistentFlags().StringVar(&gFlags.selectedSuite, "select-suite", "", "Run specified test suite.")
 	cmd.PersistentFlags().BoolVar(&gFlags.testEnvSilent, "test-env-silent", false, "Skips the test environment announcement.")
@@ -205,7 +210,7 @@ func Run(cmd *cobra.Command) (int, error) {
 		return runTestcafe(cmd, testcafeFlags{}, false)
 	}
 	if typeDef.Kind == replay.Kind {
-		return runReplay(cmd, false)
+		return runReplay(cmd, replayFlags{}, false)
 	}
 	if typeDef.Kind == espresso.Kind {
 		return runEspresso(cmd, espressoFlags{}, false)
@@ -294,6 +299,18 @@ func createReporters(c config.Reporters, async bool) []report.Reporter {
 				Dst: os.Stdout,
 			})
 		}
+		if c.PullRequest.Enabled {
+			switch ci.GetProvider() {
+			case ci.GitHub:
+				r := github.NewStatusReporter(c.PullRequest.Name, c.PullRequest.CheckRun)
+				reps = append(reps, &r)
+			case ci.GitLab:
+				r := gitlab.NewNoteReporter(c.PullRequest.Name)
+				reps = append(reps, &r)
+			default:
+				log.Warn().Msg("The pull request reporter is only supported on GitHub and GitLab.")
+			}
+		}
 	}
 
 	buildReporter := buildtable.New()
@@ -302,6 +319,15 @@ func createReporters(c config.Reporters, async bool) []report.Reporter {
 	return reps
 }
 
+// failFast returns the fail fast thresholds of the config. The --fail-fast flag
+// takes precedence and stops the run after the first failed suite.
+func failFast(c config.FailFast) config.FailFast {
+	if gFlags.failFast {
+		c.MaxFailures = 1
+	}
+	return c
+}
+
 // cleanupArtifacts removes any files in the artifact folder. Does nothing if cleanup is turned off.
 func cleanupArtifacts(c config.Artifacts) {
 	if !c.Cleanup {
@@ -313,3 +339,47 @@ func cleanupArtifacts(c config.Artifacts) {
 		log.Err(err).Msg("Unable to clean up previous artifacts")
 	}
 }
+
+// warnRealDeviceCustomData warns that the custom data of the metadata isn't
+// attached to jobs on real devices, which don't support it. devices returns
+// the real devices of a suite.
+func warnRealDeviceCustomData[S any](m config.Metadata, suites []S, devices func(S) []config.Device) {
+	if len(m.CustomData) == 0 {
+		return
+	}
+	for _, s := range suites {
+		if len(devices(s)) > 0 {
+			log.Warn().Msg("Custom data is not supported on real devices and won't be attached to real device jobs.")
+			return
+		}
+	}
+}
+
+// applyCIMetadata tags jobs with CI details and attaches them as custom data,
+// unless auto tagging is disabled. The build name may be a template that
+// references CI details and is rendered regardless.
+func applyCIMetadata(m *config.Metadata) error {
+	c := ci.GetCI(ci.GetProvider())
+
+	if !gFlags.noAutoTagging {
+		m.Tags = append(m.Tags, ci.GetTags()...)
+
+		if c.Provider != ci.None {
+			if m.CustomData == nil {
+				m.CustomData = map[string]interface{}{}
+			}
+			// Don't override user provided data.
+			if _, ok := m.CustomData["ci"]; !ok {
+				m.CustomData["ci"] = c.CustomData()
+			}
+		}
+	}
+
+	build, err := ci.RenderBuildName(m.Build, c)
+	if err != nil {
+		return err
+	}
+	m.Build = build
+
+	return nil
+}
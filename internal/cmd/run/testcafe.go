@@ -9,7 +9,6 @@ import (
 	"github.com/saucelabs/saucectl/internal/http"
 
 	"github.com/rs/zerolog/log"
-	"github.com/saucelabs/saucectl/internal/ci"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
@@ -20,6 +19,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/testcafe"
 	"github.com/saucelabs/saucectl/internal/usage"
 	"github.com/saucelabs/saucectl/internal/viper"
+	"github.com/saucelabs/saucectl/internal/vmd"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -157,8 +157,8 @@ func runTestcafe(cmd *cobra.Command, tcFlags testcafeFlags, isCLIDriven bool) (i
 		return 1, errors.New(msg.NoFrameworkSupport)
 	}
 
-	if !gFlags.noAutoTagging {
-		p.Sauce.Metadata.Tags = append(p.Sauce.Metadata.Tags, ci.GetTags()...)
+	if err := applyCIMetadata(&p.Sauce.Metadata); err != nil {
+		return 1, err
 	}
 
 	tracker := usage.DefaultClient
@@ -188,6 +188,13 @@ func runTestcafe(cmd *cobra.Command, tcFlags testcafeFlags, isCLIDriven bool) (i
 	creds := regio.Credentials()
 
 	restoClient := http.NewResto(regio, creds.Username, creds.AccessKey, 0)
+
+	if err := config.ResolveSuiteVirtualDevices(cmd.Context(), &restoClient, vmd.IOSSimulator, p.Suites, func(s *testcafe.Suite) *[]config.Simulator {
+		return &s.Simulators
+	}); err != nil {
+		return 1, err
+	}
+
 	testcompClient := http.NewTestComposer(regio.APIBaseURL(), creds, testComposerTimeout)
 	webdriverClient := http.NewWebdriver(regio, creds, webdriverTimeout)
 	appsClient := *http.NewAppStore(regio.APIBaseURL(), creds.Username, creds.AccessKey, gFlags.appStoreTimeout)
@@ -223,7 +230,9 @@ func runTestcafe(cmd *cobra.Command, tcFlags testcafeFlags, isCLIDriven bool) (i
 			ShowConsoleLog:         p.ShowConsoleLog,
 			Reporters:              createReporters(p.Reporters, gFlags.async),
 			Async:                  gFlags.async,
-			FailFast:               gFlags.failFast,
+			FailFast:               failFast(p.Sauce.FailFast),
+			ConfigUpload:           p.Sauce.ConfigUpload,
+			RetryPolicy:            p.Sauce.RetryPolicy,
 			MetadataSearchStrategy: framework.NewSearchStrategy(p.Testcafe.Version, p.RootDir),
 			NPMDependencies:        p.Npm.Dependencies,
 			Retrier: &retry.SauceReportRetrier{
@@ -5,7 +5,6 @@ import (
 	"os"
 
 	"github.com/rs/zerolog/log"
-	"github.com/saucelabs/saucectl/internal/ci"
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/flags"
@@ -15,6 +14,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/saucecloud"
 	"github.com/saucelabs/saucectl/internal/saucecloud/retry"
 	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/saucelabs/saucectl/internal/vmd"
 	"github.com/saucelabs/saucectl/internal/xctest"
 	"github.com/saucelabs/saucectl/internal/xcuitest"
 	"github.com/spf13/cobra"
@@ -110,8 +110,12 @@ func runXctest(cmd *cobra.Command, xcuiFlags xctestFlags, isCLIDriven bool) (int
 
 	regio := region.FromString(p.Sauce.Region)
 
-	if !gFlags.noAutoTagging {
-		p.Sauce.Metadata.Tags = append(p.Sauce.Metadata.Tags, ci.GetTags()...)
+	warnRealDeviceCustomData(p.Sauce.Metadata, p.Suites, func(s xctest.Suite) []config.Device {
+		return s.Devices
+	})
+
+	if err := applyCIMetadata(&p.Sauce.Metadata); err != nil {
+		return 1, err
 	}
 
 	tracker := usage.DefaultClient
@@ -148,6 +152,13 @@ func runXctestInCloud(ctx context.Context, p xctest.Project, regio region.Region
 	creds := regio.Credentials()
 
 	restoClient := http.NewResto(regio, creds.Username, creds.AccessKey, 0)
+
+	if err := config.ResolveSuiteVirtualDevices(ctx, &restoClient, vmd.IOSSimulator, p.Suites, func(s *xctest.Suite) *[]config.Simulator {
+		return &s.Simulators
+	}); err != nil {
+		return 1, err
+	}
+
 	testcompClient := http.NewTestComposer(regio.APIBaseURL(), creds, testComposerTimeout)
 	webdriverClient := http.NewWebdriver(regio, creds, webdriverTimeout)
 	appsClient := *http.NewAppStore(regio.APIBaseURL(), creds.Username, creds.AccessKey, gFlags.appStoreTimeout)
@@ -180,7 +191,9 @@ func runXctestInCloud(ctx context.Context, p xctest.Project, regio region.Region
 			Reporters:       createReporters(p.Reporters, gFlags.async),
 			Framework:       framework.Framework{Name: xcuitest.Kind},
 			Async:           gFlags.async,
-			FailFast:        gFlags.failFast,
+			FailFast:        failFast(p.Sauce.FailFast),
+			ConfigUpload:    p.Sauce.ConfigUpload,
+			RetryPolicy:     p.Sauce.RetryPolicy,
 			Retrier: &retry.JunitRetrier{
 				JobService: jobService,
 			},
@@ -8,7 +8,6 @@ import (
 	"github.com/saucelabs/saucectl/internal/http"
 
 	"github.com/rs/zerolog/log"
-	"github.com/saucelabs/saucectl/internal/ci"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
@@ -16,6 +15,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/saucecloud"
 	"github.com/saucelabs/saucectl/internal/saucecloud/retry"
 	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/saucelabs/saucectl/internal/vmd"
 	"github.com/saucelabs/saucectl/internal/xcuitest"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -61,8 +61,10 @@ func NewXCUITestCmd() *cobra.Command {
 	sc.StringSlice("otherApps", "xcuitest::otherApps", []string{}, "Specifies any additional apps that are installed alongside the main app")
 	sc.Int("passThreshold", "suite::passThreshold", 1, "The minimum number of successful attempts for a suite to be considered as 'passed'.")
 
-	sc.String("shard", "suite::shard", "", "When shard is configured as concurrency, saucectl automatically splits the tests by concurrency so that they can easily run in parallel. Requires --name to be set.")
+	sc.String("shard", "suite::shard", "", "When shard is configured as concurrency, saucectl automatically splits the tests by concurrency so that they can easily run in parallel. With a test plan, tests can also be sharded by class. Requires --name to be set.")
 	sc.String("testListFile", "suite::testListFile", "", "This file containing tests will be used in sharding by concurrency. Requires --name to be set.")
+	sc.String("testPlan", "suite::testPlan", "", "Specifies a local .xctestrun or .xctestplan file from which the tests are read. Requires --name to be set.")
+	sc.StringSlice("testPlanConfigurations", "suite::testPlanConfigurations", []string{}, "Only run the tests of the specified test plan configurations. Requires --name to be set.")
 
 	// Test Options
 	sc.StringSlice("testOptions.class", "suite::testOptions::class", []string{}, "Only run the specified classes. Requires --name to be set.")
@@ -114,8 +116,12 @@ func runXcuitest(cmd *cobra.Command, xcuiFlags xcuitestFlags, isCLIDriven bool)
 
 	regio := region.FromString(p.Sauce.Region)
 
-	if !gFlags.noAutoTagging {
-		p.Sauce.Metadata.Tags = append(p.Sauce.Metadata.Tags, ci.GetTags()...)
+	warnRealDeviceCustomData(p.Sauce.Metadata, p.Suites, func(s xcuitest.Suite) []config.Device {
+		return s.Devices
+	})
+
+	if err := applyCIMetadata(&p.Sauce.Metadata); err != nil {
+		return 1, err
 	}
 
 	tracker := usage.DefaultClient
@@ -152,6 +158,13 @@ func runXcuitestInCloud(ctx context.Context, p xcuitest.Project, regio region.Re
 	creds := regio.Credentials()
 
 	restoClient := http.NewResto(regio, creds.Username, creds.AccessKey, 0)
+
+	if err := config.ResolveSuiteVirtualDevices(ctx, &restoClient, vmd.IOSSimulator, p.Suites, func(s *xcuitest.Suite) *[]config.Simulator {
+		return &s.Simulators
+	}); err != nil {
+		return 1, err
+	}
+
 	testcompClient := http.NewTestComposer(regio.APIBaseURL(), creds, testComposerTimeout)
 	webdriverClient := http.NewWebdriver(regio, creds, webdriverTimeout)
 	appsClient := *http.NewAppStore(regio.APIBaseURL(), creds.Username, creds.AccessKey, gFlags.appStoreTimeout)
@@ -184,7 +197,9 @@ func runXcuitestInCloud(ctx context.Context, p xcuitest.Project, regio region.Re
 			Reporters:       createReporters(p.Reporters, gFlags.async),
 			Framework:       framework.Framework{Name: xcuitest.Kind},
 			Async:           gFlags.async,
-			FailFast:        gFlags.failFast,
+			FailFast:        failFast(p.Sauce.FailFast),
+			ConfigUpload:    p.Sauce.ConfigUpload,
+			RetryPolicy:     p.Sauce.RetryPolicy,
 			Retrier: &retry.JunitRetrier{
 				JobService: jobService,
 			},
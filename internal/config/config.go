@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -11,17 +12,13 @@ import (
 	"strings"
 	"time"
 
-	"github.com/fatih/color"
 	"github.com/mitchellh/mapstructure"
 	"github.com/rs/zerolog/log"
-	"github.com/santhosh-tekuri/jsonschema/v5"
-	"gopkg.in/yaml.v2"
-
-	// httploader needs to be loaded to be able to fetch http-based schemas.
-	_ "github.com/santhosh-tekuri/jsonschema/v5/httploader"
+	"gopkg.in/yaml.v3"
 
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/node"
+	"github.com/saucelabs/saucectl/internal/secret"
 	"github.com/saucelabs/saucectl/internal/viper"
 )
 
@@ -29,6 +26,8 @@ import (
 type Metadata struct {
 	Tags  []string `yaml:"tags" json:"tags,omitempty"`
 	Build string   `yaml:"build" json:"build"`
+	// CustomData is arbitrary data that is attached to each job.
+	CustomData map[string]interface{} `yaml:"customData" json:"customData,omitempty"`
 }
 
 type LaunchOrder string
@@ -48,6 +47,12 @@ type SauceConfig struct {
 	Retries     int               `yaml:"retries,omitempty" json:"-"`
 	Visibility  string            `yaml:"visibility,omitempty" json:"-"`
 	LaunchOrder LaunchOrder       `yaml:"launchOrder,omitempty" json:"launchOrder,omitempty"`
+	// ConfigUpload controls how the config and CLI flags are attached to jobs.
+	ConfigUpload ConfigUpload `yaml:"configUpload,omitempty" json:"-"`
+	// RetryPolicy controls when and how failed suites are retried.
+	RetryPolicy RetryPolicy `yaml:"retryPolicy,omitempty" json:"-"`
+	// FailFast defines when a run is stopped early due to failing suites.
+	FailFast FailFast `yaml:"failFast,omitempty" json:"-"`
 }
 
 // DeviceOptions represents the devices capabilities required from a real device.
@@ -158,6 +163,15 @@ type Reporters struct {
 		WebhookURL string `yaml:"webhookURL"`
 		Filename   string `yaml:"filename"`
 	} `yaml:"json"`
+
+	PullRequest struct {
+		Enabled bool `yaml:"enabled"`
+		// Name identifies the commit status, check run or note.
+		Name string `yaml:"name"`
+		// CheckRun reports a check run with annotations instead of a commit
+		// status. GitHub only.
+		CheckRun bool `yaml:"checkRun"`
+	} `yaml:"pullRequest"`
 }
 
 // Tunnel represents a sauce labs tunnel.
@@ -252,24 +266,6 @@ func (s *SmartRetry) IsRetryFailedOnly() bool {
 	return false
 }
 
-func readYaml(cfgFilePath string) ([]byte, error) {
-	if cfgFilePath == "" {
-		return nil, errors.New(msg.MissingConfigFile)
-	}
-
-	pwd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
-
-	fp := cfgFilePath
-	if !filepath.IsAbs(fp) {
-		fp = filepath.Join(pwd, cfgFilePath)
-	}
-
-	return os.ReadFile(fp)
-}
-
 // Describe returns a description of the given config that is cfgPath.
 func Describe(cfgPath string) (TypeDef, error) {
 	var d TypeDef
@@ -278,7 +274,7 @@ func Describe(cfgPath string) (TypeDef, error) {
 		return TypeDef{}, nil
 	}
 
-	yamlFile, err := readYaml(cfgPath)
+	yamlFile, err := Resolve(cfgPath)
 	if err != nil {
 		return TypeDef{}, fmt.Errorf("failed to locate project configuration: %v", err)
 	}
@@ -341,68 +337,197 @@ func CleanNpmPackages(packages map[string]string, denyList []string) map[string]
 // Unmarshal parses the file cfgPath into the given project struct.
 func Unmarshal(cfgPath string, project interface{}) error {
 	if cfgPath != "" {
-		name := strings.TrimSuffix(filepath.Base(cfgPath), filepath.Ext(cfgPath)) // config name without extension
-		viper.SetConfigName(name)
-		viper.AddConfigPath(filepath.Dir(cfgPath))
-		if err := viper.ReadInConfig(); err != nil {
+		b, err := Resolve(cfgPath)
+		if err != nil {
 			return fmt.Errorf("failed to locate project config: %v", err)
 		}
+		// Expand the config only once, so that expanded values, e.g. secrets,
+		// are never expanded again.
+		if b, err = expandConfig(b); err != nil {
+			return fmt.Errorf("failed to expand project config: %v", err)
+		}
+		viper.SetConfigType("yaml")
+		if err := viper.ReadConfig(bytes.NewReader(b)); err != nil {
+			return fmt.Errorf("failed to read project config: %v", err)
+		}
 	}
 
 	return viper.Unmarshal(&project, func(decodeCfg *mapstructure.DecoderConfig) {
 		decodeCfg.DecodeHook = mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToTimeDurationHookFunc(),
 			mapstructure.StringToSliceHookFunc(","),
-			func(_ reflect.Kind, _ reflect.Kind, v interface{}) (interface{}, error) {
-				return expandEnv(v), nil
-			},
 		)
 	})
 }
 
-func expandEnv(v interface{}) interface{} {
-	if v == nil {
+func expandConfig(b []byte) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if err := expandNode(&doc); err != nil {
+		return nil, err
+	}
+	return marshalNode(&doc)
+}
+
+// expandNode expands all string scalars of n in place. Other scalars, e.g.
+// numbers and booleans, are left untouched.
+func expandNode(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode && n.ShortTag() == "!!str" {
+		s, err := expandString(n.Value)
+		if err != nil {
+			return err
+		}
+		n.Value = s
 		return nil
 	}
+	for _, c := range n.Content {
+		if err := expandNode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandEnv expands environment variables and resolves secret references in v.
+// See expandString for the supported syntax.
+func expandEnv(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
 	switch reflect.TypeOf(v).Kind() {
 	case reflect.String:
-		return os.ExpandEnv(v.(string))
+		return expandString(v.(string))
 	case reflect.Slice:
 		if val, ok := v.([]string); ok {
 			var strs []string
 			for _, item := range val {
-				strs = append(strs, os.ExpandEnv(item))
+				s, err := expandString(item)
+				if err != nil {
+					return nil, err
+				}
+				strs = append(strs, s)
 			}
-			return strs
+			return strs, nil
 		}
 		if val, ok := v.([]interface{}); ok {
 			var items []interface{}
 			for _, item := range val {
-				items = append(items, expandEnv(item))
+				i, err := expandEnv(item)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, i)
 			}
-			return items
+			return items, nil
 		}
 	case reflect.Map:
 		if mp, ok := v.(map[string]string); ok {
 			for key, val := range mp {
-				mp[key] = os.ExpandEnv(val)
+				s, err := expandString(val)
+				if err != nil {
+					return nil, err
+				}
+				mp[key] = s
 			}
-			return mp
+			return mp, nil
 		}
 		if mp, ok := v.(map[string]interface{}); ok {
 			for key, val := range mp {
-				mp[key] = expandEnv(val)
+				i, err := expandEnv(val)
+				if err != nil {
+					return nil, err
+				}
+				mp[key] = i
 			}
-			return mp
+			return mp, nil
 		}
 		if mp, ok := v.(map[interface{}]interface{}); ok {
 			for key, val := range mp {
-				mp[key] = expandEnv(val)
+				i, err := expandEnv(val)
+				if err != nil {
+					return nil, err
+				}
+				mp[key] = i
 			}
-			return mp
+			return mp, nil
+		}
+	}
+	return v, nil
+}
+
+// expandString expands environment variables in s. Besides $VAR and ${VAR},
+// the following shell-style expressions are supported:
+//
+//	${VAR:-default}  default if VAR is unset or empty
+//	${VAR-default}   default if VAR is unset
+//	${VAR:+value}    value if VAR is set and not empty, otherwise empty
+//	${VAR+value}     value if VAR is set, otherwise empty
+//	${VAR:?message}  fails with message if VAR is unset or empty
+//	${VAR?message}   fails with message if VAR is unset
+//
+// If the expanded value is a secret reference (secret://name), the secret is
+// resolved with the configured secret provider.
+func expandString(s string) (string, error) {
+	var err error
+	s = os.Expand(s, func(expr string) string {
+		v, e := expandExpr(expr)
+		if e != nil && err == nil {
+			err = e
 		}
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if secret.IsReference(s) {
+		return secret.Resolve(secret.DefaultProvider(), s)
 	}
-	return v
+	return s, nil
+}
+
+func expandExpr(expr string) (string, error) {
+	i := strings.IndexAny(expr, ":-+?")
+	if i == -1 {
+		return os.Getenv(expr), nil
+	}
+
+	name, op := expr[:i], expr[i:]
+	val, set := os.LookupEnv(name)
+	// The colon variants treat empty values like unset ones.
+	if strings.HasPrefix(op, ":") {
+		op = op[1:]
+		set = val != ""
+	}
+	if op == "" {
+		return "", fmt.Errorf("invalid expression '${%s}'", expr)
+	}
+
+	arg := op[1:]
+	switch op[0] {
+	case '-':
+		if !set {
+			return arg, nil
+		}
+	case '+':
+		if set {
+			return arg, nil
+		}
+		return "", nil
+	case '?':
+		if !set {
+			if arg == "" {
+				arg = "required but not set"
+			}
+			return "", fmt.Errorf("environment variable %s: %s", name, arg)
+		}
+	default:
+		return "", fmt.Errorf("invalid expression '${%s}'", expr)
+	}
+
+	return val, nil
 }
 
 // SetDefaults updates tunnel default values
@@ -487,100 +612,6 @@ func ValidateVisibility(visibility string) bool {
 	return false
 }
 
-// ValidateSchema validates user config against the JSON Schema.
-// If validation fails for any reason, fail softly to avoid disturbing execution as this is not critical.
-func ValidateSchema(cfgFile string) {
-	yamlText, err := os.ReadFile(cfgFile)
-	if err != nil {
-		return
-	}
-
-	var m interface{}
-	err = yaml.Unmarshal(yamlText, &m)
-	if err != nil {
-		return
-	}
-	m, err = toStringKeys(m)
-	if err != nil {
-		return
-	}
-
-	compiler := jsonschema.NewCompiler()
-	schema, err := compiler.Compile("https://raw.githubusercontent.com/saucelabs/saucectl/main/api/saucectl.schema.json")
-	if err != nil {
-		return
-	}
-	err = schema.Validate(m)
-	if err == nil {
-		return
-	}
-	rootCause := findRootCauses(err.(*jsonschema.ValidationError))
-	renderSchemaValidationIssues(cfgFile, rootCause)
-}
-
-func renderSchemaValidationIssues(cfgFile string, errors []*jsonschema.ValidationError) {
-	errStr := "error"
-	if len(errors) > 1 {
-		errStr = "errors"
-	}
-	fmt.Println()
-	color.Red("There is %d validation %s found in %s:\n", len(errors), errStr, cfgFile)
-	for _, d := range errors {
-		if d.InstanceLocation != "" {
-			color.Red("- %s in %s\n", d.Message, d.InstanceLocation)
-		} else {
-			color.Red("- %s\n", d.Message)
-		}
-	}
-	println()
-}
-
-func findRootCauses(validationError *jsonschema.ValidationError) []*jsonschema.ValidationError {
-	if validationError == nil {
-		return []*jsonschema.ValidationError{}
-	}
-
-	if len(validationError.Causes) == 0 {
-		return []*jsonschema.ValidationError{validationError}
-	}
-
-	var errors []*jsonschema.ValidationError
-	for _, cause := range validationError.Causes {
-		errors = append(errors, findRootCauses(cause)...)
-	}
-	return errors
-}
-
-func toStringKeys(val interface{}) (interface{}, error) {
-	var err error
-	switch val := val.(type) {
-	case map[interface{}]interface{}:
-		m := make(map[string]interface{})
-		for k, v := range val {
-			k, ok := k.(string)
-			if !ok {
-				return nil, errors.New("found non-string key")
-			}
-			m[k], err = toStringKeys(v)
-			if err != nil {
-				return nil, err
-			}
-		}
-		return m, nil
-	case []interface{}:
-		var l = make([]interface{}, len(val))
-		for i, v := range val {
-			l[i], err = toStringKeys(v)
-			if err != nil {
-				return nil, err
-			}
-		}
-		return l, nil
-	default:
-		return val, nil
-	}
-}
-
 func ValidateSmartRetry(smartRetry SmartRetry) {
 	if smartRetry.FailedClassesOnly {
 		log.Warn().Msg("failedClassesOnly has been deprecated. Use FailedOnly instead.")
@@ -3,11 +3,13 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/saucelabs/saucectl/internal/node"
 	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
 )
 
 func TestStandardizeVersionFormat(t *testing.T) {
@@ -158,7 +160,8 @@ func TestConfig_ExpandEnv(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := expandEnv(tc.input)
+			result, err := expandEnv(tc.input)
+			assert.NoError(t, err)
 			assert.False(t, strings.Contains(fmt.Sprint(result), "$"))
 			assert.Equal(t, tc.expected, result)
 		})
@@ -446,3 +449,57 @@ func TestValidatePackage(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandString(t *testing.T) {
+	t.Setenv("SET", "value")
+	t.Setenv("EMPTY", "")
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr string
+	}{
+		{input: "$SET-${SET}", want: "value-value"},
+		{input: "${UNSET:-fallback}", want: "fallback"},
+		{input: "${EMPTY:-fallback}", want: "fallback"},
+		{input: "${EMPTY-fallback}", want: ""},
+		{input: "${SET:-fallback}", want: "value"},
+		{input: "${SET:+on}", want: "on"},
+		{input: "${EMPTY:+on}", want: ""},
+		{input: "${EMPTY+on}", want: "on"},
+		{input: "${SET:?must be set}", want: "value"},
+		{input: "${UNSET:?must be set}", wantErr: "environment variable UNSET: must be set"},
+		{input: "${EMPTY:?}", wantErr: "environment variable EMPTY: required but not set"},
+		{input: "${EMPTY?}", want: ""},
+		{input: "${SET:}", wantErr: "invalid expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := expandString(tt.input)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExpandString_Secret(t *testing.T) {
+	dir := fs.NewDir(t, "secrets", fs.WithFile("secrets.env", "DB_PASSWORD=pa$$word\n"))
+	defer dir.Remove()
+	t.Setenv("SAUCE_SECRETS_FILE", filepath.Join(dir.Path(), "secrets.env"))
+
+	got, err := expandConfig([]byte("env:\n  PASSWORD: secret://DB_PASSWORD\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "env:\n  PASSWORD: pa$$word\n", string(got))
+}
+
+func TestExpandConfig(t *testing.T) {
+	t.Setenv("PORT", "8080")
+
+	got, err := expandConfig([]byte("env:\n  ANSWER: y\n  FLAG: off\n  PORT: $PORT\n  RETRIES: 3\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "env:\n  ANSWER: y\n  FLAG: off\n  PORT: \"8080\"\n  RETRIES: 3\n", string(got))
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SuiteDependencies describes the suites that a suite depends on.
+type SuiteDependencies struct {
+	Name      string
+	DependsOn []string
+}
+
+// DependencyMatches returns true if the suite satisfies the dependency. Besides
+// the suite of the same name, a dependency also matches any suite that was
+// derived from it by matrix expansion or sharding, e.g. "smoke - chrome" or
+// "smoke - 1/2" for the dependency "smoke".
+func DependencyMatches(dependency, suite string) bool {
+	return suite == dependency || strings.HasPrefix(suite, dependency+" - ")
+}
+
+// ValidateSuiteDependencies validates the dependencies of the given suites. See
+// ValidateDependencies.
+func ValidateSuiteDependencies[S interface{ Dependencies() SuiteDependencies }](suites []S) error {
+	var deps []SuiteDependencies
+	for _, s := range suites {
+		deps = append(deps, s.Dependencies())
+	}
+	return ValidateDependencies(deps)
+}
+
+// ValidateDependencies validates that suites only depend on existing suites and
+// that the dependencies don't form a cycle.
+func ValidateDependencies(suites []SuiteDependencies) error {
+	// edges maps each suite to the suites it depends on.
+	edges := make(map[string][]string, len(suites))
+	for _, s := range suites {
+		for _, dep := range s.DependsOn {
+			found := false
+			for _, other := range suites {
+				if other.Name == s.Name || !DependencyMatches(dep, other.Name) {
+					continue
+				}
+				found = true
+				edges[s.Name] = append(edges[s.Name], other.Name)
+			}
+			if !found {
+				return fmt.Errorf("suite '%s' depends on unknown suite '%s'", s.Name, dep)
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		path = append(path, name)
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("suites have circular dependencies: %s", strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, dep := range edges[name] {
+			if err := visit(dep, path); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, s := range suites {
+		if err := visit(s.Name, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		suites  []SuiteDependencies
+		wantErr string
+	}{
+		{
+			name: "no dependencies",
+			suites: []SuiteDependencies{
+				{Name: "smoke"},
+				{Name: "regression"},
+			},
+		},
+		{
+			name: "valid dependencies",
+			suites: []SuiteDependencies{
+				{Name: "smoke"},
+				{Name: "regression", DependsOn: []string{"smoke"}},
+				{Name: "e2e", DependsOn: []string{"smoke", "regression"}},
+			},
+		},
+		{
+			name: "dependency on expanded suites",
+			suites: []SuiteDependencies{
+				{Name: "smoke - chrome"},
+				{Name: "smoke - firefox"},
+				{Name: "regression", DependsOn: []string{"smoke"}},
+			},
+		},
+		{
+			name: "unknown dependency",
+			suites: []SuiteDependencies{
+				{Name: "regression", DependsOn: []string{"smoke"}},
+			},
+			wantErr: "suite 'regression' depends on unknown suite 'smoke'",
+		},
+		{
+			name: "self dependency",
+			suites: []SuiteDependencies{
+				{Name: "smoke", DependsOn: []string{"smoke"}},
+			},
+			wantErr: "suite 'smoke' depends on unknown suite 'smoke'",
+		},
+		{
+			name: "circular dependencies",
+			suites: []SuiteDependencies{
+				{Name: "a", DependsOn: []string{"c"}},
+				{Name: "b", DependsOn: []string{"a"}},
+				{Name: "c", DependsOn: []string{"b"}},
+			},
+			wantErr: "suites have circular dependencies: a -> c -> b -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDependencies(tt.suites)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+type dependentSuite struct {
+	name      string
+	dependsOn []string
+}
+
+func (s dependentSuite) Dependencies() SuiteDependencies {
+	return SuiteDependencies{Name: s.name, DependsOn: s.dependsOn}
+}
+
+func TestValidateSuiteDependencies(t *testing.T) {
+	assert.NoError(t, ValidateSuiteDependencies([]dependentSuite{{name: "a"}, {name: "b", dependsOn: []string{"a"}}}))
+	assert.EqualError(t, ValidateSuiteDependencies([]dependentSuite{{name: "b", dependsOn: []string{"a"}}}), "suite 'b' depends on unknown suite 'a'")
+}
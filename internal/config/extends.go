@@ -0,0 +1,285 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Directives that pull other config files into the current one. Paths are
+// relative to the file that contains the directive.
+//
+// Files listed under "extends" are merged first, followed by files listed
+// under "include", followed by the file itself. Later files take precedence.
+const (
+	extendsKey = "extends"
+	includeKey = "include"
+)
+
+// Resolve reads the config file at cfgPath, resolves its "extends" and
+// "include" directives and expands any suite matrices. Returns the resulting
+// config in YAML.
+//
+// Files are merged according to the following rules:
+//   - Maps are merged recursively.
+//   - Lists of maps that all have a "name" key (e.g. suites) are merged by
+//     name. Items with matching names are merged recursively, new items are
+//     appended.
+//   - Any other value, including any other list, is replaced.
+//
+// Files are merged as YAML nodes, so scalars keep their original style and
+// are interpreted the same way as in a single config file, e.g. "on" or "y"
+// remain strings.
+func Resolve(cfgPath string) ([]byte, error) {
+	m, err := resolveFile(cfgPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err = expandMatrices(m)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalNode(m)
+}
+
+func marshalNode(n *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func resolveFile(cfgPath string, visited []string) (*yaml.Node, error) {
+	abs, err := filepath.Abs(cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(visited, abs) {
+		return nil, fmt.Errorf("circular config reference: %s", cfgPath)
+	}
+	visited = append(visited, abs)
+
+	b, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+
+	own, err := parseMapping(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", cfgPath, err)
+	}
+
+	var refs []string
+	for _, key := range []string{extendsKey, includeKey} {
+		r, err := stringOrList(lookup(own, key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid '%s' in %s: %w", key, cfgPath, err)
+		}
+		refs = append(refs, r...)
+	}
+
+	merged := newMapping()
+	for _, ref := range refs {
+		if !filepath.IsAbs(ref) {
+			ref = filepath.Join(filepath.Dir(abs), ref)
+		}
+		base, err := resolveFile(ref, visited)
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeMaps(merged, base)
+	}
+
+	return mergeMaps(merged, without(own, extendsKey, includeKey)), nil
+}
+
+// parseMapping parses a YAML document whose root is a map. Aliases and merge
+// keys are resolved, since anchors don't survive merging files.
+func parseMapping(b []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return newMapping(), nil
+	}
+
+	root := resolveAliases(doc.Content[0])
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a map at line %d", root.Line)
+	}
+	return root, nil
+}
+
+// resolveAliases returns a copy of n with aliases replaced by the nodes they
+// refer to and merge keys ("<<") replaced by the merged values.
+func resolveAliases(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.AliasNode {
+		return resolveAliases(n.Alias)
+	}
+
+	out := *n
+	out.Anchor = ""
+	out.Content = nil
+
+	if n.Kind != yaml.MappingNode {
+		for _, c := range n.Content {
+			out.Content = append(out.Content, resolveAliases(c))
+		}
+		return &out
+	}
+
+	var merges []*yaml.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], resolveAliases(n.Content[i+1])
+		if k.ShortTag() != "!!merge" {
+			out.Content = append(out.Content, k, v)
+			continue
+		}
+		if v.Kind == yaml.SequenceNode {
+			merges = append(merges, v.Content...)
+		} else {
+			merges = append(merges, v)
+		}
+	}
+
+	// Explicit keys take precedence over merged ones, and earlier merged maps
+	// over later ones.
+	for _, m := range merges {
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			if lookup(&out, m.Content[i].Value) == nil {
+				out.Content = append(out.Content, m.Content[i], m.Content[i+1])
+			}
+		}
+	}
+
+	return &out
+}
+
+func newMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func without(m *yaml.Node, keys ...string) *yaml.Node {
+	out := *m
+	out.Content = nil
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if !slices.Contains(keys, m.Content[i].Value) {
+			out.Content = append(out.Content, m.Content[i], m.Content[i+1])
+		}
+	}
+	return &out
+}
+
+func stringOrList(n *yaml.Node) ([]string, error) {
+	if n == nil || n.ShortTag() == "!!null" {
+		return nil, nil
+	}
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.ShortTag() == "!!str" {
+			return []string{n.Value}, nil
+		}
+	case yaml.SequenceNode:
+		var out []string
+		for _, item := range n.Content {
+			if item.Kind != yaml.ScalarNode || item.ShortTag() != "!!str" {
+				return nil, fmt.Errorf("expected a file path at line %d", item.Line)
+			}
+			out = append(out, item.Value)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("expected a file path or a list of file paths at line %d", n.Line)
+}
+
+// mergeMaps merges override into base. See Resolve for the merge rules.
+func mergeMaps(base, override *yaml.Node) *yaml.Node {
+	out := *base
+	out.Content = slices.Clone(base.Content)
+
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		key, val := override.Content[i], override.Content[i+1]
+		idx := -1
+		for j := 0; j+1 < len(out.Content); j += 2 {
+			if out.Content[j].Value == key.Value {
+				idx = j + 1
+				break
+			}
+		}
+		if idx == -1 {
+			out.Content = append(out.Content, key, val)
+			continue
+		}
+		out.Content[idx] = mergeValues(out.Content[idx], val)
+	}
+
+	return &out
+}
+
+func mergeValues(base, override *yaml.Node) *yaml.Node {
+	switch {
+	case base.Kind == yaml.MappingNode && override.Kind == yaml.MappingNode:
+		return mergeMaps(base, override)
+	case base.Kind == yaml.SequenceNode && override.Kind == yaml.SequenceNode &&
+		isNamedList(base) && isNamedList(override):
+		return mergeNamedLists(base, override)
+	}
+	return override
+}
+
+// isNamedList returns true if every item of the list is a map with a "name".
+func isNamedList(l *yaml.Node) bool {
+	if len(l.Content) == 0 {
+		return false
+	}
+	for _, item := range l.Content {
+		if lookup(item, "name") == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeNamedLists(base, override *yaml.Node) *yaml.Node {
+	out := *base
+	out.Content = slices.Clone(base.Content)
+
+	for _, item := range override.Content {
+		name := lookup(item, "name").Value
+		idx := slices.IndexFunc(out.Content, func(b *yaml.Node) bool {
+			return lookup(b, "name").Value == name
+		})
+		if idx == -1 {
+			out.Content = append(out.Content, item)
+			continue
+		}
+		out.Content[idx] = mergeMaps(out.Content[idx], item)
+	}
+
+	return &out
+}
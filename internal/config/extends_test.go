@@ -0,0 +1,132 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"gotest.tools/v3/fs"
+)
+
+func TestResolve(t *testing.T) {
+	dir := fs.NewDir(t, "configs",
+		fs.WithFile("base.yml", `apiVersion: v1alpha
+kind: playwright
+sauce:
+  region: us-west-1
+  concurrency: 2
+  metadata:
+    tags:
+      - base
+suites:
+  - name: chrome
+    platformName: Windows 11
+    params:
+      browserName: chromium
+`),
+		fs.WithDir("shared",
+			fs.WithFile("reporters.yml", `reporters:
+  junit:
+    enabled: true
+`)),
+		fs.WithFile("config.yml", `extends: base.yml
+include:
+  - shared/reporters.yml
+sauce:
+  concurrency: 5
+  metadata:
+    tags:
+      - child
+suites:
+  - name: chrome
+    platformName: Windows 10
+  - name: firefox
+    params:
+      browserName: firefox
+`),
+	)
+	defer dir.Remove()
+
+	b, err := Resolve(filepath.Join(dir.Path(), "config.yml"))
+	assert.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1alpha
+kind: playwright
+sauce:
+  region: us-west-1
+  concurrency: 5
+  metadata:
+    tags:
+      - child
+suites:
+  - name: chrome
+    platformName: Windows 10
+    params:
+      browserName: chromium
+  - name: firefox
+    params:
+      browserName: firefox
+reporters:
+  junit:
+    enabled: true
+`, string(b))
+}
+
+func TestResolve_Circular(t *testing.T) {
+	dir := fs.NewDir(t, "configs",
+		fs.WithFile("a.yml", "extends: b.yml\n"),
+		fs.WithFile("b.yml", "extends: a.yml\n"),
+	)
+	defer dir.Remove()
+
+	_, err := Resolve(filepath.Join(dir.Path(), "a.yml"))
+	assert.ErrorContains(t, err, "circular config reference")
+}
+
+func TestResolve_InvalidDirective(t *testing.T) {
+	dir := fs.NewDir(t, "configs",
+		fs.WithFile("config.yml", "extends:\n  nested: true\n"),
+	)
+	defer dir.Remove()
+
+	_, err := Resolve(filepath.Join(dir.Path(), "config.yml"))
+	assert.ErrorContains(t, err, "invalid 'extends'")
+}
+
+func TestResolve_KeepsScalars(t *testing.T) {
+	dir := fs.NewDir(t, "configs",
+		fs.WithFile("base.yml", `defaults: &defaults
+  env:
+    ANSWER: y
+    FLAG: off
+suites:
+  - name: smoke
+    <<: *defaults
+`),
+		fs.WithFile("config.yml", `extends: base.yml
+suites:
+  - name: smoke
+    matrix:
+      mode: [on, "no"]
+`),
+	)
+	defer dir.Remove()
+
+	b, err := Resolve(filepath.Join(dir.Path(), "config.yml"))
+	assert.NoError(t, err)
+
+	var got struct {
+		Suites []struct {
+			Name string
+			Mode string
+			Env  map[string]string
+		}
+	}
+	assert.NoError(t, yaml.Unmarshal(b, &got))
+	assert.Len(t, got.Suites, 2)
+	assert.Equal(t, "smoke - on", got.Suites[0].Name)
+	assert.Equal(t, "on", got.Suites[0].Mode)
+	assert.Equal(t, map[string]string{"ANSWER": "y", "FLAG": "off"}, got.Suites[0].Env)
+	assert.Equal(t, "smoke - no", got.Suites[1].Name)
+	assert.Equal(t, "no", got.Suites[1].Mode)
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"errors"
+)
+
+// FailFast defines when a run is stopped early due to failing suites. Once a
+// threshold is reached, running suites are stopped and upcoming suites are
+// skipped.
+type FailFast struct {
+	// MaxFailures stops the run once the given number of suites failed.
+	MaxFailures int `yaml:"maxFailures,omitempty" json:"-"`
+	// MaxFailureRate stops the run once the percentage of failed suites
+	// reaches the given value.
+	MaxFailureRate float64 `yaml:"maxFailureRate,omitempty" json:"-"`
+	// MinSuites is the number of suites that must have completed before
+	// MaxFailureRate applies.
+	MinSuites int `yaml:"minSuites,omitempty" json:"-"`
+}
+
+// Enabled returns true if any threshold is set.
+func (f FailFast) Enabled() bool {
+	return f.MaxFailures > 0 || f.MaxFailureRate > 0
+}
+
+// Exceeded returns true if the number of failed suites out of the completed
+// ones reaches any of the thresholds.
+func (f FailFast) Exceeded(failed, completed int) bool {
+	if f.MaxFailures > 0 && failed >= f.MaxFailures {
+		return true
+	}
+	if f.MaxFailureRate > 0 && completed > 0 && completed >= f.MinSuites {
+		return float64(failed)/float64(completed)*100 >= f.MaxFailureRate
+	}
+	return false
+}
+
+// ValidateFailFast validates the fail fast thresholds.
+func ValidateFailFast(f FailFast) error {
+	if f.MaxFailures < 0 {
+		return errors.New("failFast.maxFailures must not be negative")
+	}
+	if f.MaxFailureRate < 0 || f.MaxFailureRate > 100 {
+		return errors.New("failFast.maxFailureRate must be between 0 and 100")
+	}
+	if f.MinSuites < 0 {
+		return errors.New("failFast.minSuites must not be negative")
+	}
+	return nil
+}
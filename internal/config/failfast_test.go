@@ -0,0 +1,80 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailFast_Exceeded(t *testing.T) {
+	tests := []struct {
+		name      string
+		failFast  FailFast
+		failed    int
+		completed int
+		expected  bool
+	}{
+		{
+			name:      "disabled",
+			failFast:  FailFast{},
+			failed:    5,
+			completed: 5,
+			expected:  false,
+		},
+		{
+			name:      "below max failures",
+			failFast:  FailFast{MaxFailures: 3},
+			failed:    2,
+			completed: 10,
+			expected:  false,
+		},
+		{
+			name:      "max failures reached",
+			failFast:  FailFast{MaxFailures: 3},
+			failed:    3,
+			completed: 10,
+			expected:  true,
+		},
+		{
+			name:      "failure rate reached",
+			failFast:  FailFast{MaxFailureRate: 50},
+			failed:    1,
+			completed: 2,
+			expected:  true,
+		},
+		{
+			name:      "failure rate reached before min suites completed",
+			failFast:  FailFast{MaxFailureRate: 50, MinSuites: 4},
+			failed:    2,
+			completed: 3,
+			expected:  false,
+		},
+		{
+			name:      "failure rate reached after min suites completed",
+			failFast:  FailFast{MaxFailureRate: 50, MinSuites: 4},
+			failed:    2,
+			completed: 4,
+			expected:  true,
+		},
+		{
+			name:      "below failure rate",
+			failFast:  FailFast{MaxFailureRate: 50, MinSuites: 4},
+			failed:    1,
+			completed: 4,
+			expected:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.failFast.Exceeded(tt.failed, tt.completed))
+		})
+	}
+}
+
+func TestValidateFailFast(t *testing.T) {
+	assert.NoError(t, ValidateFailFast(FailFast{}))
+	assert.NoError(t, ValidateFailFast(FailFast{MaxFailures: 2, MaxFailureRate: 25, MinSuites: 4}))
+	assert.Error(t, ValidateFailFast(FailFast{MaxFailures: -1}))
+	assert.Error(t, ValidateFailFast(FailFast{MaxFailureRate: 101}))
+	assert.Error(t, ValidateFailFast(FailFast{MinSuites: -1}))
+}
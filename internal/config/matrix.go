@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// matrixKey is the suite field that defines a matrix.
+const matrixKey = "matrix"
+
+// expandMatrices replaces every suite that defines a matrix with one suite
+// per combination of the matrix values.
+//
+// Matrix keys refer to suite fields. Nested fields are addressed by nesting
+// maps, e.g.:
+//
+//	matrix:
+//	  browserName: [chrome, firefox]
+//	  env:
+//	    LOCALE: [en, de]
+//
+// expands into four suites with browserName and env.LOCALE set accordingly.
+// The generated suites are named after the original suite and the values of
+// the combination, e.g. "my suite - chrome - en".
+func expandMatrices(cfg *yaml.Node) (*yaml.Node, error) {
+	suites := lookup(cfg, "suites")
+	if suites == nil || suites.Kind != yaml.SequenceNode {
+		return cfg, nil
+	}
+
+	expanded := *suites
+	expanded.Content = nil
+	for _, suite := range suites.Content {
+		matrix := lookup(suite, matrixKey)
+		if matrix == nil {
+			expanded.Content = append(expanded.Content, suite)
+			continue
+		}
+
+		var name string
+		if n := lookup(suite, "name"); n != nil {
+			name = n.Value
+		}
+		dims, err := matrixDimensions(matrix, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matrix in suite '%s': %w", name, err)
+		}
+
+		for _, combo := range combinations(dims) {
+			s := deepCopy(without(suite, matrixKey))
+
+			values := []string{name}
+			for i, d := range dims {
+				setPath(s, d.path, deepCopy(combo[i]))
+				values = append(values, combo[i].Value)
+			}
+			setPath(s, []string{"name"}, &yaml.Node{
+				Kind:  yaml.ScalarNode,
+				Tag:   "!!str",
+				Value: strings.Join(values, " - "),
+			})
+
+			expanded.Content = append(expanded.Content, s)
+		}
+	}
+
+	out := *cfg
+	out.Content = append([]*yaml.Node{}, cfg.Content...)
+	for i := 0; i+1 < len(out.Content); i += 2 {
+		if out.Content[i].Value == "suites" {
+			out.Content[i+1] = &expanded
+		}
+	}
+
+	return &out, nil
+}
+
+type dimension struct {
+	path   []string
+	values []*yaml.Node
+}
+
+func matrixDimensions(n *yaml.Node, path []string) ([]dimension, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a map at line %d", n.Line)
+	}
+
+	var dims []dimension
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := n.Content[i], n.Content[i+1]
+		p := append(append([]string{}, path...), key.Value)
+
+		switch val.Kind {
+		case yaml.SequenceNode:
+			if len(val.Content) == 0 {
+				return nil, fmt.Errorf("'%s' has no values", strings.Join(p, "."))
+			}
+			dims = append(dims, dimension{path: p, values: val.Content})
+		case yaml.MappingNode:
+			nested, err := matrixDimensions(val, p)
+			if err != nil {
+				return nil, err
+			}
+			dims = append(dims, nested...)
+		default:
+			return nil, fmt.Errorf("'%s' must be a list of values", strings.Join(p, "."))
+		}
+	}
+
+	return dims, nil
+}
+
+// combinations returns the cartesian product of the dimension values.
+func combinations(dims []dimension) [][]*yaml.Node {
+	combos := [][]*yaml.Node{{}}
+	for _, d := range dims {
+		var next [][]*yaml.Node
+		for _, c := range combos {
+			for _, v := range d.values {
+				combo := append(append([]*yaml.Node{}, c...), v)
+				next = append(next, combo)
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// setPath sets the value at the given path of the map m, creating
+// intermediate maps as needed.
+func setPath(m *yaml.Node, path []string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != path[0] {
+			continue
+		}
+		if len(path) == 1 {
+			m.Content[i+1] = value
+			return
+		}
+		if m.Content[i+1].Kind != yaml.MappingNode {
+			m.Content[i+1] = newMapping()
+		}
+		setPath(m.Content[i+1], path[1:], value)
+		return
+	}
+
+	key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[0]}
+	if len(path) == 1 {
+		m.Content = append(m.Content, key, value)
+		return
+	}
+	nested := newMapping()
+	setPath(nested, path[1:], value)
+	m.Content = append(m.Content, key, nested)
+}
+
+func deepCopy(n *yaml.Node) *yaml.Node {
+	out := *n
+	out.Content = make([]*yaml.Node, len(n.Content))
+	for i, c := range n.Content {
+		out.Content[i] = deepCopy(c)
+	}
+	return &out
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandMatrices(t *testing.T) {
+	cfg, err := parseMapping([]byte(`kind: testcafe
+suites:
+  - name: smoke
+    src: ["tests/smoke.js"]
+    matrix:
+      browserName: [chrome, firefox]
+      env:
+        LOCALE: [en, de]
+  - name: plain
+    browserName: safari
+`))
+	assert.NoError(t, err)
+
+	got, err := expandMatrices(cfg)
+	assert.NoError(t, err)
+
+	b, err := marshalNode(got)
+	assert.NoError(t, err)
+	assert.Equal(t, `kind: testcafe
+suites:
+  - name: smoke - chrome - en
+    src: ["tests/smoke.js"]
+    browserName: chrome
+    env:
+      LOCALE: en
+  - name: smoke - chrome - de
+    src: ["tests/smoke.js"]
+    browserName: chrome
+    env:
+      LOCALE: de
+  - name: smoke - firefox - en
+    src: ["tests/smoke.js"]
+    browserName: firefox
+    env:
+      LOCALE: en
+  - name: smoke - firefox - de
+    src: ["tests/smoke.js"]
+    browserName: firefox
+    env:
+      LOCALE: de
+  - name: plain
+    browserName: safari
+`, string(b))
+}
+
+func TestExpandMatrices_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     string
+		wantErr string
+	}{
+		{
+			name:    "scalar value",
+			cfg:     "suites:\n  - name: s\n    matrix:\n      browserName: chrome\n",
+			wantErr: "'browserName' must be a list of values",
+		},
+		{
+			name:    "empty list",
+			cfg:     "suites:\n  - name: s\n    matrix:\n      env:\n        LOCALE: []\n",
+			wantErr: "'env.LOCALE' has no values",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseMapping([]byte(tt.cfg))
+			assert.NoError(t, err)
+
+			_, err = expandMatrices(cfg)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Change describes a transformation applied by Migrate.
+type Change struct {
+	Description string `json:"description"`
+	// Manual indicates that the change could not be applied automatically and
+	// requires user action.
+	Manual bool `json:"manual,omitempty"`
+}
+
+func (c Change) String() string {
+	if c.Manual {
+		return "manual action required: " + c.Description
+	}
+	return c.Description
+}
+
+// migration rewrites a config document in place and returns the changes it
+// applied.
+type migration func(doc *yamlv3.Node, d TypeDef) []Change
+
+var migrations = []migration{
+	migrateCypressV1Alpha,
+	migrateTunnel,
+	migrateNpmRegistry,
+	migrateSmartRetry,
+	migrateTestCafeSuites,
+}
+
+// Migrate rewrites the given config, described by d, from deprecated formats
+// to the current one. Comments are preserved. If no changes are necessary, the
+// config is returned as is.
+func Migrate(b []byte, d TypeDef) ([]byte, []Change, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(b, &doc); err != nil {
+		return nil, nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yamlv3.MappingNode {
+		return b, nil, nil
+	}
+
+	var changes []Change
+	for _, m := range migrations {
+		changes = append(changes, m(doc.Content[0], d)...)
+	}
+	if len(changes) == 0 {
+		return b, nil, nil
+	}
+
+	out, err := marshalNode(&doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, changes, nil
+}
+
+func migrateCypressV1Alpha(root *yamlv3.Node, d TypeDef) []Change {
+	if d.Kind != "cypress" || d.APIVersion != "v1alpha" {
+		return nil
+	}
+
+	_, v := mapEntry(root, "apiVersion")
+	v.Value = "v1"
+	changes := []Change{{Description: "apiVersion: changed from v1alpha to v1"}}
+
+	for i, s := range suiteNodes(root) {
+		_, cfg := mapEntry(s, "config")
+		if cfg == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("suites[%d].config", i)
+		if renameKey(cfg, "testFiles", "specPattern") {
+			changes = append(changes,
+				Change{Description: fmt.Sprintf("%s.testFiles: renamed to specPattern", prefix)},
+				Change{
+					Description: fmt.Sprintf("%s.specPattern: testFiles were relative to the integrationFolder (cypress/integration by default), specPattern is relative to the project root. Prefix the patterns with the integrationFolder", prefix),
+					Manual:      true,
+				},
+			)
+		}
+		if renameKey(cfg, "excludedTestFiles", "excludeSpecPattern") {
+			changes = append(changes, Change{Description: fmt.Sprintf("%s.excludedTestFiles: renamed to excludeSpecPattern", prefix)})
+		}
+	}
+
+	if _, cf := mapEntry(root, "cypress", "configFile"); cf != nil && strings.HasSuffix(cf.Value, "cypress.json") {
+		changes = append(changes, Change{
+			Description: "cypress.configFile: cypress.json is not supported by Cypress 10 and above. Migrate it with Cypress and point configFile to the resulting cypress.config.js",
+			Manual:      true,
+		})
+	}
+
+	return changes
+}
+
+func migrateTunnel(root *yamlv3.Node, _ TypeDef) []Change {
+	_, tunnel := mapEntry(root, "sauce", "tunnel")
+	if tunnel == nil {
+		return nil
+	}
+
+	var changes []Change
+	if renameKey(tunnel, "id", "name") {
+		changes = append(changes, Change{Description: "sauce.tunnel.id: renamed to name"})
+	}
+	if renameKey(tunnel, "parent", "owner") {
+		changes = append(changes, Change{Description: "sauce.tunnel.parent: renamed to owner"})
+	}
+	return changes
+}
+
+func migrateNpmRegistry(root *yamlv3.Node, _ TypeDef) []Change {
+	_, npm := mapEntry(root, "npm")
+	if npm == nil {
+		return nil
+	}
+	k, registry := mapEntry(npm, "registry")
+	if registry == nil {
+		return nil
+	}
+
+	entry := &yamlv3.Node{Kind: yamlv3.MappingNode, Content: []*yamlv3.Node{
+		{Kind: yamlv3.ScalarNode, Value: "url"},
+		registry,
+	}}
+
+	_, registries := mapEntry(npm, "registries")
+	if registries == nil || registries.Kind != yamlv3.SequenceNode {
+		k.Value = "registries"
+		for i := 0; i < len(npm.Content); i += 2 {
+			if npm.Content[i] == k {
+				npm.Content[i+1] = &yamlv3.Node{Kind: yamlv3.SequenceNode, Content: []*yamlv3.Node{entry}}
+			}
+		}
+	} else {
+		registries.Content = append(registries.Content, entry)
+		removeKey(npm, "registry")
+	}
+
+	return []Change{{Description: "npm.registry: moved to npm.registries"}}
+}
+
+func migrateSmartRetry(root *yamlv3.Node, _ TypeDef) []Change {
+	var changes []Change
+	for i, s := range suiteNodes(root) {
+		_, sr := mapEntry(s, "smartRetry")
+		if sr != nil && renameKey(sr, "failedClassesOnly", "failedOnly") {
+			changes = append(changes, Change{Description: fmt.Sprintf("suites[%d].smartRetry.failedClassesOnly: renamed to failedOnly", i)})
+		}
+	}
+	return changes
+}
+
+func migrateTestCafeSuites(root *yamlv3.Node, d TypeDef) []Change {
+	if d.Kind != "testcafe" {
+		return nil
+	}
+
+	var changes []Change
+	for i, s := range suiteNodes(root) {
+		if renameKey(s, "devices", "simulators") {
+			changes = append(changes, Change{Description: fmt.Sprintf("suites[%d].devices: renamed to simulators", i)})
+		}
+
+		k, tsConfig := mapEntry(s, "tsConfigPath")
+		if tsConfig == nil {
+			continue
+		}
+		if _, existing := mapEntry(s, "compilerOptions", "typescript", "configPath"); existing == nil {
+			ts := ensureMap(ensureMap(s, "compilerOptions"), "typescript")
+			ts.Content = append(ts.Content, &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: "configPath", HeadComment: k.HeadComment}, tsConfig)
+		}
+		removeKey(s, "tsConfigPath")
+		changes = append(changes, Change{Description: fmt.Sprintf("suites[%d].tsConfigPath: moved to compilerOptions.typescript.configPath", i)})
+	}
+	return changes
+}
+
+// mapEntry returns the key and value nodes at the given path of nested maps.
+func mapEntry(n *yamlv3.Node, path ...string) (*yamlv3.Node, *yamlv3.Node) {
+	var k *yamlv3.Node
+	for _, p := range path {
+		if n == nil || n.Kind != yamlv3.MappingNode {
+			return nil, nil
+		}
+		var next *yamlv3.Node
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == p {
+				k, next = n.Content[i], n.Content[i+1]
+				break
+			}
+		}
+		if next == nil {
+			return nil, nil
+		}
+		n = next
+	}
+	return k, n
+}
+
+// renameKey renames the key of a map. If the new key already exists, the old
+// one is removed, since the new one takes precedence.
+func renameKey(n *yamlv3.Node, from, to string) bool {
+	k, _ := mapEntry(n, from)
+	if k == nil {
+		return false
+	}
+	if existing, _ := mapEntry(n, to); existing != nil {
+		removeKey(n, from)
+		return true
+	}
+	k.Value = to
+	return true
+}
+
+func removeKey(n *yamlv3.Node, key string) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			n.Content = append(n.Content[:i], n.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// ensureMap returns the map at the given key, creating it if necessary.
+func ensureMap(n *yamlv3.Node, key string) *yamlv3.Node {
+	if _, v := mapEntry(n, key); v != nil && v.Kind == yamlv3.MappingNode {
+		return v
+	}
+	removeKey(n, key)
+	v := &yamlv3.Node{Kind: yamlv3.MappingNode}
+	n.Content = append(n.Content, &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: key}, v)
+	return v
+}
+
+func suiteNodes(root *yamlv3.Node) []*yamlv3.Node {
+	_, suites := mapEntry(root, "suites")
+	if suites == nil || suites.Kind != yamlv3.SequenceNode {
+		return nil
+	}
+	return suites.Content
+}
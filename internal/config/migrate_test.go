@@ -0,0 +1,119 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		typeDef     TypeDef
+		want        string
+		wantChanges []Change
+	}{
+		{
+			name: "cypress v1alpha",
+			input: `apiVersion: v1alpha # legacy
+kind: cypress
+sauce:
+  tunnel:
+    # shared tunnel
+    id: my-tunnel
+cypress:
+  version: 9.7.0
+  configFile: cypress.json
+suites:
+  - name: chrome
+    config:
+      testFiles: ["**/*.cy.js"] # everything
+      excludedTestFiles: ["skip.cy.js"]
+    smartRetry:
+      failedClassesOnly: true
+`,
+			typeDef: TypeDef{APIVersion: "v1alpha", Kind: "cypress"},
+			want: `apiVersion: v1 # legacy
+kind: cypress
+sauce:
+  tunnel:
+    # shared tunnel
+    name: my-tunnel
+cypress:
+  version: 9.7.0
+  configFile: cypress.json
+suites:
+  - name: chrome
+    config:
+      specPattern: ["**/*.cy.js"] # everything
+      excludeSpecPattern: ["skip.cy.js"]
+    smartRetry:
+      failedOnly: true
+`,
+			wantChanges: []Change{
+				{Description: "apiVersion: changed from v1alpha to v1"},
+				{Description: "suites[0].config.testFiles: renamed to specPattern"},
+				{Description: "suites[0].config.specPattern: testFiles were relative to the integrationFolder (cypress/integration by default), specPattern is relative to the project root. Prefix the patterns with the integrationFolder", Manual: true},
+				{Description: "suites[0].config.excludedTestFiles: renamed to excludeSpecPattern"},
+				{Description: "cypress.configFile: cypress.json is not supported by Cypress 10 and above. Migrate it with Cypress and point configFile to the resulting cypress.config.js", Manual: true},
+				{Description: "sauce.tunnel.id: renamed to name"},
+				{Description: "suites[0].smartRetry.failedClassesOnly: renamed to failedOnly"},
+			},
+		},
+		{
+			name: "testcafe",
+			input: `apiVersion: v1alpha
+kind: testcafe
+npm:
+  registry: https://registry.example.com
+suites:
+  - name: safari
+    tsConfigPath: tsconfig.json
+    devices:
+      - name: iPhone 12 Simulator
+`,
+			typeDef: TypeDef{APIVersion: "v1alpha", Kind: "testcafe"},
+			want: `apiVersion: v1alpha
+kind: testcafe
+npm:
+  registries:
+    - url: https://registry.example.com
+suites:
+  - name: safari
+    simulators:
+      - name: iPhone 12 Simulator
+    compilerOptions:
+      typescript:
+        configPath: tsconfig.json
+`,
+			wantChanges: []Change{
+				{Description: "npm.registry: moved to npm.registries"},
+				{Description: "suites[0].devices: renamed to simulators"},
+				{Description: "suites[0].tsConfigPath: moved to compilerOptions.typescript.configPath"},
+			},
+		},
+		{
+			name: "up to date",
+			input: `apiVersion: v1
+kind: cypress
+suites:
+  -   name: untouched
+`,
+			typeDef: TypeDef{APIVersion: "v1", Kind: "cypress"},
+			want: `apiVersion: v1
+kind: cypress
+suites:
+  -   name: untouched
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changes, err := Migrate([]byte(tt.input), tt.typeDef)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+			assert.Equal(t, tt.wantChanges, changes)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Redacted replaces redacted values.
+const Redacted = "***REDACTED***"
+
+// DefaultRedactPatterns are the patterns of env keys whose values are always
+// redacted before the config is attached to jobs.
+var DefaultRedactPatterns = []string{"*KEY*", "*TOKEN*", "*SECRET*", "*PASSWORD*", "*PASSWD*", "*AUTH*", "*CREDENTIAL*"}
+
+// ConfigUpload represents the settings for attaching the config and CLI flags
+// to jobs.
+type ConfigUpload struct {
+	// Disabled prevents the config and CLI flags from being attached to jobs.
+	Disabled bool `yaml:"disabled,omitempty"`
+	// Redact lists additional patterns of env keys whose values are redacted,
+	// e.g. "MY_*". Matching is case-insensitive.
+	Redact []string `yaml:"redact,omitempty"`
+}
+
+// Patterns returns all patterns of env keys whose values are redacted.
+func (c ConfigUpload) Patterns() []string {
+	return append(append([]string{}, DefaultRedactPatterns...), c.Redact...)
+}
+
+// RedactEnv redacts the values of all env keys in the given config that either
+// match one of the patterns or are listed in keys. Comments and formatting are
+// preserved as far as possible.
+func RedactEnv(b []byte, patterns []string, keys []string) ([]byte, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	if !redactNode(&doc, patterns, keys) {
+		return b, nil
+	}
+
+	return marshalNode(&doc)
+}
+
+// redactNode walks the node and redacts the values of matching keys of any
+// env map. Returns true if anything was redacted.
+func redactNode(n *yamlv3.Node, patterns []string, keys []string) bool {
+	redacted := false
+	if n.Kind == yamlv3.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if k.Value == "env" && v.Kind == yamlv3.MappingNode {
+				redacted = redactEnvMap(v, patterns, keys) || redacted
+			}
+		}
+	}
+	for _, c := range n.Content {
+		redacted = redactNode(c, patterns, keys) || redacted
+	}
+	return redacted
+}
+
+func redactEnvMap(n *yamlv3.Node, patterns []string, keys []string) bool {
+	redacted := false
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if v.Kind != yamlv3.ScalarNode || v.Value == Redacted || !shouldRedact(k.Value, patterns, keys) {
+			continue
+		}
+		v.Value = Redacted
+		v.Tag = "!!str"
+		v.Style = 0
+		redacted = true
+	}
+	return redacted
+}
+
+func shouldRedact(key string, patterns []string, keys []string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(strings.ToUpper(p), strings.ToUpper(key)); ok {
+			return true
+		}
+	}
+	return false
+}
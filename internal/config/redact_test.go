@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactEnv(t *testing.T) {
+	input := `apiVersion: v1alpha
+kind: playwright
+env:
+  API_TOKEN: abc # used by fixtures
+  BASE_URL: https://example.com
+suites:
+  - name: chrome
+    env:
+      db_password: hunter2
+      MY_VAR: custom
+      FROM_FLAG: value
+`
+	got, err := RedactEnv([]byte(input), append(DefaultRedactPatterns, "MY_*"), []string{"FROM_FLAG"})
+	assert.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1alpha
+kind: playwright
+env:
+  API_TOKEN: '***REDACTED***' # used by fixtures
+  BASE_URL: https://example.com
+suites:
+  - name: chrome
+    env:
+      db_password: '***REDACTED***'
+      MY_VAR: '***REDACTED***'
+      FROM_FLAG: '***REDACTED***'
+`, string(got))
+}
+
+func TestRedactEnv_Untouched(t *testing.T) {
+	input := "env:\n    BASE_URL:   https://example.com\n"
+	got, err := RedactEnv([]byte(input), DefaultRedactPatterns, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, input, string(got))
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// The kinds of failures that a RetryPolicy retries on.
+const (
+	// RetryOnAny retries suites regardless of why they failed.
+	RetryOnAny = "any"
+	// RetryOnError retries suites only on infrastructure errors, e.g. a job
+	// that errored, timed out or could not be allocated a device. Suites with
+	// test failures aren't retried.
+	RetryOnError = "error"
+)
+
+// RetryPolicy controls when and how failed suites are retried.
+type RetryPolicy struct {
+	// Delay is the time to wait before the first retry.
+	Delay time.Duration `yaml:"delay,omitempty" json:"-"`
+	// Backoff multiplies the delay for each subsequent retry.
+	Backoff float64 `yaml:"backoff,omitempty" json:"-"`
+	// MaxDelay caps the delay between retries.
+	MaxDelay time.Duration `yaml:"maxDelay,omitempty" json:"-"`
+	// On restricts which failures are retried. Defaults to RetryOnAny.
+	On string `yaml:"on,omitempty" json:"-"`
+	// MaxRetries caps the total number of retries across all suites of a run.
+	// Unlimited if 0.
+	MaxRetries int `yaml:"maxRetries,omitempty" json:"-"`
+}
+
+// DelayFor returns the time to wait before the given retry, starting at 1.
+func (p RetryPolicy) DelayFor(retry int) time.Duration {
+	d := p.Delay
+	for i := 1; i < retry && p.Backoff > 1; i++ {
+		d = time.Duration(float64(d) * p.Backoff)
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
+
+// RetriesOn returns true if the policy retries a suite that failed due to an
+// infrastructure error (infraErr) or due to test failures.
+func (p RetryPolicy) RetriesOn(infraErr bool) bool {
+	return p.On != RetryOnError || infraErr
+}
+
+// ValidateRetryPolicy validates the retry policy.
+func ValidateRetryPolicy(p RetryPolicy) error {
+	if p.On != "" && p.On != RetryOnAny && p.On != RetryOnError {
+		return fmt.Errorf("invalid retryPolicy.on '%s', must be one of: %s, %s", p.On, RetryOnAny, RetryOnError)
+	}
+	if p.Delay < 0 || p.MaxDelay < 0 {
+		return errors.New("retryPolicy delays must not be negative")
+	}
+	if p.Backoff != 0 && p.Backoff < 1 {
+		return errors.New("retryPolicy.backoff must be at least 1")
+	}
+	if p.MaxRetries < 0 {
+		return errors.New("retryPolicy.maxRetries must not be negative")
+	}
+	return nil
+}
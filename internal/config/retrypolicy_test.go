@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestRetryPolicy_DelayFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		expected []time.Duration
+	}{
+		{
+			name:     "no delay",
+			policy:   RetryPolicy{},
+			expected: []time.Duration{0, 0, 0},
+		},
+		{
+			name:     "constant delay",
+			policy:   RetryPolicy{Delay: 10 * time.Second},
+			expected: []time.Duration{10 * time.Second, 10 * time.Second, 10 * time.Second},
+		},
+		{
+			name:     "exponential backoff",
+			policy:   RetryPolicy{Delay: 10 * time.Second, Backoff: 2},
+			expected: []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second},
+		},
+		{
+			name:     "capped backoff",
+			policy:   RetryPolicy{Delay: 10 * time.Second, Backoff: 3, MaxDelay: time.Minute},
+			expected: []time.Duration{10 * time.Second, 30 * time.Second, time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.expected {
+				assert.Equal(t, want, tt.policy.DelayFor(i+1), "retry %d", i+1)
+			}
+		})
+	}
+}
+
+func TestValidateRetryPolicy(t *testing.T) {
+	assert.NoError(t, ValidateRetryPolicy(RetryPolicy{}))
+	assert.NoError(t, ValidateRetryPolicy(RetryPolicy{On: RetryOnError, Delay: time.Second, Backoff: 2, MaxRetries: 3}))
+	assert.Error(t, ValidateRetryPolicy(RetryPolicy{On: "sometimes"}))
+	assert.Error(t, ValidateRetryPolicy(RetryPolicy{Backoff: 0.5}))
+	assert.Error(t, ValidateRetryPolicy(RetryPolicy{Delay: -time.Second}))
+	assert.Error(t, ValidateRetryPolicy(RetryPolicy{MaxRetries: -1}))
+}
+
+func TestRetryPolicy_Unmarshal(t *testing.T) {
+	dir := fs.NewDir(t, "configs",
+		fs.WithFile("base.yml", `sauce:
+  retryPolicy:
+    on: error
+`),
+		fs.WithFile("config.yml", `extends: base.yml
+sauce:
+  retryPolicy:
+    delay: 30s
+    maxRetries: 3
+`),
+	)
+	defer dir.Remove()
+	defer viper.Reset()
+
+	var p struct {
+		Sauce SauceConfig
+	}
+	assert.NoError(t, Unmarshal(dir.Join("config.yml"), &p))
+	assert.Equal(t, RetryPolicy{On: RetryOnError, Delay: 30 * time.Second, MaxRetries: 3}, p.Sauce.RetryPolicy)
+}
@@ -0,0 +1,412 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/saucelabs/saucectl/api"
+)
+
+// schemaURL identifies the embedded schema. It's never fetched.
+const schemaURL = "https://raw.githubusercontent.com/saucelabs/saucectl/main/api/saucectl.schema.json"
+
+// SchemaIssue describes a part of the config that violates the schema.
+type SchemaIssue struct {
+	// Path is the JSON pointer to the offending value, e.g. "/suites/0/name".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+
+	// File, Line and Column locate the offending value in the config file.
+	// Unset if the value can't be located, e.g. because it was pulled in by an
+	// extends directive.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+func (i SchemaIssue) String() string {
+	s := i.Message
+	if i.Path != "" {
+		s = fmt.Sprintf("%s in %s", i.Message, i.Path)
+	}
+	if i.Line > 0 {
+		s = fmt.Sprintf("%s:%d:%d: %s", i.File, i.Line, i.Column, s)
+	}
+	return s
+}
+
+// ValidateSchema validates user config against the JSON Schema.
+// If validation fails for any reason, fail softly to avoid disturbing execution as this is not critical.
+func ValidateSchema(cfgFile string) {
+	issues, err := CheckSchema(cfgFile)
+	if err != nil || len(issues) == 0 {
+		return
+	}
+	renderSchemaValidationIssues(cfgFile, issues)
+}
+
+// CheckSchema validates the config file against the JSON schema that is
+// embedded in saucectl and returns all issues found.
+func CheckSchema(cfgFile string) ([]SchemaIssue, error) {
+	yamlText, err := Resolve(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var m interface{}
+	if err := yamlv3.Unmarshal(yamlText, &m); err != nil {
+		return nil, err
+	}
+	m, err = toStringKeys(m)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := compileSchema()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema: %w", err)
+	}
+
+	err = schema.Validate(m)
+	if err == nil {
+		return nil, nil
+	}
+	var validationError *jsonschema.ValidationError
+	if !errors.As(err, &validationError) {
+		return nil, err
+	}
+
+	// The file itself is only used to point to the offending lines.
+	var doc yamlv3.Node
+	if b, err := os.ReadFile(cfgFile); err == nil {
+		_ = yamlv3.Unmarshal(b, &doc)
+	}
+
+	var issues []SchemaIssue
+	for _, cause := range findRootCauses(validationError) {
+		issue := SchemaIssue{
+			Path:    cause.InstanceLocation,
+			Message: cause.Message,
+		}
+		if n := locate(&doc, m, pointerTokens(cause.InstanceLocation)); n != nil {
+			issue.File = cfgFile
+			issue.Line = n.Line
+			issue.Column = n.Column
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues, nil
+}
+
+func compileSchema() (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(schemaURL, bytes.NewReader(api.Schema)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(schemaURL)
+}
+
+func renderSchemaValidationIssues(cfgFile string, issues []SchemaIssue) {
+	errStr := "error"
+	if len(issues) > 1 {
+		errStr = "errors"
+	}
+	fmt.Println()
+	color.Red("There is %d validation %s found in %s:\n", len(issues), errStr, cfgFile)
+	for _, i := range issues {
+		color.Red("- %s\n", i)
+	}
+	println()
+}
+
+func findRootCauses(validationError *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if validationError == nil {
+		return []*jsonschema.ValidationError{}
+	}
+
+	if len(validationError.Causes) == 0 {
+		return []*jsonschema.ValidationError{validationError}
+	}
+
+	var errors []*jsonschema.ValidationError
+	for _, cause := range validationError.Causes {
+		errors = append(errors, findRootCauses(cause)...)
+	}
+	return errors
+}
+
+func pointerTokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, t := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(t)
+	}
+	return tokens
+}
+
+// locate finds the node in the config file that corresponds to the value at
+// the given path of the resolved config. Since extends, include and matrix
+// directives may add list items, named items (e.g. suites) are matched by
+// name rather than by index.
+func locate(n *yamlv3.Node, resolved interface{}, tokens []string) *yamlv3.Node {
+	if n.Kind == yamlv3.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return locate(n.Content[0], resolved, tokens)
+	}
+	if len(tokens) == 0 {
+		return n
+	}
+
+	switch n.Kind {
+	case yamlv3.MappingNode:
+		m, _ := resolved.(map[string]interface{})
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value != tokens[0] {
+				continue
+			}
+			if len(tokens) == 1 {
+				return n.Content[i]
+			}
+			return locate(n.Content[i+1], m[tokens[0]], tokens[1:])
+		}
+	case yamlv3.SequenceNode:
+		l, _ := resolved.([]interface{})
+		idx, err := strconv.Atoi(tokens[0])
+		if err != nil || idx >= len(l) {
+			return nil
+		}
+		item := l[idx]
+		if m, ok := item.(map[string]interface{}); ok && m["name"] != nil {
+			for _, c := range n.Content {
+				if c.Kind != yamlv3.MappingNode {
+					continue
+				}
+				for i := 0; i+1 < len(c.Content); i += 2 {
+					if c.Content[i].Value == "name" && c.Content[i+1].Value == fmt.Sprint(m["name"]) {
+						return locate(c, item, tokens[1:])
+					}
+				}
+			}
+			return nil
+		}
+		if len(l) == len(n.Content) {
+			return locate(n.Content[idx], item, tokens[1:])
+		}
+	}
+
+	return nil
+}
+
+func toStringKeys(val interface{}) (interface{}, error) {
+	var err error
+	switch val := val.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{})
+		for k, v := range val {
+			k, ok := k.(string)
+			if !ok {
+				return nil, errors.New("found non-string key")
+			}
+			m[k], err = toStringKeys(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case []interface{}:
+		var l = make([]interface{}, len(val))
+		for i, v := range val {
+			l[i], err = toStringKeys(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
+	default:
+		return val, nil
+	}
+}
+
+// KeyDoc documents a config key.
+type KeyDoc struct {
+	Path        string        `json:"path"`
+	Description string        `json:"description,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Default     interface{}   `json:"default,omitempty"`
+	Values      []interface{} `json:"values,omitempty"`
+	Keys        []string      `json:"keys,omitempty"`
+}
+
+// Explain returns the documentation of the config key at the given path
+// (e.g. "suites.browserName") for the given kind of config.
+func Explain(kind, apiVersion, path string) (KeyDoc, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal(api.Schema, &root); err != nil {
+		return KeyDoc{}, err
+	}
+	s := schemaDoc{root: root}
+
+	node := s.kindSchema(kind, apiVersion)
+	if node == nil {
+		return KeyDoc{}, fmt.Errorf("unknown kind '%s'", kind)
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		key = strings.TrimSuffix(key, "[]")
+		if key == "" {
+			continue
+		}
+		node = s.property(node, key)
+		if node == nil {
+			return KeyDoc{}, fmt.Errorf("unknown config key '%s'", path)
+		}
+	}
+
+	doc := KeyDoc{
+		Path:    path,
+		Default: node["default"],
+		Keys:    s.keys(node),
+	}
+	doc.Description, _ = node["description"].(string)
+	doc.Values, _ = node["enum"].([]interface{})
+
+	switch t := node["type"].(type) {
+	case string:
+		doc.Type = t
+	case []interface{}:
+		var types []string
+		for _, v := range t {
+			types = append(types, fmt.Sprint(v))
+		}
+		doc.Type = strings.Join(types, " | ")
+	}
+	if items := s.resolve(node["items"]); doc.Type == "array" && items["type"] != nil {
+		doc.Type = fmt.Sprintf("array of %s", items["type"])
+	}
+
+	return doc, nil
+}
+
+type schemaDoc struct {
+	root map[string]interface{}
+}
+
+// kindSchema returns the schema that applies to the given kind of config.
+func (s schemaDoc) kindSchema(kind, apiVersion string) map[string]interface{} {
+	branches, _ := s.root["allOf"].([]interface{})
+	for _, b := range branches {
+		branch, _ := b.(map[string]interface{})
+		cond := s.lookup(branch, "if", "properties")
+		if s.lookup(cond, "kind")["const"] != kind {
+			continue
+		}
+		if v, ok := s.lookup(cond, "apiVersion")["const"]; ok && apiVersion != "" && v != apiVersion {
+			continue
+		}
+		return s.lookup(branch, "then")
+	}
+	return nil
+}
+
+func (s schemaDoc) lookup(n map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, k := range keys {
+		n, _ = n[k].(map[string]interface{})
+	}
+	return n
+}
+
+// resolve follows $ref to the referenced schema.
+func (s schemaDoc) resolve(v interface{}) map[string]interface{} {
+	n, _ := v.(map[string]interface{})
+	ref, ok := n["$ref"].(string)
+	if !ok {
+		return n
+	}
+
+	var cur interface{} = s.root
+	for _, t := range pointerTokens(strings.TrimPrefix(ref, "#")) {
+		switch c := cur.(type) {
+		case map[string]interface{}:
+			cur = c[t]
+		case []interface{}:
+			idx, err := strconv.Atoi(t)
+			if err != nil || idx >= len(c) {
+				return nil
+			}
+			cur = c[idx]
+		}
+	}
+
+	// Keywords next to $ref take precedence over the referenced ones.
+	out := map[string]interface{}{}
+	for k, v := range s.resolve(cur) {
+		out[k] = v
+	}
+	for k, v := range n {
+		if k != "$ref" {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// subschemas returns the schemas that the node is composed of, including
+// itself. Arrays are represented by the schema of their items.
+func (s schemaDoc) subschemas(n map[string]interface{}) []map[string]interface{} {
+	n = s.resolve(n)
+	if n == nil {
+		return nil
+	}
+	if items, ok := n["items"]; ok {
+		n = s.resolve(items)
+	}
+
+	out := []map[string]interface{}{n}
+	for _, k := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, _ := n[k].([]interface{})
+		for _, sub := range subs {
+			m, _ := sub.(map[string]interface{})
+			out = append(out, s.subschemas(m)...)
+		}
+	}
+	return out
+}
+
+func (s schemaDoc) property(n map[string]interface{}, key string) map[string]interface{} {
+	for _, sub := range s.subschemas(n) {
+		if p, ok := s.lookup(sub, "properties")[key]; ok {
+			return s.resolve(p)
+		}
+	}
+	return nil
+}
+
+func (s schemaDoc) keys(n map[string]interface{}) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, sub := range s.subschemas(n) {
+		for k := range s.lookup(sub, "properties") {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
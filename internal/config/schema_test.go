@@ -0,0 +1,147 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestCheckSchema(t *testing.T) {
+	dir := fs.NewDir(t, "configs",
+		fs.WithFile("base.yml", `apiVersion: v1alpha
+kind: testcafe
+sauce:
+  region: us-west-1
+testcafe:
+  version: package.json
+suites:
+  - name: inherited
+    browserName: chrome
+    src: ["tests/*.js"]
+    unknown: true
+`),
+		fs.WithFile("config.yml", `extends: base.yml
+sauce:
+  concurrency: "many"
+suites:
+  - name: own
+    browserName: chrome
+    src: ["tests/*.js"]
+    bogus: true
+`),
+		fs.WithFile("valid.yml", `apiVersion: v1alpha
+kind: testcafe
+sauce:
+  metadata:
+    build: "{{.Repo}}#{{.PR}}"
+    customData:
+      team: web
+  retryPolicy:
+    on: error
+    delay: 30s
+reporters:
+  pullRequest:
+    enabled: true
+    checkRun: true
+testcafe:
+  version: package.json
+suites:
+  - name: chrome
+    browserName: chrome
+    src: ["tests/*.js"]
+`),
+	)
+	defer dir.Remove()
+
+	cfgFile := filepath.Join(dir.Path(), "config.yml")
+	issues, err := CheckSchema(cfgFile)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []SchemaIssue{
+		{
+			Path:    "/sauce/concurrency",
+			Message: "expected integer, but got string",
+			File:    cfgFile,
+			Line:    3,
+			Column:  3,
+		},
+		{
+			Path:    "/suites/0",
+			Message: "additionalProperties 'unknown' not allowed",
+		},
+		{
+			Path:    "/suites/1",
+			Message: "additionalProperties 'bogus' not allowed",
+			File:    cfgFile,
+			Line:    5,
+			Column:  5,
+		},
+	}, issues)
+
+	issues, err = CheckSchema(filepath.Join(dir.Path(), "valid.yml"))
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       string
+		apiVersion string
+		path       string
+		want       KeyDoc
+		wantErr    bool
+	}{
+		{
+			name: "scalar",
+			kind: "cypress",
+			path: "sauce.concurrency",
+			want: KeyDoc{
+				Path:        "sauce.concurrency",
+				Description: "Sets the maximum number of suites to execute at the same time. Excess suites are queued and run in order as each suite completes.",
+				Type:        "integer",
+			},
+		},
+		{
+			name: "referenced enum",
+			kind: "testcafe",
+			path: "suites.browserName",
+			want: KeyDoc{
+				Path:        "suites.browserName",
+				Description: "The name of the browser in which to run the tests.",
+				Values:      []interface{}{"chrome", "firefox", "microsoftedge", "safari"},
+			},
+		},
+		{
+			name:    "unknown key",
+			kind:    "testcafe",
+			path:    "suites.nope",
+			wantErr: true,
+		},
+		{
+			name:    "unknown kind",
+			kind:    "nope",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Explain(tt.kind, tt.apiVersion, tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExplain_Keys(t *testing.T) {
+	got, err := Explain("playwright", "v1alpha", "suites")
+	assert.NoError(t, err)
+	assert.Equal(t, "array of object", got.Type)
+	assert.Contains(t, got.Keys, "params")
+	assert.Contains(t, got.Keys, "matrix")
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/vmd"
+)
+
+// ResolveSuiteVirtualDevices resolves the virtual devices of all suites. See
+// ResolveVirtualDevices. devices returns the virtual devices of a suite.
+func ResolveSuiteVirtualDevices[S any](ctx context.Context, reader vmd.Reader, kind string, suites []S, devices func(*S) *[]VirtualDevice) error {
+	var all []*[]VirtualDevice
+	for i := range suites {
+		all = append(all, devices(&suites[i]))
+	}
+	return ResolveVirtualDevices(ctx, reader, kind, all...)
+}
+
+// ResolveVirtualDevices resolves the names and platform versions of the given
+// virtual devices against the ones that are available on Sauce Labs, e.g.
+// "latest-1" is replaced with the version before the latest one. Unknown
+// device names and platform versions are rejected.
+// If the available devices can't be fetched, devices with explicit platform
+// versions are left as is, since they may still be valid.
+func ResolveVirtualDevices(ctx context.Context, reader vmd.Reader, kind string, devices ...*[]VirtualDevice) error {
+	var all []VirtualDevice
+	for _, d := range devices {
+		all = append(all, *d...)
+	}
+	if len(all) == 0 {
+		return nil
+	}
+
+	available, err := reader.GetVirtualDevices(ctx, kind)
+	if err != nil {
+		if hasVersionConstraints(all) {
+			return fmt.Errorf("failed to resolve platform versions: %w", err)
+		}
+		log.Warn().Err(err).Msg("Unable to fetch the available virtual devices. Skipping validation of virtual devices.")
+		return nil
+	}
+
+	for _, d := range devices {
+		resolved, err := resolveVirtualDevices(available, *d)
+		if err != nil {
+			return err
+		}
+		*d = resolved
+	}
+
+	return nil
+}
+
+func resolveVirtualDevices(available []vmd.VirtualDevice, devices []VirtualDevice) ([]VirtualDevice, error) {
+	var resolved []VirtualDevice
+	for _, d := range devices {
+		dev, ok := vmd.Find(available, d.Name)
+		if !ok {
+			return nil, fmt.Errorf("virtual device %q does not exist", d.Name)
+		}
+
+		versions, err := vmd.ResolveVersions(dev.OSVersion, d.PlatformVersions)
+		if err != nil {
+			return nil, fmt.Errorf("virtual device %q: %w", d.Name, err)
+		}
+		if hasVersionConstraints([]VirtualDevice{d}) {
+			log.Info().Str("device", dev.Name).Strs("platformVersions", versions).Msg("Resolved platform versions.")
+		}
+
+		d.Name = dev.Name
+		d.PlatformVersions = versions
+		resolved = append(resolved, d)
+	}
+	return resolved, nil
+}
+
+func hasVersionConstraints(devices []VirtualDevice) bool {
+	for _, d := range devices {
+		for _, v := range d.PlatformVersions {
+			if vmd.IsConstraint(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/mocks"
+	"github.com/saucelabs/saucectl/internal/vmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveVirtualDevices(t *testing.T) {
+	reader := &mocks.FakeEmulatorsReader{
+		GetVirtualDevicesFn: func(_ context.Context, kind string) ([]vmd.VirtualDevice, error) {
+			assert.Equal(t, vmd.AndroidEmulator, kind)
+			return []vmd.VirtualDevice{
+				{Name: "Google Pixel 6 GoogleAPI Emulator", OSVersion: []string{"11.0", "12.0", "13.0"}},
+				{Name: "Samsung Galaxy S9 HD GoogleAPI Emulator", OSVersion: []string{"8.1", "9.0"}},
+			}, nil
+		},
+	}
+
+	suite1 := []Emulator{
+		{Name: "google pixel 6 googleapi emulator", PlatformVersions: []string{"latest", "latest-1"}, Orientation: "portrait"},
+	}
+	suite2 := []Emulator{
+		{Name: "Samsung Galaxy S9 HD GoogleAPI Emulator", PlatformVersions: []string{"8.1"}},
+		{Name: "Google Pixel 6 GoogleAPI Emulator", PlatformVersions: []string{">=11 <13"}},
+	}
+	var suite3 []Emulator
+
+	err := ResolveVirtualDevices(context.Background(), reader, vmd.AndroidEmulator, &suite1, &suite2, &suite3)
+	assert.NoError(t, err)
+	assert.Equal(t, []Emulator{
+		{Name: "Google Pixel 6 GoogleAPI Emulator", PlatformVersions: []string{"13.0", "12.0"}, Orientation: "portrait"},
+	}, suite1)
+	assert.Equal(t, []Emulator{
+		{Name: "Samsung Galaxy S9 HD GoogleAPI Emulator", PlatformVersions: []string{"8.1"}},
+		{Name: "Google Pixel 6 GoogleAPI Emulator", PlatformVersions: []string{"12.0", "11.0"}},
+	}, suite2)
+	assert.Empty(t, suite3)
+
+	unknown := []Emulator{{Name: "Google Pixel 60 Emulator", PlatformVersions: []string{"13.0"}}}
+	err = ResolveVirtualDevices(context.Background(), reader, vmd.AndroidEmulator, &unknown)
+	assert.EqualError(t, err, `virtual device "Google Pixel 60 Emulator" does not exist`)
+
+	typo := []Emulator{{Name: "Google Pixel 6 GoogleAPI Emulator", PlatformVersions: []string{"31.0"}}}
+	err = ResolveVirtualDevices(context.Background(), reader, vmd.AndroidEmulator, &typo)
+	assert.EqualError(t, err, `virtual device "Google Pixel 6 GoogleAPI Emulator": version "31.0" is not available, choose from: 13.0, 12.0, 11.0`)
+}
+
+func TestResolveVirtualDevices_Unavailable(t *testing.T) {
+	reader := &mocks.FakeEmulatorsReader{
+		GetVirtualDevicesFn: func(context.Context, string) ([]vmd.VirtualDevice, error) {
+			return nil, errors.New("service unavailable")
+		},
+	}
+
+	explicit := []Simulator{{Name: "iPhone 14 Simulator", PlatformVersions: []string{"16.2"}}}
+	err := ResolveVirtualDevices(context.Background(), reader, vmd.IOSSimulator, &explicit)
+	assert.NoError(t, err)
+	assert.Equal(t, []Simulator{{Name: "iPhone 14 Simulator", PlatformVersions: []string{"16.2"}}}, explicit)
+
+	latest := []Simulator{{Name: "iPhone 14 Simulator", PlatformVersions: []string{"latest"}}}
+	err = ResolveVirtualDevices(context.Background(), reader, vmd.IOSSimulator, &explicit, &latest)
+	assert.EqualError(t, err, "failed to resolve platform versions: service unavailable")
+}
+
+func TestResolveSuiteVirtualDevices(t *testing.T) {
+	reader := &mocks.FakeEmulatorsReader{
+		GetVirtualDevicesFn: func(context.Context, string) ([]vmd.VirtualDevice, error) {
+			return []vmd.VirtualDevice{{Name: "iPhone 14 Simulator", OSVersion: []string{"16.2", "17.0"}}}, nil
+		},
+	}
+
+	type suite struct {
+		Simulators []Simulator
+	}
+	suites := []suite{
+		{Simulators: []Simulator{{Name: "iPhone 14 Simulator", PlatformVersions: []string{"latest"}}}},
+		{},
+		{Simulators: []Simulator{{Name: "iPhone 14 Simulator", PlatformVersions: []string{"16.x"}}}},
+	}
+
+	err := ResolveSuiteVirtualDevices(context.Background(), reader, vmd.IOSSimulator, suites, func(s *suite) *[]Simulator {
+		return &s.Simulators
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []suite{
+		{Simulators: []Simulator{{Name: "iPhone 14 Simulator", PlatformVersions: []string{"17.0"}}}},
+		{},
+		{Simulators: []Simulator{{Name: "iPhone 14 Simulator", PlatformVersions: []string{"16.2"}}}},
+	}, suites)
+}
@@ -18,8 +18,10 @@ import (
 	"github.com/saucelabs/saucectl/internal/insights"
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/node"
+	"github.com/saucelabs/saucectl/internal/pattern"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/saucereport"
+	"github.com/saucelabs/saucectl/internal/slice"
 )
 
 // Config descriptors.
@@ -53,6 +55,9 @@ type Project struct {
 	Env           map[string]string `yaml:"env,omitempty" json:"env"`
 	EnvFlag       map[string]string `yaml:"-" json:"-"`
 	NodeVersion   string            `yaml:"nodeVersion,omitempty" json:"nodeVersion,omitempty"`
+	// ScenarioDurations holds the average duration of previous runs per
+	// scenario. It's used to balance suites that are sharded by duration.
+	ScenarioDurations map[ScenarioKey]time.Duration `yaml:"-" json:"-"`
 }
 
 // Playwright represents the playwright setting
@@ -75,8 +80,16 @@ type Suite struct {
 	PreExec          []string          `yaml:"preExec,omitempty" json:"preExec"`
 	Options          Options           `yaml:"options,omitempty" json:"options"`
 	PassThreshold    int               `yaml:"passThreshold,omitempty" json:"-"`
+	Priority         int               `yaml:"priority,omitempty" json:"-"`
+	DependsOn        []string          `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry       config.SmartRetry `yaml:"smartRetry,omitempty" json:"-"`
 	ARMRequired      bool              `yaml:"armRequired,omitempty" json:"armRequired"`
+	ShardPlan        ShardPlan         `yaml:"-" json:"-"`
+}
+
+// Dependencies returns the suites that the suite depends on.
+func (s Suite) Dependencies() config.SuiteDependencies {
+	return config.SuiteDependencies{Name: s.Name, DependsOn: s.DependsOn}
 }
 
 // Options represents cucumber settings
@@ -183,6 +196,18 @@ func Validate(p *Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := config.ValidateRetryPolicy(p.Sauce.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := config.ValidateFailFast(p.Sauce.FailFast); err != nil {
+		return err
+	}
+
+	if err := config.ValidateSuiteDependencies(p.Suites); err != nil {
+		return err
+	}
+
 	err := config.ValidateRegistries(p.Npm.Registries)
 	if err != nil {
 		return err
@@ -236,13 +261,19 @@ func Validate(p *Project) error {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
 	}
 
-	p.Suites, err = shardSuites(p.RootDir, p.Suites, p.Sauce.Concurrency)
+	return nil
+}
 
+// ShardSuites divides the suites of a validated project into shards. Suites
+// that are sharded by duration are balanced by p.ScenarioDurations.
+func ShardSuites(p *Project) error {
+	var err error
+	p.Suites, err = shardSuites(p.RootDir, p.Suites, p.Sauce.Concurrency, p.ScenarioDurations)
 	return err
 }
 
 // shardSuites divides suites into shards based on the pattern.
-func shardSuites(rootDir string, suites []Suite, ccy int) ([]Suite, error) {
+func shardSuites(rootDir string, suites []Suite, ccy int, durations map[ScenarioKey]time.Duration) ([]Suite, error) {
 	var shardedSuites []Suite
 
 	for _, s := range suites {
@@ -260,11 +291,7 @@ func shardSuites(rootDir string, suites []Suite, ccy int) ([]Suite, error) {
 		}
 
 		if s.ShardTagsEnabled && len(s.Options.Tags) > 0 {
-			tags := make([]string, len(s.Options.Tags))
-			for i, t := range s.Options.Tags {
-				tags[i] = fmt.Sprintf("(%s)", t)
-			}
-			tagExp := strings.Join(tags, " and ")
+			tagExp := tagExpression(s.Options.Tags)
 
 			var unmatched []string
 			files, unmatched = tag.MatchFiles(os.DirFS(rootDir), files, tagExp)
@@ -315,11 +342,28 @@ func shardSuites(rootDir string, suites []Suite, ccy int) ([]Suite, error) {
 				shardedSuites = append(shardedSuites, replica)
 			}
 		}
+		if s.Shard == "duration" {
+			replicas, err := shardByDuration(rootDir, s, testFiles, ccy, durations)
+			if err != nil {
+				return []Suite{}, err
+			}
+			shardedSuites = append(shardedSuites, replicas...)
+		}
 	}
 
 	return shardedSuites, nil
 }
 
+// tagExpression combines the given tag expressions into a single one that
+// requires all of them to match.
+func tagExpression(tags []string) string {
+	exps := make([]string, len(tags))
+	for i, t := range tags {
+		exps[i] = fmt.Sprintf("(%s)", t)
+	}
+	return strings.Join(exps, " and ")
+}
+
 // FilterSuites filters out suites in the project that don't match the given suite name.
 func FilterSuites(p *Project, suiteName string) error {
 	for _, s := range p.Suites {
@@ -331,6 +375,16 @@ func FilterSuites(p *Project, suiteName string) error {
 	return fmt.Errorf("no suite named '%s' found", suiteName)
 }
 
+// ShardsByDuration returns true if any of the suites is sharded by duration.
+func (p *Project) ShardsByDuration() bool {
+	for _, s := range p.Suites {
+		if s.Shard == "duration" {
+			return true
+		}
+	}
+	return false
+}
+
 // GetShardTypes returns the shard types in a project.
 func GetShardTypes(suites []Suite) []string {
 	var set = map[string]bool{}
@@ -378,14 +432,15 @@ func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
 	return res
 }
 
-// FilterFailedTests takes the failed specs in the report and sets them as a test filter in the suite.
+// FilterFailedTests takes the failed scenarios in the report and sets them as a test filter in the suite.
+// Scenarios are selected by line if the report provides it, by name otherwise.
 // The test filter remains unchanged if the report does not contain any failed tests.
 func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceReport) error {
-	specs, err := getFailedSpecFiles(report)
+	paths, names, err := getFailedScenarios(report)
 	if err != nil {
 		return err
 	}
-	if len(specs) == 0 {
+	if len(paths) == 0 {
 		return nil
 	}
 
@@ -394,7 +449,12 @@ func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceRe
 		if s.Name != suiteName {
 			continue
 		}
-		p.Suites[i].Options.Paths = specs
+		p.Suites[i].Options.Paths = paths
+		// Failed scenarios are limited to those that match the name filter of
+		// the suite, which is kept if none of them do.
+		if names := pattern.Matching(names, s.Options.Name); len(names) > 0 {
+			p.Suites[i].Options.Name = pattern.ExactMatch(names)
+		}
 		found = true
 		break
 	}
@@ -405,24 +465,48 @@ func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceRe
 	return nil
 }
 
-func getFailedSpecFiles(report saucereport.SauceReport) ([]string, error) {
-	var failedSpecs []string
+// getFailedScenarios returns the paths of the failed scenarios. Paths are of
+// the form "file.feature:line" if the report provides the lines of all failed
+// scenarios. Otherwise, paths refer to whole feature files and the names of the
+// failed scenarios are returned as well.
+func getFailedScenarios(report saucereport.SauceReport) (paths []string, names []string, err error) {
 	if report.Status != saucereport.StatusFailed {
-		return failedSpecs, nil
+		return nil, nil, nil
 	}
 
 	re, err := regexp.Compile(".*.feature$")
 	if err != nil {
-		return failedSpecs, err
+		return nil, nil, err
 	}
 
+	var files, lines []string
+	byLine := true
 	for _, s := range report.Suites {
-		if s.Status == saucereport.StatusFailed && re.MatchString(s.Name) {
-			failedSpecs = append(failedSpecs, filepath.Clean(s.Name))
+		if s.Status != saucereport.StatusFailed || !re.MatchString(s.Name) {
+			continue
+		}
+		file := filepath.Clean(s.Name)
+		files = append(files, file)
+
+		failed := saucereport.FailedTests(s)
+		if len(failed) == 0 {
+			byLine = false
+		}
+		for _, t := range failed {
+			names = append(names, t.Name)
+			line, ok := t.Metadata["line"].(float64)
+			if !ok || line <= 0 {
+				byLine = false
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("%s:%d", file, int(line)))
 		}
 	}
 
-	return failedSpecs, nil
+	if byLine {
+		return lines, nil, nil
+	}
+	return files, slice.Dedupe(names), nil
 }
 
 // IsSmartRetried checks if the suites contain a smartRetried suite
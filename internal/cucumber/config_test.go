@@ -165,3 +165,80 @@ func TestCucumber_FilterFailedTests(t *testing.T) {
 		})
 	}
 }
+
+func TestCucumber_FilterFailedTests_Scenarios(t *testing.T) {
+	newReport := func(failed ...saucereport.Test) saucereport.SauceReport {
+		return saucereport.SauceReport{
+			Status: saucereport.StatusFailed,
+			Suites: []saucereport.Suite{
+				{
+					Name:   "features/checkout.feature",
+					Status: saucereport.StatusFailed,
+					Suites: []saucereport.Suite{
+						{
+							Name:   "Checkout",
+							Status: saucereport.StatusFailed,
+							Tests: append([]saucereport.Test{
+								{Status: saucereport.StatusPassed, Name: "pays with card"},
+							}, failed...),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name       string
+		report     saucereport.SauceReport
+		nameFilter string
+		wantPaths  []string
+		wantName   string
+	}{
+		{
+			name: "by line",
+			report: newReport(
+				saucereport.Test{Status: saucereport.StatusFailed, Name: "pays with voucher", Metadata: saucereport.Metadata{"line": float64(12)}},
+				saucereport.Test{Status: saucereport.StatusFailed, Name: "pays (twice)", Metadata: saucereport.Metadata{"line": float64(20)}},
+			),
+			wantPaths: []string{"features/checkout.feature:12", "features/checkout.feature:20"},
+		},
+		{
+			name: "by name",
+			report: newReport(
+				saucereport.Test{Status: saucereport.StatusFailed, Name: "pays with voucher", Metadata: saucereport.Metadata{"line": float64(12)}},
+				saucereport.Test{Status: saucereport.StatusFailed, Name: "pays (twice)"},
+			),
+			wantPaths: []string{"features/checkout.feature"},
+			wantName:  `^(pays with voucher|pays \(twice\))$`,
+		},
+		{
+			name: "by name within the name filter",
+			report: newReport(
+				saucereport.Test{Status: saucereport.StatusFailed, Name: "pays with voucher"},
+				saucereport.Test{Status: saucereport.StatusFailed, Name: "pays (twice)"},
+			),
+			nameFilter: "voucher",
+			wantPaths:  []string{"features/checkout.feature"},
+			wantName:   `^(pays with voucher)$`,
+		},
+		{
+			name: "keeps the name filter if no failed scenario matches",
+			report: newReport(
+				saucereport.Test{Status: saucereport.StatusFailed, Name: "pays (twice)"},
+			),
+			nameFilter: "^refunds",
+			wantPaths:  []string{"features/checkout.feature"},
+			wantName:   "^refunds",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Project{Suites: []Suite{{Name: "my suite", Options: Options{Name: tc.nameFilter}}}}
+			err := p.FilterFailedTests("my suite", tc.report)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantPaths, p.Suites[0].Options.Paths)
+			assert.Equal(t, tc.wantName, p.Suites[0].Options.Name)
+		})
+	}
+}
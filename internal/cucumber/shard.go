@@ -0,0 +1,240 @@
+package cucumber
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/cucumber/scenario"
+	"github.com/saucelabs/saucectl/internal/cucumber/tag"
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/pattern"
+)
+
+// ShardPlan represents the scenarios that were planned for a sharded suite.
+type ShardPlan struct {
+	Scenarios []string
+	// Estimated is the expected duration of the shard, based on previous
+	// scenario runs. It's zero if none of the scenarios ran before.
+	Estimated time.Duration
+}
+
+// ScenarioKey identifies a scenario by its feature file and name, since
+// scenario names are only unique within a feature file.
+type ScenarioKey struct {
+	File string
+	Name string
+}
+
+// ScenarioDurations returns the average duration of the scenarios of the given
+// test runs. Only test runs of the given suites, including their shards, are
+// taken into account. The feature file of a scenario is taken from the suite
+// path of its test run. Test runs without a feature file are ignored.
+func ScenarioDurations(runs []insights.TestRun, suites []Suite) map[ScenarioKey]time.Duration {
+	type total struct {
+		duration int
+		runs     int
+	}
+
+	totals := map[ScenarioKey]*total{}
+	for _, r := range runs {
+		if r.Status == insights.StateSkipped || r.SauceJob == nil || !isSuiteJob(r.SauceJob.Name, suites) {
+			continue
+		}
+		file := featureFile(r.PathName)
+		if file == "" {
+			continue
+		}
+
+		k := ScenarioKey{File: file, Name: r.Name}
+		if totals[k] == nil {
+			totals[k] = &total{}
+		}
+		totals[k].duration += r.Duration
+		totals[k].runs++
+	}
+
+	durations := map[ScenarioKey]time.Duration{}
+	for k, t := range totals {
+		durations[k] = time.Duration(t.duration) * time.Second / time.Duration(t.runs)
+	}
+	return durations
+}
+
+// isSuiteJob returns true if the job ran one of the suites or their shards.
+func isSuiteJob(job string, suites []Suite) bool {
+	for _, s := range suites {
+		if job == s.Name || strings.HasPrefix(job, s.Name+" - ") {
+			return true
+		}
+	}
+	return false
+}
+
+// featureFile returns the feature file within the suite path of a test run.
+func featureFile(path string) string {
+	for _, p := range strings.Split(path, insights.SuitePathSeparator) {
+		if strings.HasSuffix(p, ".feature") {
+			return strings.TrimPrefix(filepath.ToSlash(p), "./")
+		}
+	}
+	return ""
+}
+
+// plannedScenario represents all occurrences of a scenario name, which are
+// always assigned to the same shard, since scenarios are selected by name.
+type plannedScenario struct {
+	name string
+	// files holds the number of occurrences per feature file.
+	files    map[string]int
+	estimate time.Duration
+}
+
+// shardByDuration splits the scenarios of the suite into at most ccy shards,
+// so that each shard takes roughly the same time to run. Scenarios that didn't
+// run before are estimated by the average duration of the known ones.
+func shardByDuration(rootDir string, s Suite, files []string, ccy int, durations map[ScenarioKey]time.Duration) ([]Suite, error) {
+	scenarios := scenario.List(os.DirFS(rootDir), files)
+	if len(s.Options.Tags) > 0 {
+		var err error
+		scenarios, err = tag.MatchScenarios(scenarios, tagExpression(s.Options.Tags))
+		if err != nil {
+			return nil, fmt.Errorf("suite '%s' has an invalid tag expression: %w", s.Name, err)
+		}
+	}
+
+	var nameFilter *regexp.Regexp
+	if s.Options.Name != "" {
+		var err error
+		if nameFilter, err = regexp.Compile(s.Options.Name); err != nil {
+			return nil, fmt.Errorf("suite '%s' has an invalid name pattern: %w", s.Name, err)
+		}
+	}
+
+	var planned []*plannedScenario
+	byName := map[string]*plannedScenario{}
+	for _, sc := range scenarios {
+		if nameFilter != nil && !nameFilter.MatchString(sc.Name) {
+			continue
+		}
+		ps, ok := byName[sc.Name]
+		if !ok {
+			ps = &plannedScenario{name: sc.Name, files: map[string]int{}}
+			byName[sc.Name] = ps
+			planned = append(planned, ps)
+		}
+		ps.files[sc.Uri]++
+	}
+	if len(planned) == 0 {
+		return nil, fmt.Errorf("suite '%s' has no matching scenarios", s.Name)
+	}
+
+	known := estimateScenarios(planned, durations)
+
+	buckets := balanceScenarios(planned, ccy)
+	var suites []Suite
+	for i, b := range buckets {
+		var names []string
+		inBucket := map[string]bool{}
+		var estimated time.Duration
+		for _, ps := range b {
+			names = append(names, ps.name)
+			for f := range ps.files {
+				inBucket[f] = true
+			}
+			estimated += ps.estimate
+		}
+		sort.Strings(names)
+		if !known {
+			estimated = 0
+		}
+
+		// Keep the original order of the test files.
+		var paths []string
+		for _, f := range files {
+			if inBucket[f] {
+				paths = append(paths, f)
+			}
+		}
+
+		replica := s
+		replica.Name = fmt.Sprintf("%s - %d/%d", s.Name, i+1, len(buckets))
+		replica.Options.Paths = paths
+		replica.Options.Name = pattern.ExactMatch(names)
+		replica.ShardPlan = ShardPlan{Scenarios: names, Estimated: estimated}
+		suites = append(suites, replica)
+	}
+
+	return suites, nil
+}
+
+// estimateScenarios sets the estimated duration of each scenario and returns
+// true if any of them ran before.
+func estimateScenarios(planned []*plannedScenario, durations map[ScenarioKey]time.Duration) bool {
+	var total time.Duration
+	var known int
+	for _, ps := range planned {
+		for f := range ps.files {
+			if d, ok := durations[ScenarioKey{File: f, Name: ps.name}]; ok {
+				total += d
+				known++
+			}
+		}
+	}
+
+	// Without any history, all scenarios weigh the same.
+	fallback := time.Duration(1)
+	if known > 0 {
+		fallback = total / time.Duration(known)
+	}
+
+	for _, ps := range planned {
+		ps.estimate = 0
+		for f, n := range ps.files {
+			d, ok := durations[ScenarioKey{File: f, Name: ps.name}]
+			if !ok {
+				d = fallback
+			}
+			ps.estimate += d * time.Duration(n)
+		}
+	}
+
+	return known > 0
+}
+
+// balanceScenarios distributes the scenarios across at most n buckets by
+// assigning the longest scenarios first, each to the bucket with the least
+// estimated duration.
+func balanceScenarios(planned []*plannedScenario, n int) [][]*plannedScenario {
+	if n > len(planned) {
+		n = len(planned)
+	}
+	if n < 1 {
+		n = 1
+	}
+
+	sorted := make([]*plannedScenario, len(planned))
+	copy(sorted, planned)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].estimate > sorted[j].estimate
+	})
+
+	buckets := make([][]*plannedScenario, n)
+	totals := make([]time.Duration, n)
+	for _, ps := range sorted {
+		lightest := 0
+		for i := range totals {
+			if totals[i] < totals[lightest] {
+				lightest = i
+			}
+		}
+		buckets[lightest] = append(buckets[lightest], ps)
+		totals[lightest] += ps.estimate
+	}
+
+	return buckets
+}
@@ -0,0 +1,178 @@
+package cucumber
+
+import (
+	"testing"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+func Test_shardSuites_Duration(t *testing.T) {
+	dir := fs.NewDir(t, "cucumber",
+		fs.WithDir("features",
+			fs.WithFile("login.feature", `Feature: Login
+
+  @smoke
+  Scenario: Login
+    When I log in
+
+  Scenario: Logout
+    When I log out
+`),
+			fs.WithFile("cart.feature", `Feature: Cart
+
+  @smoke
+  Scenario: Add item
+    When I add an item
+
+  Scenario: Remove item
+    When I remove an item
+
+  Scenario: Logout
+    When I log out
+`),
+		),
+	)
+	defer dir.Remove()
+
+	suite := Suite{
+		Name:  "suite",
+		Shard: "duration",
+		Options: Options{
+			Paths: []string{"features/cart.feature", "features/login.feature"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		tags      []string
+		filter    string
+		durations map[ScenarioKey]time.Duration
+		want      []Suite
+		wantErr   string
+	}{
+		{
+			name: "balanced by previous durations",
+			durations: map[ScenarioKey]time.Duration{
+				{File: "features/login.feature", Name: "Login"}:      50 * time.Second,
+				{File: "features/login.feature", Name: "Logout"}:     5 * time.Second,
+				{File: "features/cart.feature", Name: "Logout"}:      5 * time.Second,
+				{File: "features/cart.feature", Name: "Add item"}:    30 * time.Second,
+				{File: "features/cart.feature", Name: "Remove item"}: 20 * time.Second,
+			},
+			want: []Suite{
+				{
+					Name: "suite - 1/2",
+					Options: Options{
+						Paths: []string{"features/cart.feature", "features/login.feature"},
+						Name:  "^(Login|Logout)$",
+					},
+					ShardPlan: ShardPlan{Scenarios: []string{"Login", "Logout"}, Estimated: 60 * time.Second},
+				},
+				{
+					Name: "suite - 2/2",
+					Options: Options{
+						Paths: []string{"features/cart.feature"},
+						Name:  "^(Add item|Remove item)$",
+					},
+					ShardPlan: ShardPlan{Scenarios: []string{"Add item", "Remove item"}, Estimated: 50 * time.Second},
+				},
+			},
+		},
+		{
+			name: "unknown scenarios are estimated by average",
+			durations: map[ScenarioKey]time.Duration{
+				{File: "features/login.feature", Name: "Login"}:  60 * time.Second,
+				{File: "features/login.feature", Name: "Logout"}: 10 * time.Second,
+			},
+			want: []Suite{
+				{
+					Name: "suite - 1/2",
+					Options: Options{
+						Paths: []string{"features/cart.feature", "features/login.feature"},
+						Name:  "^(Login|Remove item)$",
+					},
+					ShardPlan: ShardPlan{Scenarios: []string{"Login", "Remove item"}, Estimated: 95 * time.Second},
+				},
+				{
+					Name: "suite - 2/2",
+					Options: Options{
+						Paths: []string{"features/cart.feature", "features/login.feature"},
+						Name:  "^(Add item|Logout)$",
+					},
+					ShardPlan: ShardPlan{Scenarios: []string{"Add item", "Logout"}, Estimated: 80 * time.Second},
+				},
+			},
+		},
+		{
+			name: "balanced by number of scenarios without history",
+			tags: []string{"@smoke"},
+			want: []Suite{
+				{
+					Name: "suite - 1/2",
+					Options: Options{
+						Paths: []string{"features/cart.feature"},
+						Name:  "^(Add item)$",
+						Tags:  []string{"@smoke"},
+					},
+					ShardPlan: ShardPlan{Scenarios: []string{"Add item"}},
+				},
+				{
+					Name: "suite - 2/2",
+					Options: Options{
+						Paths: []string{"features/login.feature"},
+						Name:  "^(Login)$",
+						Tags:  []string{"@smoke"},
+					},
+					ShardPlan: ShardPlan{Scenarios: []string{"Login"}},
+				},
+			},
+		},
+		{
+			name:    "no matching scenarios",
+			filter:  "^Checkout",
+			wantErr: "suite 'suite' has no matching scenarios",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := suite
+			s.Options.Tags = tc.tags
+			s.Options.Name = tc.filter
+
+			got, err := shardSuites(dir.Path(), []Suite{s}, 2, tc.durations)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			for i := range tc.want {
+				tc.want[i].Shard = "duration"
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestScenarioDurations(t *testing.T) {
+	runs := []insights.TestRun{
+		{Name: "Login", PathName: "features/login.feature > Login", Status: insights.StatePassed, Duration: 10, SauceJob: &insights.Job{Name: "suite - 1/2"}},
+		{Name: "Login", PathName: "./features/login.feature > Login", Status: insights.StateFailed, Duration: 20, SauceJob: &insights.Job{Name: "suite"}},
+		{Name: "Login", PathName: "features/login.feature > Login", Status: insights.StateSkipped, Duration: 0, SauceJob: &insights.Job{Name: "suite"}},
+		{Name: "Logout", PathName: "features/cart.feature > Cart", Status: insights.StatePassed, Duration: 5, SauceJob: &insights.Job{Name: "suite - 2/2"}},
+		{Name: "Logout", PathName: "features/login.feature > Login", Status: insights.StatePassed, Duration: 8, SauceJob: &insights.Job{Name: "suite - 2/2"}},
+		{Name: "Login", PathName: "features/login.feature > Login", Status: insights.StatePassed, Duration: 99, SauceJob: &insights.Job{Name: "other suite"}},
+		{Name: "Login", PathName: "Login", Status: insights.StatePassed, Duration: 99, SauceJob: &insights.Job{Name: "suite"}},
+	}
+
+	got := ScenarioDurations(runs, []Suite{{Name: "suite"}})
+
+	want := map[ScenarioKey]time.Duration{
+		{File: "features/login.feature", Name: "Login"}:  15 * time.Second,
+		{File: "features/cart.feature", Name: "Logout"}:  5 * time.Second,
+		{File: "features/login.feature", Name: "Logout"}: 8 * time.Second,
+	}
+	assert.Equal(t, want, got)
+}
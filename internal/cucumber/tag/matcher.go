@@ -39,6 +39,22 @@ func MatchFiles(sys fs.FS, files []string, tagExpression string) (matched []stri
 	return matched, unmatched
 }
 
+// MatchScenarios returns the scenarios with tags that match the given tag expression.
+func MatchScenarios(scenarios []*messages.Pickle, tagExpression string) ([]*messages.Pickle, error) {
+	tagMatcher, err := tagexpressions.Parse(tagExpression)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*messages.Pickle
+	for _, s := range scenarios {
+		if match(s.Tags, tagMatcher) {
+			matched = append(matched, s)
+		}
+	}
+	return matched, nil
+}
+
 func match(tags []*messages.PickleTag, matcher tagexpressions.Evaluatable) bool {
 	tagNames := make([]string, len(tags))
 	for i, t := range tags {
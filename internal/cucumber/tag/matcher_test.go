@@ -5,6 +5,7 @@ import (
 	"testing/fstest"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/saucelabs/saucectl/internal/cucumber/scenario"
 )
 
 func TestMatchFiles(t *testing.T) {
@@ -140,3 +141,39 @@ Feature: Scenario 3
 		})
 	}
 }
+
+func TestMatchScenarios(t *testing.T) {
+	mockFS := fstest.MapFS{
+		"scenario.feature": {
+			Data: []byte(`
+@act1
+Feature: Scenario
+
+        @interior
+        Scenario: Dinner scene
+                When Turkey is served
+
+        @exterior
+        Scenario: Exterior scene
+                When The character exits the house
+`),
+		},
+	}
+	scenarios := scenario.List(mockFS, []string{"scenario.feature"})
+
+	matched, err := MatchScenarios(scenarios, "@act1 and not @exterior")
+	if err != nil {
+		t.Fatalf("MatchScenarios() returned unexpected error: %v", err)
+	}
+	var names []string
+	for _, s := range matched {
+		names = append(names, s.Name)
+	}
+	if diff := cmp.Diff([]string{"Dinner scene"}, names); diff != "" {
+		t.Errorf("MatchScenarios() returned unexpected scenarios (-want +got):\n%s", diff)
+	}
+
+	if _, err := MatchScenarios(scenarios, "(@act1"); err == nil {
+		t.Error("MatchScenarios() expected error for invalid tag expression")
+	}
+}
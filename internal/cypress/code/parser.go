@@ -18,33 +18,6 @@ var (
 	reSingleTagPattern = regexp.MustCompile(`tags\s*:\s*['"](.*?)["']`)
 )
 
-// TestCase describes the metadata for a cypress test case parsed from a cypress spec file.
-type TestCase struct {
-	// Title is the name of the test case, the first argument to `it|test`.
-	Title string
-	// Tags is an optional list of tags. This is simply a space delimited
-	// concatenation of all tags defined for the testcase.
-	Tags string
-}
-
-// Parse takes the contents of a test file and parses testcases
-func Parse(input string) []TestCase {
-	matches := reTestCasePattern.FindAllStringSubmatch(input, -1)
-
-	var testCases []TestCase
-	for _, m := range matches {
-		tc := TestCase{}
-		argSubMatch := m[1]
-
-		tc.Title = parseTitle(argSubMatch)
-		tc.Tags = parseTags(argSubMatch)
-
-		testCases = append(testCases, tc)
-	}
-
-	return testCases
-}
-
 func parseTitle(input string) string {
 	titleMatch := reTitlePattern.FindStringSubmatch(input)
 	if titleMatch != nil {
@@ -72,3 +45,119 @@ func parseTags(input string) string {
 	}
 	return ""
 }
+
+// Test describes a cypress test along with the suites it is nested in.
+type Test struct {
+	// FullTitle is the title of the test, prefixed by the titles of its suites.
+	// This is the title that cypress-grep matches against.
+	FullTitle string
+	// Title is the title of the test itself. It's empty if the title couldn't
+	// be parsed, e.g. because it's not a string literal.
+	Title string
+	// Tags is the list of tags of the test, including the ones inherited from
+	// its suites.
+	Tags []string
+	// Skipped is true if the test or any of its suites is skipped.
+	Skipped bool
+}
+
+// suite represents a describe or context block that is being parsed.
+type suite struct {
+	title   string
+	tags    []string
+	skipped bool
+	end     int
+}
+
+// ParseTests takes the contents of a test file and parses the tests, resolving
+// the titles and tags of the suites (describe and context blocks) they are
+// nested in.
+func ParseTests(input string) []Test {
+	matches := reTestCasePattern.FindAllStringSubmatchIndex(input, -1)
+
+	var tests []Test
+	var stack []suite
+	for _, m := range matches {
+		start := m[2]
+		for len(stack) > 0 && stack[len(stack)-1].end < start {
+			stack = stack[:len(stack)-1]
+		}
+
+		args := input[m[2]:m[3]]
+		fn, modifier, _ := strings.Cut(strings.TrimSpace(input[m[0]:m[2]]), ".")
+		title := parseTitle(args)
+		tags := strings.Fields(parseTags(args))
+		skipped := modifier == "skip"
+
+		var titles []string
+		var inherited []string
+		for _, s := range stack {
+			if s.title != "" {
+				titles = append(titles, s.title)
+			}
+			inherited = append(inherited, s.tags...)
+			skipped = skipped || s.skipped
+		}
+
+		if fn == "describe" || fn == "context" {
+			stack = append(stack, suite{
+				title:   title,
+				tags:    tags,
+				skipped: skipped,
+				end:     closingParen(input, start),
+			})
+			continue
+		}
+
+		if title != "" {
+			titles = append(titles, title)
+		}
+		tests = append(tests, Test{
+			FullTitle: strings.Join(titles, " "),
+			Title:     title,
+			Tags:      append(inherited, tags...),
+			Skipped:   skipped,
+		})
+	}
+
+	return tests
+}
+
+// closingParen returns the position of the parenthesis that closes the one at
+// the start position, skipping over strings and comments. If there is none,
+// the length of the input is returned.
+func closingParen(input string, start int) int {
+	depth := 0
+	for i := start; i < len(input); i++ {
+		switch c := input[i]; c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '"', '\'', '`':
+			for i++; i < len(input) && input[i] != c; i++ {
+				if input[i] == '\\' {
+					i++
+				}
+			}
+		case '/':
+			if strings.HasPrefix(input[i:], "//") {
+				if n := strings.IndexByte(input[i:], '\n'); n >= 0 {
+					i += n
+				} else {
+					return len(input)
+				}
+			} else if strings.HasPrefix(input[i:], "/*") {
+				if n := strings.Index(input[i+2:], "*/"); n >= 0 {
+					i += n + 3
+				} else {
+					return len(input)
+				}
+			}
+		}
+	}
+	return len(input)
+}
@@ -19,7 +19,7 @@ type Project interface {
 	FilterSuites(suiteName string) error
 	CleanPackages()
 	ApplyFlags(selectedSuite string) error
-	AppendTags([]string)
+	SetMetadata(config.Metadata)
 	Validate() error
 	GetShardTypes() []string
 	GetShardOpts() map[string]bool
@@ -72,7 +72,7 @@ func FromFile(cfgPath string) (Project, error) {
 		return nil, err
 	}
 	if version == "v1alpha" {
-		return nil, errors.New("cypress v1alpha is no longer supported")
+		return nil, errors.New("cypress v1alpha is no longer supported, run 'saucectl config migrate' to upgrade your config")
 	}
 	return v1.FromFile(cfgPath)
 }
@@ -6,13 +6,18 @@ package grep
 
 import (
 	"io/fs"
+	"strings"
 
 	"github.com/saucelabs/saucectl/internal/cypress/code"
 )
 
-// MatchFiles finds the files whose contents match the grep expression in the title parameter
-// and the grep tag expression in the tag parameter.
-func MatchFiles(sys fs.FS, files []string, title string, tag string) (matched []string, unmatched []string) {
+// MatchFiles finds the files that contain at least one test that cypress-grep
+// runs for the grep expression in the title parameter, the grep tag expression
+// in the tag parameter and the grepUntagged setting in the untagged parameter.
+func MatchFiles(sys fs.FS, files []string, title string, tag string, untagged bool) (matched []string, unmatched []string) {
+	grepExp := ParseGrepTitleExp(title)
+	grepTagsExp := ParseGrepTagsExp(tag)
+
 	for _, f := range files {
 		b, err := fs.ReadFile(sys, f)
 
@@ -20,20 +25,17 @@ func MatchFiles(sys fs.FS, files []string, title string, tag string) (matched []
 			continue
 		}
 
-		testcases := code.Parse(string(b))
-		grepExp := ParseGrepTitleExp(title)
-		grepTagsExp := ParseGrepTagsExp(tag)
-
 		include := false
-		for _, tc := range testcases {
-			include = include || match(grepExp, grepTagsExp, tc.Title, tc.Tags)
-			if include {
-				// As long as one testcase matched, we know the spec will need to be executed
-				matched = append(matched, f)
+		for _, t := range code.ParseTests(string(b)) {
+			if !t.Skipped && ShouldTestRun(grepExp, grepTagsExp, t, untagged) {
+				// As long as one test matched, we know the spec will need to be executed
+				include = true
 				break
 			}
 		}
-		if !include {
+		if include {
+			matched = append(matched, f)
+		} else {
 			unmatched = append(unmatched, f)
 		}
 	}
@@ -41,10 +43,18 @@ func MatchFiles(sys fs.FS, files []string, title string, tag string) (matched []
 	return matched, unmatched
 }
 
-func match(titleExp Expression, tagsExp Expression, title string, tags string) bool {
+// ShouldTestRun reports whether cypress-grep runs the test. Like in cypress-grep,
+// the title expression is matched against the full title of the test, and the
+// tag expression against its own and inherited tags. If untagged is set, only
+// tests without any tags run.
+func ShouldTestRun(titleExp Expression, tagsExp Expression, test code.Test, untagged bool) bool {
+	if untagged {
+		return len(test.Tags) == 0
+	}
+
 	// Allow empty title to match. This mimics the behaviour of cypress-grep.
-	titleMatch := title == "" || titleExp.Eval(title)
-	tagMatch := tagsExp.Eval(tags)
+	titleMatch := test.Title == "" || titleExp.Eval(test.FullTitle)
+	tagMatch := tagsExp.Eval(strings.Join(test.Tags, " "))
 
 	return titleMatch && tagMatch
 }
@@ -1,9 +1,13 @@
 package grep
 
 import (
+	"encoding/json"
+	"os"
 	"reflect"
 	"testing"
 	"testing/fstest"
+
+	"github.com/saucelabs/saucectl/internal/cypress/code"
 )
 
 func TestMatchFiles(t *testing.T) {
@@ -47,7 +51,7 @@ context('Assertions', () => {
 		},
 	}
 
-	matched, unmatched := MatchFiles(mockFS, []string{"spec1.js", "spec2.js"}, "", "@flakey")
+	matched, unmatched := MatchFiles(mockFS, []string{"spec1.js", "spec2.js"}, "", "@flakey", false)
 
 	got := len(matched) + len(unmatched)
 	want := len(mockFS)
@@ -65,3 +69,112 @@ context('Assertions', () => {
 		t.Errorf("MatchFiles() unmatched got = (%s) want = (%s)", unmatched, wantUnmatched)
 	}
 }
+
+// corpusCase describes a test and whether cypress-grep runs it. The corpus
+// follows the unit tests of cypress-grep.
+type corpusCase struct {
+	Name         string   `json:"name"`
+	Grep         string   `json:"grep"`
+	GrepTags     string   `json:"grepTags"`
+	GrepUntagged bool     `json:"grepUntagged"`
+	Title        string   `json:"title"`
+	Tags         []string `json:"tags"`
+	Want         bool     `json:"want"`
+}
+
+func TestShouldTestRun(t *testing.T) {
+	b, err := os.ReadFile("testdata/corpus.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var corpus []corpusCase
+	if err := json.Unmarshal(b, &corpus); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range corpus {
+		t.Run(tc.Name, func(t *testing.T) {
+			test := code.Test{FullTitle: tc.Title, Title: tc.Title, Tags: tc.Tags}
+			got := ShouldTestRun(ParseGrepTitleExp(tc.Grep), ParseGrepTagsExp(tc.GrepTags), test, tc.GrepUntagged)
+			if got != tc.Want {
+				t.Errorf("ShouldTestRun() got = %t, want = %t", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestMatchFiles_Nested(t *testing.T) {
+	mockFS := fstest.MapFS{
+		"login.cy.js": {
+			Data: []byte(`
+describe('Login', { tags: '@auth' }, () => {
+  it('works', () => {})
+
+  context('with SSO', () => {
+    it('redirects', { tags: ['@slow'] }, () => {})
+  })
+})
+`),
+		},
+		"cart.cy.js": {
+			Data: []byte(`
+describe('Cart', () => {
+  it('adds items', () => {})
+  it.skip('removes items', { tags: '@auth' }, () => {})
+})
+`),
+		},
+	}
+	files := []string{"cart.cy.js", "login.cy.js"}
+
+	testCases := []struct {
+		name          string
+		grep          string
+		grepTags      string
+		grepUntagged  bool
+		wantMatched   []string
+		wantUnmatched []string
+	}{
+		{
+			name:          "full title",
+			grep:          "Login with SSO redirects",
+			wantMatched:   []string{"login.cy.js"},
+			wantUnmatched: []string{"cart.cy.js"},
+		},
+		{
+			name:          "inherited tags",
+			grepTags:      "@auth+@slow",
+			wantMatched:   []string{"login.cy.js"},
+			wantUnmatched: []string{"cart.cy.js"},
+		},
+		{
+			name:          "inverted inherited tags",
+			grepTags:      "--@auth",
+			wantMatched:   []string{"cart.cy.js"},
+			wantUnmatched: []string{"login.cy.js"},
+		},
+		{
+			name:          "untagged",
+			grepUntagged:  true,
+			wantMatched:   []string{"cart.cy.js"},
+			wantUnmatched: []string{"login.cy.js"},
+		},
+		{
+			name:          "title inversion of suite",
+			grep:          "-Login",
+			wantMatched:   []string{"cart.cy.js"},
+			wantUnmatched: []string{"login.cy.js"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, unmatched := MatchFiles(mockFS, files, tc.grep, tc.grepTags, tc.grepUntagged)
+			if !reflect.DeepEqual(matched, tc.wantMatched) {
+				t.Errorf("MatchFiles() matched got = (%s) want = (%s)", matched, tc.wantMatched)
+			}
+			if !reflect.DeepEqual(unmatched, tc.wantUnmatched) {
+				t.Errorf("MatchFiles() unmatched got = (%s) want = (%s)", unmatched, tc.wantUnmatched)
+			}
+		})
+	}
+}
@@ -198,6 +198,12 @@ func ParseGrepTagsExp(expr string) Expression {
 		parsed.add(&matcher)
 	}
 
+	// An expression of only globally inverted tags matches all tests that
+	// have none of them.
+	if len(parsed.Expressions) == 0 && len(not) > 0 {
+		parsed.add(&All{Expressions: not})
+	}
+
 	return &parsed
 }
 
@@ -0,0 +1,296 @@
+// Package spec implements functions to resolve the spec files that Cypress runs.
+//
+// The resolution mirrors the behavior of Cypress itself, so that specs that are
+// sharded locally match the ones that are executed remotely.
+// See https://docs.cypress.io/guides/references/configuration#Testing-Type-Specific-Options
+package spec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// Testing types supported by Cypress.
+const (
+	E2E       = "e2e"
+	Component = "component"
+)
+
+// Default patterns as defined by Cypress.
+var (
+	DefaultE2ESpecPattern              = []string{"cypress/e2e/**/*.cy.{js,jsx,ts,tsx}"}
+	DefaultE2EExcludeSpecPattern       = []string{"*.hot-update.js"}
+	DefaultComponentSpecPattern        = []string{"**/*.cy.{js,jsx,ts,tsx}"}
+	DefaultComponentExcludeSpecPattern = []string{"**/__snapshots__/*", "**/__image_snapshots__/*"}
+)
+
+// evaluateTimeout is the maximum time to wait for node to evaluate a config file.
+var evaluateTimeout = 30 * time.Second
+
+// Patterns represents a list of glob patterns. Cypress accepts either a single
+// pattern or a list of patterns.
+type Patterns []string
+
+// UnmarshalJSON accepts both a single pattern and a list of patterns.
+func (p *Patterns) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*p = Patterns{s}
+		return nil
+	}
+
+	var l []string
+	if err := json.Unmarshal(b, &l); err != nil {
+		return fmt.Errorf("pattern must be a string or a list of strings: %w", err)
+	}
+	*p = l
+	return nil
+}
+
+// TestingTypeConfig represents the spec related options of a testing type.
+type TestingTypeConfig struct {
+	SpecPattern        Patterns `json:"specPattern"`
+	ExcludeSpecPattern Patterns `json:"excludeSpecPattern"`
+}
+
+// Config represents the spec related options of a cypress config file.
+type Config struct {
+	E2E       TestingTypeConfig `json:"e2e"`
+	Component TestingTypeConfig `json:"component"`
+}
+
+// SpecPattern returns the spec pattern of the testing type, or the Cypress
+// default if none is configured.
+func (c Config) SpecPattern(testingType string) []string {
+	if testingType == Component {
+		if len(c.Component.SpecPattern) > 0 {
+			return c.Component.SpecPattern
+		}
+		return DefaultComponentSpecPattern
+	}
+	if len(c.E2E.SpecPattern) > 0 {
+		return c.E2E.SpecPattern
+	}
+	return DefaultE2ESpecPattern
+}
+
+// ExcludeSpecPattern returns the exclude spec pattern of the testing type, or
+// the Cypress default if none is configured.
+func (c Config) ExcludeSpecPattern(testingType string) []string {
+	if testingType == Component {
+		if c.Component.ExcludeSpecPattern != nil {
+			return c.Component.ExcludeSpecPattern
+		}
+		return DefaultComponentExcludeSpecPattern
+	}
+	if c.E2E.ExcludeSpecPattern != nil {
+		return c.E2E.ExcludeSpecPattern
+	}
+	return DefaultE2EExcludeSpecPattern
+}
+
+// evaluateScript loads the cypress config file passed as first argument and
+// prints the spec related options as JSON.
+const evaluateScript = `
+const path = require('path');
+const url = require('url');
+
+(async () => {
+  const file = path.resolve(process.argv[1]);
+  let cfg;
+  try {
+    cfg = require(file);
+  } catch (e) {
+    if (e.code !== 'ERR_REQUIRE_ESM' && e.code !== 'ERR_REQUIRE_ASYNC_MODULE') {
+      throw e;
+    }
+    cfg = await import(url.pathToFileURL(file).href);
+  }
+  if (cfg && cfg.default) {
+    cfg = cfg.default;
+  }
+  if (typeof cfg === 'function') {
+    cfg = await cfg();
+  }
+  cfg = cfg || {};
+
+  const pick = (t) => t ? { specPattern: t.specPattern, excludeSpecPattern: t.excludeSpecPattern } : {};
+  process.stdout.write(JSON.stringify({ e2e: pick(cfg.e2e), component: pick(cfg.component) }));
+})().catch((e) => {
+  process.stderr.write(e.message);
+  process.exit(1);
+});
+`
+
+// ReadConfig reads the spec related options from the cypress config file.
+// JSON files, e.g. exported by the user, are read as is. JavaScript and
+// TypeScript files are evaluated by the local node runtime.
+func ReadConfig(name string) (Config, error) {
+	var b []byte
+	var err error
+
+	ext := filepath.Ext(name)
+	switch ext {
+	case ".json":
+		b, err = os.ReadFile(name)
+	case ".js", ".cjs", ".mjs", ".ts", ".cts", ".mts":
+		b, err = evaluate(name, ext)
+	default:
+		return Config{}, fmt.Errorf("unsupported cypress config file %q", name)
+	}
+	if err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Config{}, fmt.Errorf("invalid cypress config file %q: %w", name, err)
+	}
+	return c, nil
+}
+
+func evaluate(name string, ext string) ([]byte, error) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		return nil, fmt.Errorf("node is required to evaluate %q: %w", name, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), evaluateTimeout)
+	defer cancel()
+
+	args := []string{"--no-warnings"}
+	if strings.HasSuffix(ext, "ts") {
+		args = append(args, "--experimental-strip-types")
+	}
+	args = append(args, "-e", evaluateScript, name)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, node, args...)
+	cmd.Dir = filepath.Dir(name)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("failed to evaluate %q: %s", name, strings.TrimSpace(stderr.String()))
+		}
+		return nil, fmt.Errorf("failed to evaluate %q: %w", name, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// Resolve returns the spec files that Cypress runs for the given testing type,
+// relative to the root of sys.
+// Like in Cypress, the node_modules folder is always ignored, exclude patterns
+// without a slash match the file name in any folder, hidden files and folders
+// are only matched explicitly, and component testing ignores the e2e specs.
+func Resolve(sys fs.FS, testingType string, specPattern, excludeSpecPattern, e2eSpecPattern []string) ([]string, error) {
+	for _, p := range specPattern {
+		if !doublestar.ValidatePattern(normalize(p)) {
+			return nil, fmt.Errorf("invalid spec pattern %q", p)
+		}
+	}
+
+	exclude := append([]string{}, excludeSpecPattern...)
+	if testingType == Component {
+		exclude = append(exclude, e2eSpecPattern...)
+	}
+
+	var files []string
+	err := fs.WalkDir(sys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "node_modules" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if matchAny(specPattern, p, false) && !matchAny(exclude, p, true) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// UnsupportedPatterns returns the patterns whose semantics can't be mirrored
+// locally, which means the local spec list may diverge from the one Cypress
+// runs.
+func UnsupportedPatterns(patterns []string) []string {
+	var unsupported []string
+	for _, p := range patterns {
+		n := normalize(p)
+		if strings.HasPrefix(n, "!") || strings.HasPrefix(n, "/") || strings.HasPrefix(n, "../") ||
+			strings.ContainsAny(n, "\\") || containsExtglob(n) || !doublestar.ValidatePattern(n) {
+			unsupported = append(unsupported, p)
+		}
+	}
+	return unsupported
+}
+
+func containsExtglob(p string) bool {
+	for _, prefix := range []string{"!(", "?(", "+(", "*(", "@("} {
+		if strings.Contains(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalize(pattern string) string {
+	return strings.TrimPrefix(pattern, "./")
+}
+
+func matchAny(patterns []string, name string, exclude bool) bool {
+	for _, p := range patterns {
+		if match(normalize(p), name, exclude) {
+			return true
+		}
+	}
+	return false
+}
+
+// match matches the name against the pattern. Exclude patterns also match
+// hidden files and, if the pattern has no slash, the base name of the file.
+func match(pattern string, name string, exclude bool) bool {
+	if !exclude && !matchesHidden(pattern, name) {
+		return false
+	}
+	if ok, _ := doublestar.Match(pattern, name); ok {
+		return true
+	}
+	if exclude && !strings.Contains(pattern, "/") {
+		ok, _ := doublestar.Match(pattern, path.Base(name))
+		return ok
+	}
+	return false
+}
+
+// matchesHidden reports whether the hidden files and folders of name are
+// explicitly matched by the pattern, since wildcards don't match them.
+func matchesHidden(pattern string, name string) bool {
+	for _, seg := range strings.Split(name, "/") {
+		if strings.HasPrefix(seg, ".") && !strings.HasPrefix(pattern, ".") && !strings.Contains(pattern, "/.") {
+			return false
+		}
+	}
+	return true
+}
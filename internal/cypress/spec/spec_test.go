@@ -0,0 +1,117 @@
+package spec
+
+import (
+	"os/exec"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestResolve(t *testing.T) {
+	sys := fstest.MapFS{
+		"cypress/e2e/login.cy.js":               {},
+		"cypress/e2e/cart.cy.ts":                {},
+		"cypress/e2e/nested/checkout.cy.js":     {},
+		"cypress/e2e/nested/main.hot-update.js": {},
+		"cypress/e2e/.hidden/secret.cy.js":      {},
+		"cypress/support/e2e.js":                {},
+		"src/Button.cy.tsx":                     {},
+		"src/__snapshots__/Button.cy.tsx":       {},
+		"node_modules/pkg/index.cy.js":          {},
+	}
+
+	testCases := []struct {
+		name               string
+		testingType        string
+		specPattern        []string
+		excludeSpecPattern []string
+		want               []string
+	}{
+		{
+			name:               "e2e defaults",
+			testingType:        E2E,
+			specPattern:        DefaultE2ESpecPattern,
+			excludeSpecPattern: DefaultE2EExcludeSpecPattern,
+			want:               []string{"cypress/e2e/cart.cy.ts", "cypress/e2e/login.cy.js", "cypress/e2e/nested/checkout.cy.js"},
+		},
+		{
+			name:               "exclude pattern without slash matches file names",
+			testingType:        E2E,
+			specPattern:        []string{"./cypress/e2e/**/*.js"},
+			excludeSpecPattern: []string{"*.hot-update.js", "login.cy.js"},
+			want:               []string{"cypress/e2e/nested/checkout.cy.js"},
+		},
+		{
+			name:        "hidden folders are matched explicitly",
+			testingType: E2E,
+			specPattern: []string{"cypress/e2e/.hidden/*.cy.js"},
+			want:        []string{"cypress/e2e/.hidden/secret.cy.js"},
+		},
+		{
+			name:               "component testing ignores e2e specs",
+			testingType:        Component,
+			specPattern:        DefaultComponentSpecPattern,
+			excludeSpecPattern: DefaultComponentExcludeSpecPattern,
+			want:               []string{"src/Button.cy.tsx"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Resolve(sys, tc.testingType, tc.specPattern, tc.excludeSpecPattern, DefaultE2ESpecPattern)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestUnsupportedPatterns(t *testing.T) {
+	got := UnsupportedPatterns([]string{"cypress/e2e/**/*.cy.js", "!cypress/e2e/skip.cy.js", "../shared/*.cy.js", "cypress/e2e/@(a|b).cy.js", "*.hot-update.js"})
+	assert.Equal(t, []string{"!cypress/e2e/skip.cy.js", "../shared/*.cy.js", "cypress/e2e/@(a|b).cy.js"}, got)
+}
+
+func TestReadConfig(t *testing.T) {
+	dir := fs.NewDir(t, "cypress",
+		fs.WithFile("cypress.config.json", `{"e2e": {"specPattern": "tests/**/*.cy.js"}, "component": {"specPattern": ["src/**/*.cy.tsx"], "excludeSpecPattern": []}}`),
+		fs.WithFile("cypress.config.js", `module.exports = {
+  e2e: {
+    specPattern: 'tests/**/*.cy.js',
+    setupNodeEvents(on, config) {},
+  },
+  component: {
+    specPattern: ['src/**/*.cy.tsx'],
+    excludeSpecPattern: [],
+  },
+};`),
+		fs.WithFile("cypress.config.mjs", `export default async () => ({
+  e2e: { specPattern: 'tests/**/*.cy.js' },
+  component: { specPattern: ['src/**/*.cy.tsx'], excludeSpecPattern: [] },
+});`),
+		fs.WithFile("cypress.config.yml", ""),
+	)
+	defer dir.Remove()
+
+	want := Config{
+		E2E:       TestingTypeConfig{SpecPattern: Patterns{"tests/**/*.cy.js"}},
+		Component: TestingTypeConfig{SpecPattern: Patterns{"src/**/*.cy.tsx"}, ExcludeSpecPattern: Patterns{}},
+	}
+
+	got, err := ReadConfig(dir.Join("cypress.config.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, DefaultE2EExcludeSpecPattern, got.ExcludeSpecPattern(E2E))
+	assert.Equal(t, []string{}, got.ExcludeSpecPattern(Component))
+
+	_, err = ReadConfig(dir.Join("cypress.config.yml"))
+	assert.EqualError(t, err, `unsupported cypress config file "`+dir.Join("cypress.config.yml")+`"`)
+
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node is not installed")
+	}
+	for _, name := range []string{"cypress.config.js", "cypress.config.mjs"} {
+		got, err := ReadConfig(dir.Join(name))
+		assert.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+}
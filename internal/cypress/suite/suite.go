@@ -20,6 +20,8 @@ type Suite struct {
 	TimeZone         string            `yaml:"timeZone,omitempty" json:"timeZone"`
 	Env              map[string]string `yaml:"env,omitempty" json:"env"`
 	PassThreshold    int               `yaml:"passThreshold,omitempty" json:"-"`
+	Priority         int               `yaml:"priority,omitempty" json:"-"`
+	DependsOn        []string          `yaml:"dependsOn,omitempty" json:"-"`
 }
 
 // SortByHistory sorts the suites in the order of job history
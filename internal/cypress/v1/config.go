@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -12,8 +14,8 @@ import (
 	"github.com/saucelabs/saucectl/internal/concurrency"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/cypress/grep"
+	"github.com/saucelabs/saucectl/internal/cypress/spec"
 	"github.com/saucelabs/saucectl/internal/cypress/suite"
-	"github.com/saucelabs/saucectl/internal/fpath"
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/node"
 	"github.com/saucelabs/saucectl/internal/region"
@@ -70,6 +72,13 @@ type Suite struct {
 	TimeZone         string            `yaml:"timeZone,omitempty" json:"timeZone"`
 	PassThreshold    int               `yaml:"passThreshold,omitempty" json:"-"`
 	SmartRetry       config.SmartRetry `yaml:"smartRetry,omitempty" json:"-"`
+	Priority         int               `yaml:"priority,omitempty" json:"-"`
+	DependsOn        []string          `yaml:"dependsOn,omitempty" json:"-"`
+}
+
+// Dependencies returns the suites that the suite depends on.
+func (s Suite) Dependencies() config.SuiteDependencies {
+	return config.SuiteDependencies{Name: s.Name, DependsOn: s.DependsOn}
 }
 
 // SuiteConfig represents the cypress config overrides.
@@ -203,6 +212,18 @@ func (p *Project) Validate() error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := config.ValidateRetryPolicy(p.Sauce.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := config.ValidateFailFast(p.Sauce.FailFast); err != nil {
+		return err
+	}
+
+	if err := config.ValidateSuiteDependencies(p.Suites); err != nil {
+		return err
+	}
+
 	err := config.ValidateRegistries(p.Npm.Registries)
 	if err != nil {
 		return err
@@ -271,7 +292,7 @@ func (p *Project) Validate() error {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
 	}
 
-	if p.Suites, err = shardSuites(p.RootDir, p.Suites, p.Sauce.Concurrency, p.Sauce.Sauceignore); err != nil {
+	if p.Suites, err = shardSuites(p.RootDir, p.Suites, p.Sauce.Concurrency, p.Sauce.Sauceignore, p.specConfig()); err != nil {
 		return err
 	}
 	if len(p.Suites) == 0 {
@@ -280,7 +301,30 @@ func (p *Project) Validate() error {
 	return nil
 }
 
-func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string) ([]Suite, error) {
+// specConfig reads the spec related options of the cypress config file, which
+// are only needed if any of the suites is sharded.
+func (p *Project) specConfig() spec.Config {
+	if p.Cypress.ConfigFile == "" || !p.isSharded() {
+		return spec.Config{}
+	}
+
+	cfg, err := spec.ReadConfig(filepath.Join(p.RootDir, p.Cypress.ConfigFile))
+	if err != nil {
+		log.Warn().Err(err).Msg("Unable to read the spec patterns from the cypress config file. The specs resolved for sharding may differ from the ones Cypress runs.")
+	}
+	return cfg
+}
+
+func (p *Project) isSharded() bool {
+	for _, s := range p.Suites {
+		if s.Shard == "spec" || s.Shard == "concurrency" {
+			return true
+		}
+	}
+	return false
+}
+
+func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string, specCfg spec.Config) ([]Suite, error) {
 	var shardedSuites []Suite
 	for _, s := range suites {
 		// Use the original suite if there is nothing to shard.
@@ -288,7 +332,20 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 			shardedSuites = append(shardedSuites, s)
 			continue
 		}
-		files, err := fpath.FindFiles(rootDir, s.Config.SpecPattern, fpath.FindByShellPattern)
+
+		// Cypress falls back to the patterns of its config file if the suite
+		// doesn't override them.
+		excludeSpecPattern := s.Config.ExcludeSpecPattern
+		if len(excludeSpecPattern) == 0 {
+			excludeSpecPattern = specCfg.ExcludeSpecPattern(s.Config.TestingType)
+		}
+		e2eSpecPattern := specCfg.SpecPattern(spec.E2E)
+		if unsupported := spec.UnsupportedPatterns(append(append([]string{}, s.Config.SpecPattern...), excludeSpecPattern...)); len(unsupported) > 0 {
+			log.Warn().Str("suite", s.Name).Strs("patterns", unsupported).
+				Msg("Patterns can't be resolved the same way as Cypress does. The specs of the shards may differ from the ones Cypress runs.")
+		}
+
+		files, err := spec.Resolve(os.DirFS(rootDir), s.Config.TestingType, s.Config.SpecPattern, excludeSpecPattern, e2eSpecPattern)
 		if err != nil {
 			return shardedSuites, err
 		}
@@ -303,10 +360,11 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 		if s.ShardGrepEnabled {
 			grepExp, grepExists := s.Config.Env["grep"]
 			grepTagsExp, grepTagsExists := s.Config.Env["grepTags"]
+			grepUntagged, _ := strconv.ParseBool(s.Config.Env["grepUntagged"])
 
-			if grepExists || grepTagsExists {
+			if grepExists || grepTagsExists || grepUntagged {
 				var unmatched []string
-				files, unmatched = grep.MatchFiles(os.DirFS(rootDir), files, grepExp, grepTagsExp)
+				files, unmatched = grep.MatchFiles(os.DirFS(rootDir), files, grepExp, grepTagsExp, grepUntagged)
 
 				if len(files) == 0 {
 					log.Error().Str("suiteName", s.Name).Str("grep", grepExp).Str("grepTags", grepTagsExp).Msg("No files match the configured grep and grepTags expressions")
@@ -317,15 +375,8 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 			}
 		}
 
-		excludedFiles, err := fpath.FindFiles(rootDir, s.Config.ExcludeSpecPattern, fpath.FindByShellPattern)
-		if err != nil {
-			return shardedSuites, err
-		}
-
-		testFiles := fpath.ExcludeFiles(files, excludedFiles)
-
 		if s.Shard == "spec" {
-			for _, f := range testFiles {
+			for _, f := range files {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %s", s.Name, f)
 				replica.Config.SpecPattern = []string{f}
@@ -333,7 +384,7 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 			}
 		}
 		if s.Shard == "concurrency" {
-			fileGroups := concurrency.BinPack(testFiles, ccy)
+			fileGroups := concurrency.BinPack(files, ccy)
 			for i, group := range fileGroups {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %d/%d", s.Name, i+1, len(fileGroups))
@@ -468,6 +519,8 @@ func (p *Project) GetSuites() []suite.Suite {
 			TimeZone:         s.TimeZone,
 			Env:              s.Config.Env,
 			PassThreshold:    s.PassThreshold,
+			Priority:         s.Priority,
+			DependsOn:        s.DependsOn,
 		})
 	}
 	return suites
@@ -482,6 +535,8 @@ func (p *Project) GetKind() string {
 func (p *Project) FilterSuites(suiteName string) error {
 	for _, s := range p.Suites {
 		if s.Name == suiteName {
+			// The suites it depends on aren't part of the run anymore.
+			s.DependsOn = nil
 			p.Suites = []Suite{s}
 			return nil
 		}
@@ -505,9 +560,9 @@ func (p *Project) ApplyFlags(selectedSuite string) error {
 	return nil
 }
 
-// AppendTags adds tags
-func (p *Project) AppendTags(tags []string) {
-	p.Sauce.Metadata.Tags = append(p.Sauce.Metadata.Tags, tags...)
+// SetMetadata sets the job metadata.
+func (p *Project) SetMetadata(m config.Metadata) {
+	p.Sauce.Metadata = m
 }
 
 // GetSuite returns suite
@@ -526,6 +581,8 @@ func (p *Project) GetSuite() suite.Suite {
 		TimeZone:         s.TimeZone,
 		Env:              s.Config.Env,
 		PassThreshold:    s.PassThreshold,
+		Priority:         s.Priority,
+		DependsOn:        s.DependsOn,
 	}
 }
 
@@ -0,0 +1,394 @@
+// Package dex provides a minimal reader for Dalvik Executable (DEX) files.
+// It only decodes what's needed to enumerate classes, their methods and the
+// annotations applied to either.
+package dex
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+)
+
+const noIndex = 0xffffffff
+
+// Access flags of interest.
+const (
+	AccAbstract  = 0x400
+	AccInterface = 0x200
+	AccStatic    = 0x8
+	AccPublic    = 0x1
+)
+
+// Class represents a class definition found in a DEX file.
+type Class struct {
+	// Name is the fully qualified class name, e.g. "com.example.LoginTest".
+	Name string
+	// Super is the fully qualified name of the super class.
+	Super       string
+	AccessFlags uint32
+	Annotations []string
+	Methods     []Method
+}
+
+// Method represents a method of a class.
+type Method struct {
+	Name        string
+	AccessFlags uint32
+	Annotations []string
+}
+
+// IsAbstract returns true if the class is either abstract or an interface.
+func (c Class) IsAbstract() bool {
+	return c.AccessFlags&(AccAbstract|AccInterface) != 0
+}
+
+// HasAnnotation returns true if the class is annotated with the given annotation.
+func (c Class) HasAnnotation(name string) bool {
+	return slices.Contains(c.Annotations, name)
+}
+
+// HasAnnotation returns true if the method is annotated with the given annotation.
+func (m Method) HasAnnotation(name string) bool {
+	return slices.Contains(m.Annotations, name)
+}
+
+// ReadAPK reads the classes of all DEX files (classes.dex, classes2.dex, ...)
+// contained in the APK at the given path.
+func ReadAPK(apkPath string) ([]Class, error) {
+	r, err := zip.OpenReader(apkPath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var classes []Class
+	for _, f := range r.File {
+		name := path.Base(f.Name)
+		if f.Name != name || !strings.HasPrefix(name, "classes") || !strings.HasSuffix(name, ".dex") {
+			continue
+		}
+
+		data, err := readZipFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+		}
+		cc, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", f.Name, err)
+		}
+		classes = append(classes, cc...)
+	}
+	if classes == nil {
+		return nil, errors.New("no dex files found")
+	}
+
+	return classes, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+// reader decodes the sections of a single DEX file.
+type reader struct {
+	data    []byte
+	strings []string
+	types   []string
+	methods []methodID
+}
+
+type methodID struct {
+	class uint16
+	name  uint32
+}
+
+// Parse parses the given DEX file content and returns its class definitions.
+func Parse(data []byte) ([]Class, error) {
+	if len(data) < 0x70 || !bytes.HasPrefix(data, []byte("dex\n")) {
+		return nil, errors.New("not a dex file")
+	}
+
+	r := &reader{data: data}
+	if err := r.readIDs(); err != nil {
+		return nil, err
+	}
+
+	size, off := r.u32(0x60), r.u32(0x64)
+	if !r.tableInBounds(off, size, 32) {
+		return nil, errors.New("class defs out of bounds")
+	}
+	var classes []Class
+	for i := uint32(0); i < size; i++ {
+		c, err := r.readClass(off + i*32)
+		if err != nil {
+			return nil, err
+		}
+		classes = append(classes, c)
+	}
+
+	return classes, nil
+}
+
+func (r *reader) readIDs() error {
+	size, off := r.u32(0x38), r.u32(0x3C)
+	if !r.tableInBounds(off, size, 4) {
+		return errors.New("string ids out of bounds")
+	}
+	r.strings = make([]string, size)
+	for i := uint32(0); i < size; i++ {
+		s, err := r.str(r.u32(off + i*4))
+		if err != nil {
+			return err
+		}
+		r.strings[i] = s
+	}
+
+	size, off = r.u32(0x40), r.u32(0x44)
+	if !r.tableInBounds(off, size, 4) {
+		return errors.New("type ids out of bounds")
+	}
+	r.types = make([]string, size)
+	for i := uint32(0); i < size; i++ {
+		idx := r.u32(off + i*4)
+		if idx >= uint32(len(r.strings)) {
+			return errors.New("invalid type descriptor index")
+		}
+		r.types[i] = r.strings[idx]
+	}
+
+	size, off = r.u32(0x58), r.u32(0x5C)
+	if !r.tableInBounds(off, size, 8) {
+		return errors.New("method ids out of bounds")
+	}
+	r.methods = make([]methodID, size)
+	for i := uint32(0); i < size; i++ {
+		r.methods[i] = methodID{
+			class: binary.LittleEndian.Uint16(r.data[off+i*8:]),
+			name:  r.u32(off + i*8 + 4),
+		}
+	}
+
+	return nil
+}
+
+func (r *reader) readClass(off uint32) (Class, error) {
+	if !r.inBounds(off, 32) {
+		return Class{}, errors.New("class def out of bounds")
+	}
+
+	c := Class{
+		Name:        r.typeName(r.u32(off)),
+		AccessFlags: r.u32(off + 4),
+	}
+	if super := r.u32(off + 8); super != noIndex {
+		c.Super = r.typeName(super)
+	}
+
+	methodAnnotations := map[uint32][]string{}
+	if annotationsOff := r.u32(off + 20); annotationsOff != 0 {
+		var err error
+		c.Annotations, methodAnnotations, err = r.readAnnotationsDirectory(annotationsOff)
+		if err != nil {
+			return Class{}, err
+		}
+	}
+
+	if dataOff := r.u32(off + 24); dataOff != 0 {
+		methods, err := r.readClassData(dataOff)
+		if err != nil {
+			return Class{}, err
+		}
+		for _, m := range methods {
+			c.Methods = append(c.Methods, Method{
+				Name:        r.methodName(m.idx),
+				AccessFlags: m.flags,
+				Annotations: methodAnnotations[m.idx],
+			})
+		}
+	}
+
+	return c, nil
+}
+
+type encodedMethod struct {
+	idx   uint32
+	flags uint32
+}
+
+func (r *reader) readClassData(off uint32) ([]encodedMethod, error) {
+	pos := off
+	var sizes [4]uint32
+	for i := range sizes {
+		v, err := r.uleb128(&pos)
+		if err != nil {
+			return nil, err
+		}
+		sizes[i] = v
+	}
+
+	// Skip static and instance fields.
+	for i := uint64(0); i < (uint64(sizes[0])+uint64(sizes[1]))*2; i++ {
+		if _, err := r.uleb128(&pos); err != nil {
+			return nil, err
+		}
+	}
+
+	var methods []encodedMethod
+	for _, n := range sizes[2:] {
+		// Method indices are delta encoded and reset for each list.
+		idx := uint32(0)
+		for i := uint32(0); i < n; i++ {
+			var vals [3]uint32
+			for j := range vals {
+				v, err := r.uleb128(&pos)
+				if err != nil {
+					return nil, err
+				}
+				vals[j] = v
+			}
+			idx += vals[0]
+			methods = append(methods, encodedMethod{idx: idx, flags: vals[1]})
+		}
+	}
+
+	return methods, nil
+}
+
+func (r *reader) readAnnotationsDirectory(off uint32) ([]string, map[uint32][]string, error) {
+	if !r.inBounds(off, 16) {
+		return nil, nil, errors.New("annotations directory out of bounds")
+	}
+
+	classAnnotations, err := r.readAnnotationSet(r.u32(off))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields, methods := r.u32(off+4), r.u32(off+8)
+	if !r.tableInBounds(off+16, fields, 8) {
+		return nil, nil, errors.New("field annotations out of bounds")
+	}
+	pos := off + 16 + fields*8
+	if !r.tableInBounds(pos, methods, 8) {
+		return nil, nil, errors.New("method annotations out of bounds")
+	}
+	methodAnnotations := map[uint32][]string{}
+	for i := uint32(0); i < methods; i++ {
+		set, err := r.readAnnotationSet(r.u32(pos + i*8 + 4))
+		if err != nil {
+			return nil, nil, err
+		}
+		methodAnnotations[r.u32(pos+i*8)] = set
+	}
+
+	return classAnnotations, methodAnnotations, nil
+}
+
+func (r *reader) readAnnotationSet(off uint32) ([]string, error) {
+	if off == 0 {
+		return nil, nil
+	}
+	if !r.inBounds(off, 4) {
+		return nil, errors.New("annotation set out of bounds")
+	}
+
+	size := r.u32(off)
+	if !r.tableInBounds(off+4, size, 4) {
+		return nil, errors.New("annotation set out of bounds")
+	}
+	var annotations []string
+	for i := uint32(0); i < size; i++ {
+		// annotation_item: ubyte visibility, followed by encoded_annotation
+		// whose first value is the type index.
+		pos := r.u32(off+4+i*4) + 1
+		idx, err := r.uleb128(&pos)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, r.typeName(idx))
+	}
+
+	return annotations, nil
+}
+
+func (r *reader) typeName(idx uint32) string {
+	if idx >= uint32(len(r.types)) {
+		return ""
+	}
+	return descriptorToName(r.types[idx])
+}
+
+func (r *reader) methodName(idx uint32) string {
+	if idx >= uint32(len(r.methods)) || r.methods[idx].name >= uint32(len(r.strings)) {
+		return ""
+	}
+	return r.strings[r.methods[idx].name]
+}
+
+// str reads the MUTF-8 encoded string_data_item at the given offset.
+func (r *reader) str(off uint32) (string, error) {
+	pos := off
+	if _, err := r.uleb128(&pos); err != nil {
+		return "", err
+	}
+	end := bytes.IndexByte(r.data[pos:], 0)
+	if end < 0 {
+		return "", errors.New("unterminated string")
+	}
+	return string(r.data[pos : pos+uint32(end)]), nil
+}
+
+func (r *reader) u32(off uint32) uint32 {
+	if !r.inBounds(off, 4) {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(r.data[off:])
+}
+
+func (r *reader) inBounds(off, size uint32) bool {
+	return uint64(off)+uint64(size) <= uint64(len(r.data))
+}
+
+// tableInBounds returns true if a table of count entries of the given width
+// at off fits into the data. Sizes are computed in 64 bits, so that they can't
+// wrap around.
+func (r *reader) tableInBounds(off, count, width uint32) bool {
+	return uint64(off)+uint64(count)*uint64(width) <= uint64(len(r.data))
+}
+
+func (r *reader) uleb128(pos *uint32) (uint32, error) {
+	var v uint32
+	for i := 0; i < 5; i++ {
+		if *pos >= uint32(len(r.data)) {
+			return 0, errors.New("unexpected end of data")
+		}
+		b := r.data[*pos]
+		*pos++
+		v |= uint32(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			return v, nil
+		}
+	}
+	return 0, errors.New("invalid uleb128 value")
+}
+
+// descriptorToName converts a type descriptor like "Lcom/example/Foo;" into
+// its fully qualified name "com.example.Foo".
+func descriptorToName(desc string) string {
+	if strings.HasPrefix(desc, "L") && strings.HasSuffix(desc, ";") {
+		desc = desc[1 : len(desc)-1]
+	}
+	return strings.ReplaceAll(desc, "/", ".")
+}
@@ -0,0 +1,287 @@
+package dex
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// build assembles a minimal dex file that contains the given classes.
+func build(classes []Class) []byte {
+	var strs []string
+	strIdx := map[string]uint32{}
+	str := func(s string) uint32 {
+		if i, ok := strIdx[s]; ok {
+			return i
+		}
+		strIdx[s] = uint32(len(strs))
+		strs = append(strs, s)
+		return strIdx[s]
+	}
+	var types []uint32
+	typeIdx := map[string]uint32{}
+	typ := func(name string) uint32 {
+		desc := "L" + strings.ReplaceAll(name, ".", "/") + ";"
+		if i, ok := typeIdx[desc]; ok {
+			return i
+		}
+		typeIdx[desc] = uint32(len(types))
+		types = append(types, str(desc))
+		return typeIdx[desc]
+	}
+	type mid struct{ class, name uint32 }
+	var methods []mid
+	for _, c := range classes {
+		typ(c.Name)
+		if c.Super != "" {
+			typ(c.Super)
+		}
+		for _, a := range c.Annotations {
+			typ(a)
+		}
+		for _, m := range c.Methods {
+			methods = append(methods, mid{typ(c.Name), str(m.Name)})
+			for _, a := range m.Annotations {
+				typ(a)
+			}
+		}
+	}
+
+	u32 := func(b []byte, v uint32) []byte { return binary.LittleEndian.AppendUint32(b, v) }
+	uleb := func(b []byte, v uint32) []byte {
+		for v >= 0x80 {
+			b = append(b, byte(v)|0x80)
+			v >>= 7
+		}
+		return append(b, byte(v))
+	}
+
+	stringIDsOff := uint32(0x70)
+	typeIDsOff := stringIDsOff + uint32(len(strs))*4
+	methodIDsOff := typeIDsOff + uint32(len(types))*4
+	classDefsOff := methodIDsOff + uint32(len(methods))*8
+	dataOff := classDefsOff + uint32(len(classes))*32
+
+	var data []byte
+	at := func() uint32 { return dataOff + uint32(len(data)) }
+
+	var stringOffs []uint32
+	for _, s := range strs {
+		stringOffs = append(stringOffs, at())
+		data = uleb(data, uint32(len(s)))
+		data = append(data, s...)
+		data = append(data, 0)
+	}
+
+	annotationSet := func(names []string) uint32 {
+		if len(names) == 0 {
+			return 0
+		}
+		var items []uint32
+		for _, n := range names {
+			items = append(items, at())
+			data = append(data, 1)
+			data = uleb(data, typ(n))
+			data = uleb(data, 0)
+		}
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+		off := at()
+		data = u32(data, uint32(len(items)))
+		for _, i := range items {
+			data = u32(data, i)
+		}
+		return off
+	}
+
+	var classDefs []byte
+	mIdx := uint32(0)
+	for _, c := range classes {
+		first := mIdx
+		classSet := annotationSet(c.Annotations)
+		var methodSets [][2]uint32
+		for i, m := range c.Methods {
+			if off := annotationSet(m.Annotations); off != 0 {
+				methodSets = append(methodSets, [2]uint32{first + uint32(i), off})
+			}
+		}
+
+		dirOff := uint32(0)
+		if classSet != 0 || len(methodSets) > 0 {
+			dirOff = at()
+			data = u32(data, classSet)
+			data = u32(data, 0)
+			data = u32(data, uint32(len(methodSets)))
+			data = u32(data, 0)
+			for _, ms := range methodSets {
+				data = u32(data, ms[0])
+				data = u32(data, ms[1])
+			}
+		}
+
+		classDataOff := at()
+		var direct, virtual []Method
+		for _, m := range c.Methods {
+			if m.AccessFlags&AccStatic != 0 {
+				direct = append(direct, m)
+			} else {
+				virtual = append(virtual, m)
+			}
+		}
+		data = uleb(data, 0)
+		data = uleb(data, 0)
+		data = uleb(data, uint32(len(direct)))
+		data = uleb(data, uint32(len(virtual)))
+		for _, list := range [][]Method{direct, virtual} {
+			prev := first
+			for i, m := range list {
+				idx := first
+				for j, mm := range c.Methods {
+					if mm.Name == m.Name {
+						idx = first + uint32(j)
+					}
+				}
+				if i == 0 {
+					data = uleb(data, idx)
+				} else {
+					data = uleb(data, idx-prev)
+				}
+				prev = idx
+				data = uleb(data, m.AccessFlags)
+				data = uleb(data, 0)
+			}
+		}
+		for len(data)%4 != 0 {
+			data = append(data, 0)
+		}
+		mIdx += uint32(len(c.Methods))
+
+		super := uint32(noIndex)
+		if c.Super != "" {
+			super = typ(c.Super)
+		}
+		classDefs = u32(classDefs, typ(c.Name))
+		classDefs = u32(classDefs, c.AccessFlags)
+		classDefs = u32(classDefs, super)
+		classDefs = u32(classDefs, 0)
+		classDefs = u32(classDefs, noIndex)
+		classDefs = u32(classDefs, dirOff)
+		classDefs = u32(classDefs, classDataOff)
+		classDefs = u32(classDefs, 0)
+	}
+
+	out := make([]byte, 0x70)
+	copy(out, "dex\n035\x00")
+	binary.LittleEndian.PutUint32(out[0x38:], uint32(len(strs)))
+	binary.LittleEndian.PutUint32(out[0x3C:], stringIDsOff)
+	binary.LittleEndian.PutUint32(out[0x40:], uint32(len(types)))
+	binary.LittleEndian.PutUint32(out[0x44:], typeIDsOff)
+	binary.LittleEndian.PutUint32(out[0x58:], uint32(len(methods)))
+	binary.LittleEndian.PutUint32(out[0x5C:], methodIDsOff)
+	binary.LittleEndian.PutUint32(out[0x60:], uint32(len(classes)))
+	binary.LittleEndian.PutUint32(out[0x64:], classDefsOff)
+	for _, o := range stringOffs {
+		out = u32(out, o)
+	}
+	for _, t := range types {
+		out = u32(out, t)
+	}
+	for _, m := range methods {
+		out = binary.LittleEndian.AppendUint16(out, uint16(m.class))
+		out = binary.LittleEndian.AppendUint16(out, 0)
+		out = u32(out, m.name)
+	}
+	out = append(out, classDefs...)
+	out = append(out, data...)
+	binary.LittleEndian.PutUint32(out[0x20:], uint32(len(out)))
+
+	return out
+}
+
+var testClasses = []Class{
+	{
+		Name:        "com.example.LoginTest",
+		Super:       "java.lang.Object",
+		AccessFlags: AccPublic,
+		Annotations: []string{"org.junit.runner.RunWith"},
+		Methods: []Method{
+			{Name: "<init>", AccessFlags: AccPublic | 0x10000},
+			{Name: "setUp", AccessFlags: AccPublic, Annotations: []string{"org.junit.Before"}},
+			{Name: "login", AccessFlags: AccPublic, Annotations: []string{"org.junit.Test", "androidx.test.filters.SmallTest"}},
+		},
+	},
+	{
+		Name:        "com.example.BaseTest",
+		Super:       "java.lang.Object",
+		AccessFlags: AccPublic | AccAbstract,
+		Methods: []Method{
+			{Name: "helper", AccessFlags: AccPublic | AccStatic},
+			{Name: "inherited", AccessFlags: AccPublic, Annotations: []string{"org.junit.Test"}},
+		},
+	},
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse(build(testClasses))
+	assert.NoError(t, err)
+	assert.Equal(t, testClasses, got)
+	assert.False(t, got[0].IsAbstract())
+	assert.True(t, got[1].IsAbstract())
+	assert.True(t, got[0].HasAnnotation("org.junit.runner.RunWith"))
+	assert.True(t, got[0].Methods[2].HasAnnotation("org.junit.Test"))
+	assert.False(t, got[0].Methods[1].HasAnnotation("org.junit.Test"))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse([]byte("not a dex file"))
+	assert.EqualError(t, err, "not a dex file")
+
+	data := build(testClasses)
+	_, err = Parse(data[:len(data)/2])
+	assert.Error(t, err)
+
+	// A table size that wraps around when multiplied in 32 bits.
+	data = build(testClasses)
+	binary.LittleEndian.PutUint32(data[0x38:], 0x40000001)
+	_, err = Parse(data)
+	assert.EqualError(t, err, "string ids out of bounds")
+
+	data = build(testClasses)
+	binary.LittleEndian.PutUint32(data[0x60:], 0x08000001)
+	_, err = Parse(data)
+	assert.EqualError(t, err, "class defs out of bounds")
+}
+
+func TestReadAPK(t *testing.T) {
+	dir := t.TempDir()
+	apk := filepath.Join(dir, "test.apk")
+
+	f, err := os.Create(apk)
+	assert.NoError(t, err)
+	w := zip.NewWriter(f)
+	for name, classes := range map[string][]Class{
+		"classes.dex":         testClasses[:1],
+		"classes2.dex":        testClasses[1:],
+		"assets/classes3.dex": testClasses,
+	} {
+		zf, err := w.Create(name)
+		assert.NoError(t, err)
+		_, err = zf.Write(build(classes))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, w.Close())
+	assert.NoError(t, f.Close())
+
+	got, err := ReadAPK(apk)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, testClasses, got)
+
+	_, err = ReadAPK(filepath.Join(dir, "missing.apk"))
+	assert.Error(t, err)
+}
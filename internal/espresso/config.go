@@ -65,14 +65,22 @@ type Suite struct {
 	Devices            []config.Device           `yaml:"devices,omitempty" json:"devices"`
 	Emulators          []config.Emulator         `yaml:"emulators,omitempty" json:"emulators"`
 	TestOptions        map[string]interface{}    `yaml:"testOptions,omitempty" json:"testOptions"`
+	Shard              string                    `yaml:"shard,omitempty" json:"-"`
 	Timeout            time.Duration             `yaml:"timeout,omitempty" json:"timeout"`
 	AppSettings        config.AppSettings        `yaml:"appSettings,omitempty" json:"appSettings"`
 	NetworkProfile     string                    `yaml:"networkProfile,omitempty" json:"networkProfile,omitempty"`
 	NetworkConditions  *config.NetworkConditions `yaml:"networkConditions,omitempty" json:"networkConditions,omitempty"`
 	PassThreshold      int                       `yaml:"passThreshold,omitempty" json:"-"`
+	Priority           int                       `yaml:"priority,omitempty" json:"-"`
+	DependsOn          []string                  `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry         config.SmartRetry         `yaml:"smartRetry,omitempty" json:"-"`
 }
 
+// Dependencies returns the suites that the suite depends on.
+func (s Suite) Dependencies() config.SuiteDependencies {
+	return config.SuiteDependencies{Name: s.Name, DependsOn: s.DependsOn}
+}
+
 func (s *Suite) ShardConfig() ShardConfig {
 	shards := 0
 	index := 0
@@ -170,6 +178,18 @@ func Validate(p Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := config.ValidateRetryPolicy(p.Sauce.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := config.ValidateFailFast(p.Sauce.FailFast); err != nil {
+		return err
+	}
+
+	if err := config.ValidateSuiteDependencies(p.Suites); err != nil {
+		return err
+	}
+
 	if p.Espresso.App == "" {
 		return errors.New(msg.MissingAppPath)
 	}
@@ -222,6 +242,12 @@ func Validate(p Project) error {
 				return fmt.Errorf("invalid numShards in test option: %v", err)
 			}
 		}
+		if suite.Shard != "" && suite.Shard != "concurrency" {
+			return fmt.Errorf("suite '%s' has an invalid shard option '%s'; only 'concurrency' is supported", suite.Name, suite.Shard)
+		}
+		if suite.Shard != "" && suite.ShardConfig().Shards > 0 {
+			return fmt.Errorf("suite '%s' cannot combine shard with numShards in test options", suite.Name)
+		}
 	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
@@ -269,9 +295,68 @@ func FilterSuites(p *Project, suiteName string) error {
 	return fmt.Errorf(msg.SuiteNameNotFound, suiteName)
 }
 
+// ShardSuites splits suites that are sharded by concurrency into balanced
+// suites. The tests are discovered by reading the classes of the local test app.
+func ShardSuites(p *Project) error {
+	var suites []Suite
+	for _, s := range p.Suites {
+		if s.Shard != "concurrency" {
+			suites = append(suites, s)
+			continue
+		}
+
+		shardedSuites, err := shardByConcurrency(s, p.Sauce.Concurrency)
+		if err != nil {
+			return fmt.Errorf("failed to get tests from test app(%q): %v", s.TestApp, err)
+		}
+		suites = append(suites, shardedSuites...)
+	}
+	p.Suites = suites
+
+	return nil
+}
+
+func shardByConcurrency(suite Suite, ccy int) ([]Suite, error) {
+	if apps.IsStorageReference(suite.TestApp) || apps.IsRemote(suite.TestApp) {
+		return nil, errors.New("sharding by concurrency requires a local test app")
+	}
+
+	tests, err := ListTests(suite.TestApp, suite.TestOptions)
+	if err != nil {
+		return nil, err
+	}
+	if len(tests) == 0 {
+		return nil, errors.New(msg.ShardingConfigurationNoMatchingTests)
+	}
+
+	buckets := balance(tests, ccy)
+	var suites []Suite
+	for i, b := range buckets {
+		var classes []string
+		for _, t := range b {
+			classes = append(classes, t.Filters()...)
+		}
+
+		testOptions := map[string]interface{}{}
+		for k, v := range suite.TestOptions {
+			testOptions[k] = v
+		}
+		testOptions["class"] = classes
+
+		currSuite := suite
+		currSuite.Name = fmt.Sprintf("%s - %d/%d", suite.Name, i+1, len(buckets))
+		currSuite.TestOptions = testOptions
+		suites = append(suites, currSuite)
+	}
+	return suites, nil
+}
+
 func GetShardTypes(suites []Suite) []string {
 	var set = map[string]bool{}
 	for _, suite := range suites {
+		if suite.Shard != "" {
+			set[suite.Shard] = true
+		}
 		if v, ok := suite.TestOptions["numShards"]; ok {
 			num, _ := strconv.Atoi(fmt.Sprintf("%v", v))
 			set["numShards"] = num > 0
@@ -0,0 +1,255 @@
+package espresso
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/saucelabs/saucectl/internal/dex"
+)
+
+// Annotations used by JUnit4 to mark test methods.
+const (
+	testAnnotation   = "org.junit.Test"
+	ignoreAnnotation = "org.junit.Ignore"
+)
+
+// TestClass represents a test class and the test methods it contributes to a
+// run after applying the suite's test options.
+type TestClass struct {
+	Name    string
+	Methods []string
+	// Partial is true if only some of the class' methods were explicitly
+	// selected via the "class" test option (e.g. "com.example.Test#method").
+	Partial bool
+}
+
+// Filters returns the values that select this class via the "class" test option.
+func (c TestClass) Filters() []string {
+	if !c.Partial {
+		return []string{c.Name}
+	}
+
+	var filters []string
+	for _, m := range c.Methods {
+		filters = append(filters, fmt.Sprintf("%s#%s", c.Name, m))
+	}
+	return filters
+}
+
+// testFilter represents the test filtering options supported by the
+// AndroidJUnitRunner, as far as they can be applied statically.
+type testFilter struct {
+	classes        map[string][]string
+	notClasses     map[string][]string
+	packages       []string
+	notPackages    []string
+	annotations    []string
+	notAnnotations []string
+}
+
+func newTestFilter(opts map[string]interface{}) testFilter {
+	return testFilter{
+		classes:        classFilter(optionValues(opts["class"])),
+		notClasses:     classFilter(optionValues(opts["notClass"])),
+		packages:       optionValues(opts["package"]),
+		notPackages:    optionValues(opts["notPackage"]),
+		annotations:    optionValues(opts["annotation"]),
+		notAnnotations: optionValues(opts["notAnnotation"]),
+	}
+}
+
+// classFilter maps class names to the selected methods. A class without
+// any methods is selected in full.
+func classFilter(values []string) map[string][]string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	filter := map[string][]string{}
+	for _, v := range values {
+		class, method, _ := strings.Cut(v, "#")
+		if method == "" {
+			filter[class] = nil
+			continue
+		}
+		if methods, ok := filter[class]; ok && methods == nil {
+			// Class is already selected in full.
+			continue
+		}
+		filter[class] = append(filter[class], method)
+	}
+	return filter
+}
+
+// optionValues normalizes a test option value, that may either be a comma
+// separated string or a list, to a list of strings.
+func optionValues(v interface{}) []string {
+	var raw []string
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case []string:
+		raw = val
+	case []interface{}:
+		for _, item := range val {
+			raw = append(raw, fmt.Sprintf("%v", item))
+		}
+	default:
+		raw = []string{fmt.Sprintf("%v", val)}
+	}
+
+	var values []string
+	for _, r := range raw {
+		for _, s := range strings.Split(r, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+func (f testFilter) includesClass(name string) bool {
+	if f.classes != nil {
+		if _, ok := f.classes[name]; !ok {
+			return false
+		}
+	}
+	if methods, ok := f.notClasses[name]; ok && methods == nil {
+		return false
+	}
+	if len(f.packages) > 0 && !inPackages(name, f.packages) {
+		return false
+	}
+	return !inPackages(name, f.notPackages)
+}
+
+func (f testFilter) includesMethod(class dex.Class, method dex.Method) bool {
+	if methods := f.classes[class.Name]; methods != nil && !slices.Contains(methods, method.Name) {
+		return false
+	}
+	if slices.Contains(f.notClasses[class.Name], method.Name) {
+		return false
+	}
+
+	annotated := func(a string) bool {
+		return class.HasAnnotation(a) || method.HasAnnotation(a)
+	}
+	for _, a := range f.notAnnotations {
+		if annotated(a) {
+			return false
+		}
+	}
+	if len(f.annotations) == 0 {
+		return true
+	}
+	for _, a := range f.annotations {
+		if annotated(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func inPackages(class string, packages []string) bool {
+	for _, p := range packages {
+		if strings.HasPrefix(class, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// ListTests returns the JUnit4 test classes of the given test APK that match
+// the test options. Test methods inherited from super classes are taken into
+// account, as long as the super classes are part of the test APK.
+func ListTests(testApp string, testOptions map[string]interface{}) ([]TestClass, error) {
+	classes, err := dex.ReadAPK(testApp)
+	if err != nil {
+		return nil, err
+	}
+
+	return findTests(classes, newTestFilter(testOptions)), nil
+}
+
+func findTests(classes []dex.Class, filter testFilter) []TestClass {
+	byName := map[string]dex.Class{}
+	for _, c := range classes {
+		byName[c.Name] = c
+	}
+
+	var tests []TestClass
+	for _, c := range classes {
+		if c.IsAbstract() || c.HasAnnotation(ignoreAnnotation) || !filter.includesClass(c.Name) {
+			continue
+		}
+
+		var methods []string
+		seen := map[string]bool{}
+		for cls, ok := c, true; ok; cls, ok = byName[cls.Super] {
+			for _, m := range cls.Methods {
+				if seen[m.Name] || m.AccessFlags&dex.AccStatic != 0 {
+					continue
+				}
+				// Overridden methods shadow those of the super class, whether
+				// they're tests or not.
+				seen[m.Name] = true
+				if !m.HasAnnotation(testAnnotation) || m.HasAnnotation(ignoreAnnotation) {
+					continue
+				}
+				if filter.includesMethod(c, m) {
+					methods = append(methods, m.Name)
+				}
+			}
+		}
+		if len(methods) == 0 {
+			continue
+		}
+
+		sort.Strings(methods)
+		tests = append(tests, TestClass{
+			Name:    c.Name,
+			Methods: methods,
+			Partial: filter.classes[c.Name] != nil,
+		})
+	}
+
+	sort.Slice(tests, func(i, j int) bool {
+		return tests[i].Name < tests[j].Name
+	})
+	return tests
+}
+
+// balance distributes the test classes across at most n buckets, so that
+// each bucket contains roughly the same number of test methods.
+func balance(tests []TestClass, n int) [][]TestClass {
+	if n > len(tests) {
+		n = len(tests)
+	}
+	if n < 1 {
+		return nil
+	}
+
+	sorted := make([]TestClass, len(tests))
+	copy(sorted, tests)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].Methods) > len(sorted[j].Methods)
+	})
+
+	buckets := make([][]TestClass, n)
+	weights := make([]int, n)
+	for _, t := range sorted {
+		lightest := 0
+		for i := range weights {
+			if weights[i] < weights[lightest] {
+				lightest = i
+			}
+		}
+		buckets[lightest] = append(buckets[lightest], t)
+		weights[lightest] += len(t.Methods)
+	}
+
+	return buckets
+}
@@ -0,0 +1,166 @@
+package espresso
+
+import (
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/dex"
+	"github.com/stretchr/testify/assert"
+)
+
+var classes = []dex.Class{
+	{
+		Name:        "com.example.BaseTest",
+		Super:       "java.lang.Object",
+		AccessFlags: dex.AccPublic | dex.AccAbstract,
+		Methods: []dex.Method{
+			{Name: "sharedTest", AccessFlags: dex.AccPublic, Annotations: []string{testAnnotation}},
+		},
+	},
+	{
+		Name:        "com.example.login.LoginTest",
+		Super:       "com.example.BaseTest",
+		AccessFlags: dex.AccPublic,
+		Annotations: []string{"androidx.test.filters.LargeTest"},
+		Methods: []dex.Method{
+			{Name: "setUp", AccessFlags: dex.AccPublic, Annotations: []string{"org.junit.Before"}},
+			{Name: "login", AccessFlags: dex.AccPublic, Annotations: []string{testAnnotation}},
+			{Name: "logout", AccessFlags: dex.AccPublic, Annotations: []string{testAnnotation, "com.example.Flaky"}},
+			{Name: "skipped", AccessFlags: dex.AccPublic, Annotations: []string{testAnnotation, ignoreAnnotation}},
+		},
+	},
+	{
+		Name:        "com.example.cart.CartTest",
+		Super:       "com.example.BaseTest",
+		AccessFlags: dex.AccPublic,
+		Methods: []dex.Method{
+			{Name: "sharedTest", AccessFlags: dex.AccPublic},
+			{Name: "add", AccessFlags: dex.AccPublic, Annotations: []string{testAnnotation, "androidx.test.filters.SmallTest"}},
+		},
+	},
+	{
+		Name:        "com.example.IgnoredTest",
+		AccessFlags: dex.AccPublic,
+		Annotations: []string{ignoreAnnotation},
+		Methods: []dex.Method{
+			{Name: "test", AccessFlags: dex.AccPublic, Annotations: []string{testAnnotation}},
+		},
+	},
+	{
+		Name:        "com.example.Helper",
+		AccessFlags: dex.AccPublic,
+		Methods: []dex.Method{
+			{Name: "help", AccessFlags: dex.AccPublic | dex.AccStatic},
+		},
+	},
+}
+
+func Test_findTests(t *testing.T) {
+	testCases := []struct {
+		name        string
+		testOptions map[string]interface{}
+		want        []TestClass
+	}{
+		{
+			name: "all tests",
+			want: []TestClass{
+				{Name: "com.example.cart.CartTest", Methods: []string{"add"}},
+				{Name: "com.example.login.LoginTest", Methods: []string{"login", "logout", "sharedTest"}},
+			},
+		},
+		{
+			name:        "class and method selection",
+			testOptions: map[string]interface{}{"class": []interface{}{"com.example.cart.CartTest", "com.example.login.LoginTest#login"}},
+			want: []TestClass{
+				{Name: "com.example.cart.CartTest", Methods: []string{"add"}},
+				{Name: "com.example.login.LoginTest", Methods: []string{"login"}, Partial: true},
+			},
+		},
+		{
+			name:        "notClass",
+			testOptions: map[string]interface{}{"notClass": []string{"com.example.cart.CartTest", "com.example.login.LoginTest#logout"}},
+			want: []TestClass{
+				{Name: "com.example.login.LoginTest", Methods: []string{"login", "sharedTest"}},
+			},
+		},
+		{
+			name:        "package",
+			testOptions: map[string]interface{}{"package": "com.example.cart"},
+			want: []TestClass{
+				{Name: "com.example.cart.CartTest", Methods: []string{"add"}},
+			},
+		},
+		{
+			name:        "class annotation",
+			testOptions: map[string]interface{}{"annotation": "androidx.test.filters.LargeTest"},
+			want: []TestClass{
+				{Name: "com.example.login.LoginTest", Methods: []string{"login", "logout", "sharedTest"}},
+			},
+		},
+		{
+			name:        "method annotations",
+			testOptions: map[string]interface{}{"annotation": "androidx.test.filters.SmallTest", "notAnnotation": "com.example.Flaky"},
+			want: []TestClass{
+				{Name: "com.example.cart.CartTest", Methods: []string{"add"}},
+			},
+		},
+		{
+			name:        "notAnnotation",
+			testOptions: map[string]interface{}{"notAnnotation": "androidx.test.filters.SmallTest,com.example.Flaky"},
+			want: []TestClass{
+				{Name: "com.example.login.LoginTest", Methods: []string{"login", "sharedTest"}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := findTests(classes, newTestFilter(tc.testOptions))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func Test_balance(t *testing.T) {
+	tests := []TestClass{
+		{Name: "A", Methods: []string{"1"}},
+		{Name: "B", Methods: []string{"1", "2", "3", "4"}},
+		{Name: "C", Methods: []string{"1", "2"}},
+		{Name: "D", Methods: []string{"1", "2"}},
+		{Name: "E", Methods: []string{"1"}},
+	}
+
+	assert.Equal(t, [][]TestClass{
+		{tests[1], tests[0]},
+		{tests[2], tests[3], tests[4]},
+	}, balance(tests, 2))
+	assert.Len(t, balance(tests, 10), 5)
+	assert.Equal(t, [][]TestClass{{tests[1], tests[2], tests[3], tests[0], tests[4]}}, balance(tests, 1))
+	assert.Nil(t, balance(nil, 2))
+
+	got := balance(tests, 3)
+	assert.Equal(t, []TestClass{tests[1]}, got[0])
+	assert.Equal(t, []TestClass{tests[2], tests[0]}, got[1])
+	assert.Equal(t, []TestClass{tests[3], tests[4]}, got[2])
+}
+
+func TestTestClass_Filters(t *testing.T) {
+	assert.Equal(t, []string{"com.example.Test"}, TestClass{Name: "com.example.Test", Methods: []string{"a", "b"}}.Filters())
+	assert.Equal(t,
+		[]string{"com.example.Test#a", "com.example.Test#b"},
+		TestClass{Name: "com.example.Test", Methods: []string{"a", "b"}, Partial: true}.Filters(),
+	)
+}
+
+func TestShardSuites(t *testing.T) {
+	p := &Project{
+		Sauce: config.SauceConfig{Concurrency: 2},
+		Suites: []Suite{
+			{Name: "plain", TestApp: "storage:filename=test.apk"},
+		},
+	}
+	assert.NoError(t, ShardSuites(p))
+	assert.Equal(t, []Suite{{Name: "plain", TestApp: "storage:filename=test.apk"}}, p.Suites)
+
+	p.Suites[0].Shard = "concurrency"
+	assert.EqualError(t, ShardSuites(p), `failed to get tests from test app("storage:filename=test.apk"): sharding by concurrency requires a local test app`)
+}
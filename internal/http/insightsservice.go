@@ -25,16 +25,19 @@ type archivesJobList struct {
 
 // archivesJob represents job response structure
 type archivesJob struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Status      string `json:"status"`
-	Error       string `json:"error"`
-	Framework   string `json:"automation_backend"`
-	Device      string `json:"device"`
-	BrowserName string `json:"browser_name"`
-	OS          string `json:"os"`
-	OSVersion   string `json:"os_version"`
-	Source      string `json:"source"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+	Framework      string `json:"automation_backend"`
+	Device         string `json:"device"`
+	BrowserName    string `json:"browser_name"`
+	BrowserVersion string `json:"browser_version"`
+	OS             string `json:"os"`
+	OSVersion      string `json:"os_version"`
+	Source         string `json:"source"`
+	// Duration of the job in seconds.
+	Duration int `json:"duration"`
 }
 
 // AutomaticRunMode indicates the job is automated
@@ -103,6 +106,10 @@ type testRunsInput struct {
 	TestRuns []insights.TestRun `json:"test_runs,omitempty"`
 }
 
+type testRunsOutput struct {
+	Items []insights.TestRun `json:"items"`
+}
+
 type testRunError struct {
 	Loc  []interface{} `json:"loc,omitempty"`
 	Msg  string        `json:"msg,omitempty"`
@@ -170,6 +177,54 @@ func (c *InsightsService) PostTestRun(ctx context.Context, runs []insights.TestR
 	return fmt.Errorf("unexpected status code from API: %d", resp.StatusCode)
 }
 
+// ListTestRuns returns the test runs that match the given options.
+func (c *InsightsService) ListTestRuns(ctx context.Context, opts insights.ListTestRunsOptions) ([]insights.TestRun, error) {
+	url := fmt.Sprintf("%s/test-runs/v1/", c.URL)
+	req, err := NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(c.Credentials.Username, c.Credentials.AccessKey)
+
+	q := req.URL.Query()
+	if opts.Name != "" {
+		q.Add("name", opts.Name)
+	}
+	if opts.Framework != "" {
+		q.Add("framework", opts.Framework)
+	}
+	if !opts.Since.IsZero() {
+		q.Add("start_time", opts.Since.UTC().Format(time.RFC3339))
+	}
+	if !opts.Until.IsZero() {
+		q.Add("end_time", opts.Until.UTC().Format(time.RFC3339))
+	}
+	if opts.Limit > 0 {
+		q.Add("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Offset > 0 {
+		q.Add("offset", strconv.Itoa(opts.Offset))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	var out testRunsOutput
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	return out.Items, nil
+}
+
 // ListJobs returns job list
 func (c *InsightsService) ListJobs(ctx context.Context, opts insights.ListJobsOptions) ([]job.Job, error) {
 	url := fmt.Sprintf("%s/v2/archives/jobs", c.URL)
@@ -181,19 +236,31 @@ func (c *InsightsService) ListJobs(ctx context.Context, opts insights.ListJobsOp
 
 	q := req.URL.Query()
 	queries := map[string]string{
-		"ts":       strconv.FormatInt(time.Now().UTC().UnixMilli(), 10),
-		"page":     strconv.Itoa(opts.Page),
-		"size":     strconv.Itoa(opts.Size),
-		"status":   opts.Status,
-		"owner_id": opts.UserID,
-		"run_mode": AutomaticRunMode,
-		"source":   string(opts.Source),
+		"ts":                 strconv.FormatInt(time.Now().UTC().UnixMilli(), 10),
+		"page":               strconv.Itoa(opts.Page),
+		"size":               strconv.Itoa(opts.Size),
+		"status":             opts.Status,
+		"owner_id":           opts.UserID,
+		"run_mode":           AutomaticRunMode,
+		"source":             string(opts.Source),
+		"name":               opts.Name,
+		"build":              opts.Build,
+		"automation_backend": opts.Framework,
+	}
+	if !opts.Since.IsZero() {
+		queries["start"] = strconv.FormatInt(opts.Since.Unix(), 10)
+	}
+	if !opts.Until.IsZero() {
+		queries["end"] = strconv.FormatInt(opts.Until.Unix(), 10)
 	}
 	for k, v := range queries {
 		if v != "" {
 			q.Add(k, v)
 		}
 	}
+	for _, t := range opts.Tags {
+		q.Add("tags", t)
+	}
 	req.URL.RawQuery = q.Encode()
 
 	resp, err := c.HTTPClient.Do(req)
@@ -261,14 +328,17 @@ func (c *InsightsService) parseJobs(body io.ReadCloser) ([]job.Job, error) {
 // parseJob converts archivesJob to job.Job.
 func (c *InsightsService) convertJob(j archivesJob) job.Job {
 	return job.Job{
-		ID:          j.ID,
-		Name:        j.Name,
-		Status:      j.Status,
-		Error:       j.Error,
-		OS:          j.OS,
-		OSVersion:   j.OSVersion,
-		Framework:   j.Framework,
-		DeviceName:  j.Device,
-		BrowserName: j.BrowserName,
+		ID:             j.ID,
+		Name:           j.Name,
+		Status:         j.Status,
+		Error:          j.Error,
+		OS:             j.OS,
+		OSVersion:      j.OSVersion,
+		Framework:      j.Framework,
+		DeviceName:     j.Device,
+		BrowserName:    j.BrowserName,
+		BrowserVersion: j.BrowserVersion,
+		IsRDC:          job.Source(j.Source) == job.SourceRDC,
+		Duration:       time.Duration(j.Duration) * time.Second,
 	}
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/saucelabs/saucectl/internal/iam"
 	"github.com/saucelabs/saucectl/internal/insights"
@@ -60,3 +62,48 @@ func TestInsightsService_PostTestRun(t *testing.T) {
 		ts.Close()
 	}
 }
+
+func TestInsightsService_ListTestRuns(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/test-runs/v1/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("name") != "login" || q.Get("framework") != "playwright" || q.Get("start_time") != "2024-01-01T00:00:00Z" || q.Get("limit") != "10" || q.Get("offset") != "20" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"name":"login","status":"failed","duration":3,"sauce_job":{"id":"abc","name":"chrome"}}]}`))
+	}))
+	defer ts.Close()
+
+	c := &InsightsService{
+		HTTPClient:  ts.Client(),
+		URL:         ts.URL,
+		Credentials: iam.Credentials{AccessKey: "accessKey", Username: "username"},
+	}
+
+	got, err := c.ListTestRuns(context.Background(), insights.ListTestRunsOptions{
+		Name:      "login",
+		Framework: "playwright",
+		Since:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Limit:     10,
+		Offset:    20,
+	})
+	if err != nil {
+		t.Fatalf("ListTestRuns() error = %v", err)
+	}
+
+	want := []insights.TestRun{
+		{
+			Name:     "login",
+			Status:   insights.StateFailed,
+			Duration: 3,
+			SauceJob: &insights.Job{ID: "abc", Name: "chrome"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListTestRuns() got = %v, want %v", got, want)
+	}
+}
@@ -67,6 +67,8 @@ type SauceOpts struct {
 	Visibility       string   `json:"public,omitempty"`
 	NodeVersion      string   `json:"nodeVersion,omitempty"`
 
+	CustomData map[string]interface{} `json:"customData,omitempty"`
+
 	// VMD specific settings.
 
 	ARMRequired bool `json:"armRequired,omitempty"`
@@ -145,6 +147,7 @@ func (c *Webdriver) StartJob(ctx context.Context, opts job.StartOptions) (job.Jo
 			TestName:         opts.Name,
 			BuildName:        opts.Build,
 			Tags:             opts.Tags,
+			CustomData:       opts.CustomData,
 			Batch: Batch{
 				Framework:        opts.Framework,
 				FrameworkVersion: opts.FrameworkVersion,
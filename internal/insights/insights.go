@@ -2,6 +2,7 @@ package insights
 
 import (
 	"context"
+	"time"
 
 	"github.com/saucelabs/saucectl/internal/iam"
 	"github.com/saucelabs/saucectl/internal/job"
@@ -12,6 +13,7 @@ type Service interface {
 	PostTestRun(ctx context.Context, runs []TestRun) error
 	ListJobs(ctx context.Context, opts ListJobsOptions) ([]job.Job, error)
 	ReadJob(ctx context.Context, id string) (job.Job, error)
+	ListTestRuns(ctx context.Context, opts ListTestRunsOptions) ([]TestRun, error)
 }
 
 // ListJobsOptions represents the query option for listing jobs
@@ -21,4 +23,33 @@ type ListJobsOptions struct {
 	Size   int
 	Status string
 	Source job.Source
+
+	// Name filters jobs whose name contains the given substring.
+	Name string
+	// Build filters jobs by build name.
+	Build string
+	// Tags filters jobs that carry all the given tags.
+	Tags []string
+	// Framework filters jobs by automation framework, e.g. "playwright".
+	Framework string
+	// Since and Until restrict jobs to the given time window. A zero value
+	// leaves the respective bound open.
+	Since time.Time
+	Until time.Time
+}
+
+// ListTestRunsOptions represents the query option for listing test runs.
+type ListTestRunsOptions struct {
+	// Name filters test runs by test name.
+	Name string
+	// Framework filters test runs by framework, e.g. "playwright".
+	Framework string
+	// Since and Until restrict test runs to the given time window. A zero
+	// value leaves the respective bound open.
+	Since time.Time
+	Until time.Time
+	// Limit is the maximum number of test runs to return.
+	Limit int
+	// Offset is the number of test runs to skip, e.g. to fetch the next page.
+	Offset int
 }
@@ -0,0 +1,103 @@
+package insights
+
+import (
+	"sort"
+	"time"
+)
+
+// TestStats represents the aggregated history of a single test.
+type TestStats struct {
+	Name  string `json:"name"`
+	Suite string `json:"suite,omitempty"`
+	// Runs is the number of runs that either passed or failed. Skipped runs
+	// are not taken into account.
+	Runs     int `json:"runs"`
+	Failures int `json:"failures"`
+	// FailureRate is the ratio of failed runs, between 0 and 1.
+	FailureRate float64 `json:"failureRate"`
+	// Flakiness is the ratio of consecutive runs that changed outcome,
+	// between 0 and 1. A test that alternates between passing and failing has
+	// a flakiness of 1, whereas a test that always fails has a flakiness of 0.
+	Flakiness   float64       `json:"flakiness"`
+	AvgDuration time.Duration `json:"avgDuration"`
+	// LastResults holds the statuses of the most recent runs, newest first.
+	LastResults []string `json:"lastResults"`
+}
+
+// Summarize aggregates test runs per suite and test name. The result is
+// sorted by failure rate, then flakiness, in descending order. lastN limits
+// the number of recent statuses that are retained per test.
+func Summarize(runs []TestRun, lastN int) []TestStats {
+	type key struct{ suite, name string }
+
+	groups := map[key][]TestRun{}
+	var keys []key
+	for _, r := range runs {
+		k := key{name: r.Name}
+		if r.SauceJob != nil {
+			k.suite = r.SauceJob.Name
+		}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], r)
+	}
+
+	var stats []TestStats
+	for _, k := range keys {
+		stats = append(stats, summarizeTest(k.suite, k.name, groups[k], lastN))
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].FailureRate != stats[j].FailureRate {
+			return stats[i].FailureRate > stats[j].FailureRate
+		}
+		return stats[i].Flakiness > stats[j].Flakiness
+	})
+
+	return stats
+}
+
+func summarizeTest(suite, name string, runs []TestRun, lastN int) TestStats {
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].StartTime.After(runs[j].StartTime)
+	})
+
+	s := TestStats{
+		Name:        name,
+		Suite:       suite,
+		LastResults: []string{},
+	}
+
+	var totalDuration int
+	var flips int
+	prev := ""
+	for _, r := range runs {
+		if len(s.LastResults) < lastN {
+			s.LastResults = append(s.LastResults, r.Status)
+		}
+		if r.Status == StateSkipped {
+			continue
+		}
+
+		s.Runs++
+		totalDuration += r.Duration
+		if r.Status == StateFailed {
+			s.Failures++
+		}
+		if prev != "" && prev != r.Status {
+			flips++
+		}
+		prev = r.Status
+	}
+
+	if s.Runs > 0 {
+		s.FailureRate = float64(s.Failures) / float64(s.Runs)
+		s.AvgDuration = time.Duration(totalDuration) * time.Second / time.Duration(s.Runs)
+	}
+	if s.Runs > 1 {
+		s.Flakiness = float64(flips) / float64(s.Runs-1)
+	}
+
+	return s
+}
@@ -0,0 +1,60 @@
+package insights
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(day int) time.Time {
+		return start.AddDate(0, 0, day)
+	}
+	suite := &Job{Name: "chrome"}
+
+	runs := []TestRun{
+		{Name: "flaky", SauceJob: suite, Status: StatePassed, StartTime: at(0), Duration: 10},
+		{Name: "flaky", SauceJob: suite, Status: StateFailed, StartTime: at(1), Duration: 20},
+		{Name: "flaky", SauceJob: suite, Status: StatePassed, StartTime: at(2), Duration: 30},
+		{Name: "broken", SauceJob: suite, Status: StateFailed, StartTime: at(0), Duration: 5},
+		{Name: "broken", SauceJob: suite, Status: StateFailed, StartTime: at(1), Duration: 5},
+		{Name: "stable", SauceJob: suite, Status: StatePassed, StartTime: at(0), Duration: 1},
+		{Name: "stable", SauceJob: suite, Status: StateSkipped, StartTime: at(1)},
+	}
+
+	want := []TestStats{
+		{
+			Name:        "broken",
+			Suite:       "chrome",
+			Runs:        2,
+			Failures:    2,
+			FailureRate: 1,
+			AvgDuration: 5 * time.Second,
+			LastResults: []string{StateFailed, StateFailed},
+		},
+		{
+			Name:        "flaky",
+			Suite:       "chrome",
+			Runs:        3,
+			Failures:    1,
+			FailureRate: 1.0 / 3,
+			Flakiness:   1,
+			AvgDuration: 20 * time.Second,
+			LastResults: []string{StatePassed, StateFailed},
+		},
+		{
+			Name:        "stable",
+			Suite:       "chrome",
+			Runs:        1,
+			AvgDuration: 1 * time.Second,
+			LastResults: []string{StateSkipped, StatePassed},
+		},
+	}
+
+	got := Summarize(runs, 2)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Summarize() mismatch (-want +got):\n%s", diff)
+	}
+}
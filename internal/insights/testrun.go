@@ -136,18 +136,31 @@ func FromJUnit(suites junit.TestSuites, jobID string, jobName string, details De
 func FromSauceReport(report saucereport.SauceReport, jobID string, jobName string, details Details, isRDC bool) []TestRun {
 	var testRuns []TestRun
 	for _, s := range report.Suites {
-		testRuns = append(testRuns, deepConvert(s)...)
+		testRuns = append(testRuns, deepConvert(s, "")...)
 	}
 	enrichInsightTestRun(testRuns, jobID, jobName, details, isRDC)
 	return testRuns
 }
 
-func deepConvert(suite saucereport.Suite) []TestRun {
+// SuitePathSeparator separates the names of nested suites in the path of a
+// test run.
+const SuitePathSeparator = " > "
+
+// deepConvert converts the tests of the suite and its child suites to test
+// runs. The path of the parent suites is retained as PathName, so that tests
+// can be told apart by their suites (e.g. the feature file of a scenario).
+func deepConvert(suite saucereport.Suite, parent string) []TestRun {
 	var runs []TestRun
 
+	path := suite.Name
+	if parent != "" {
+		path = parent + SuitePathSeparator + suite.Name
+	}
+
 	for _, test := range suite.Tests {
 		newRun := TestRun{
 			Name:         test.Name,
+			PathName:     path,
 			Status:       uniformizeJSONStatus(test.Status),
 			CreationTime: test.StartTime,
 			StartTime:    test.StartTime,
@@ -165,7 +178,7 @@ func deepConvert(suite saucereport.Suite) []TestRun {
 	}
 
 	for _, child := range suite.Suites {
-		runs = append(runs, deepConvert(child)...)
+		runs = append(runs, deepConvert(child, path)...)
 	}
 	return runs
 }
@@ -203,8 +216,12 @@ func enrichInsightTestRun(runs []TestRun, jobID string, jobName string, details
 		runs[idx].Type = resolveType(details.Framework)
 
 		if provider != ci.None {
+			branch := ciData.Branch
+			if branch == "" {
+				branch = ciData.Ref
+			}
 			runs[idx].CI = &CI{
-				Branch:     ciData.Ref,
+				Branch:     branch,
 				RefName:    ciData.Ref,
 				Repository: ciData.Repo,
 				CommitSha:  ciData.SHA,
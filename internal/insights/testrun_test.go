@@ -113,6 +113,7 @@ func TestFromSauceReport(t *testing.T) {
 			want: []TestRun{
 				{
 					Name:         "Test #1.1",
+					PathName:     "Suite #1",
 					CreationTime: time.Date(2022, 12, 13, 14, 15, 16, 17, time.UTC),
 					StartTime:    time.Date(2022, 12, 13, 14, 15, 16, 17, time.UTC),
 					EndTime:      time.Date(2022, 12, 13, 14, 15, 36, 17, time.UTC),
@@ -127,6 +128,7 @@ func TestFromSauceReport(t *testing.T) {
 				},
 				{
 					Name:         "Test #1.2",
+					PathName:     "Suite #1",
 					CreationTime: time.Date(2022, 12, 13, 14, 15, 16, 17, time.UTC),
 					StartTime:    time.Date(2022, 12, 13, 14, 15, 16, 17, time.UTC),
 					EndTime:      time.Date(2022, 12, 13, 14, 15, 36, 17, time.UTC),
@@ -188,6 +190,7 @@ func TestFromSauceReport(t *testing.T) {
 			want: []TestRun{
 				{
 					Name:         "Test #1.1",
+					PathName:     "Suite #1",
 					CreationTime: time.Date(2022, 12, 15, 14, 15, 16, 17, time.UTC),
 					StartTime:    time.Date(2022, 12, 15, 14, 15, 16, 17, time.UTC),
 					EndTime:      time.Date(2022, 12, 15, 14, 15, 36, 17, time.UTC),
@@ -202,6 +205,7 @@ func TestFromSauceReport(t *testing.T) {
 				},
 				{
 					Name:         "Test #1.1.1",
+					PathName:     "Suite #1 > Suite #1.1",
 					CreationTime: time.Date(2022, 12, 13, 14, 15, 16, 17, time.UTC),
 					StartTime:    time.Date(2022, 12, 13, 14, 15, 16, 17, time.UTC),
 					EndTime:      time.Date(2022, 12, 13, 14, 15, 36, 17, time.UTC),
@@ -216,6 +220,7 @@ func TestFromSauceReport(t *testing.T) {
 				},
 				{
 					Name:         "Test #1.1.2",
+					PathName:     "Suite #1 > Suite #1.1",
 					CreationTime: time.Date(2022, 12, 14, 14, 15, 16, 17, time.UTC),
 					StartTime:    time.Date(2022, 12, 14, 14, 15, 16, 17, time.UTC),
 					EndTime:      time.Date(2022, 12, 14, 14, 15, 36, 17, time.UTC),
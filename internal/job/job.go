@@ -27,6 +27,17 @@ const (
 	StateUnknown    = "?"
 )
 
+// The following states are only used by saucectl for suites that didn't run to
+// completion.
+const (
+	// StateCancelled is used for suites that were stopped or never started,
+	// because the run was stopped early.
+	StateCancelled = "cancelled"
+	// StateSkipped is used for suites that never started, because a suite they
+	// depend on didn't pass.
+	StateSkipped = "skipped"
+)
+
 // The following states are only used by RDC.
 const (
 	StatePassed = "passed"
@@ -65,6 +76,9 @@ type Job struct {
 	// TimedOut flags a job as an unfinished one.
 	TimedOut bool
 
+	// Duration is the total runtime of the job, if known.
+	Duration time.Duration
+
 	URL string
 }
 
@@ -42,9 +42,10 @@ type StartOptions struct {
 
 	// Job Metadata.
 
-	Name  string   `json:"name,omitempty"`
-	Build string   `json:"build,omitempty"`
-	Tags  []string `json:"tags,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Build      string                 `json:"build,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
+	CustomData map[string]interface{} `json:"customData,omitempty"`
 
 	// Job Access Control.
 
@@ -59,6 +60,14 @@ type StartOptions struct {
 	Retries    int        `json:"-"`
 	SmartRetry SmartRetry `json:"-"`
 
+	// Scheduling.
+
+	// SuiteName is the name of the suite that the job belongs to. A suite may
+	// consist of multiple jobs, e.g. one per device.
+	SuiteName string   `json:"-"`
+	Priority  int      `json:"-"`
+	DependsOn []string `json:"-"`
+
 	// Cypress & Playwright & TestCafe only.
 
 	BrowserName      string `json:"browserName,omitempty"`
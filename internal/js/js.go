@@ -0,0 +1,273 @@
+// Package js implements a lightweight tokenizer for JavaScript and TypeScript
+// sources, which is used to statically find test declarations in spec files.
+package js
+
+import (
+	"strings"
+)
+
+// Kind represents the kind of a token.
+type Kind int
+
+// Kinds of tokens.
+const (
+	Ident Kind = iota
+	String
+	Template
+	Number
+	Regex
+	Punct
+)
+
+// TemplatePart represents a part of a template literal, which is either a
+// literal text or the source of an embedded expression.
+type TemplatePart struct {
+	Text   string
+	IsExpr bool
+}
+
+// Token represents a single JavaScript/TypeScript token. Comments and
+// whitespace are dropped.
+type Token struct {
+	Kind  Kind
+	Value string
+	// Parts are the parts of a template literal.
+	Parts []TemplatePart
+}
+
+// keywords after which a slash starts a regular expression rather than a
+// division.
+var regexKeywords = map[string]bool{
+	"return": true, "typeof": true, "case": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "else": true,
+	"do": true, "instanceof": true, "yield": true, "await": true,
+}
+
+// Tokenize splits the JavaScript/TypeScript source into tokens. It's not a
+// complete lexer, but handles everything that's necessary to reliably find
+// test declarations: strings, template literals, comments and regular
+// expressions, which may contain brackets.
+func Tokenize(src string) []Token {
+	var tokens []Token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			n := strings.IndexByte(src[i:], '\n')
+			if n < 0 {
+				return tokens
+			}
+			i += n
+		case strings.HasPrefix(src[i:], "/*"):
+			n := strings.Index(src[i+2:], "*/")
+			if n < 0 {
+				return tokens
+			}
+			i += n + 4
+		case c == '\'' || c == '"':
+			s, end := readString(src, i)
+			tokens = append(tokens, Token{Kind: String, Value: s})
+			i = end
+		case c == '`':
+			parts, end := readTemplate(src, i)
+			tokens = append(tokens, Token{Kind: Template, Parts: parts})
+			i = end
+		case c == '/' && startsRegex(tokens):
+			end := readRegex(src, i)
+			tokens = append(tokens, Token{Kind: Regex, Value: src[i:end]})
+			i = end
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(src) && isIdentPart(src[j]) {
+				j++
+			}
+			tokens = append(tokens, Token{Kind: Ident, Value: src[i:j]})
+			i = j
+		case c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(src) && (isIdentPart(src[j]) || src[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, Token{Kind: Number, Value: src[i:j]})
+			i = j
+		case strings.HasPrefix(src[i:], "=>"):
+			tokens = append(tokens, Token{Kind: Punct, Value: "=>"})
+			i += 2
+		default:
+			tokens = append(tokens, Token{Kind: Punct, Value: string(c)})
+			i++
+		}
+	}
+	return tokens
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+// startsRegex reports whether a slash following the given tokens starts a
+// regular expression.
+func startsRegex(tokens []Token) bool {
+	if len(tokens) == 0 {
+		return true
+	}
+	prev := tokens[len(tokens)-1]
+	switch prev.Kind {
+	case Ident:
+		return regexKeywords[prev.Value]
+	case Punct:
+		return prev.Value != ")" && prev.Value != "]" && prev.Value != "}"
+	}
+	return false
+}
+
+// readString reads the string literal starting at i and returns its value
+// and the position after the closing quote.
+func readString(src string, i int) (string, int) {
+	quote := src[i]
+	var sb strings.Builder
+	for i++; i < len(src); i++ {
+		c := src[i]
+		if c == quote {
+			return sb.String(), i + 1
+		}
+		if c == '\\' && i+1 < len(src) {
+			i++
+			sb.WriteByte(unescape(src[i]))
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String(), i
+}
+
+func unescape(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	}
+	return c
+}
+
+// readTemplate reads the template literal starting at i and returns its parts
+// and the position after the closing backtick.
+func readTemplate(src string, i int) ([]TemplatePart, int) {
+	var parts []TemplatePart
+	var sb strings.Builder
+	for i++; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '`':
+			if sb.Len() > 0 {
+				parts = append(parts, TemplatePart{Text: sb.String()})
+			}
+			return parts, i + 1
+		case c == '\\' && i+1 < len(src):
+			i++
+			sb.WriteByte(unescape(src[i]))
+		case strings.HasPrefix(src[i:], "${"):
+			if sb.Len() > 0 {
+				parts = append(parts, TemplatePart{Text: sb.String()})
+				sb.Reset()
+			}
+			end := readExpression(src, i+2)
+			parts = append(parts, TemplatePart{Text: strings.TrimSpace(src[i+2 : end]), IsExpr: true})
+			i = end
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	if sb.Len() > 0 {
+		parts = append(parts, TemplatePart{Text: sb.String()})
+	}
+	return parts, i
+}
+
+// readExpression returns the position of the brace that closes the template
+// expression starting at i.
+func readExpression(src string, i int) int {
+	depth := 0
+	for ; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		case '\'', '"':
+			_, end := readString(src, i)
+			i = end - 1
+		case '`':
+			_, end := readTemplate(src, i)
+			i = end - 1
+		}
+	}
+	return i
+}
+
+// readRegex returns the position after the regular expression literal
+// starting at i, including its flags.
+func readRegex(src string, i int) int {
+	inClass := false
+	for i++; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '\\':
+			i++
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case c == '\n':
+			return i
+		case c == '/' && !inClass:
+			i++
+			for i < len(src) && isIdentPart(src[i]) {
+				i++
+			}
+			return i
+		}
+	}
+	return i
+}
+
+// MatchBrackets returns the index of the closing bracket for every opening
+// bracket.
+func MatchBrackets(tokens []Token) map[int]int {
+	closing := map[int]int{}
+	pairs := map[string]string{")": "(", "]": "[", "}": "{"}
+	var stack []int
+	for i, t := range tokens {
+		if t.Kind != Punct {
+			continue
+		}
+		switch t.Value {
+		case "(", "[", "{":
+			stack = append(stack, i)
+		case ")", "]", "}":
+			// Unbalanced brackets are skipped, e.g. a type parameter like
+			// Array<{ a: string }> doesn't affect brackets.
+			for j := len(stack) - 1; j >= 0; j-- {
+				if tokens[stack[j]].Value == pairs[t.Value] {
+					closing[stack[j]] = i
+					stack = stack[:j]
+					break
+				}
+			}
+		}
+	}
+	return closing
+}
@@ -0,0 +1,47 @@
+package js
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	input := "// comment\nconst re = /[)/]+/g; /* ( */ a = b / 2;\n" +
+		"test(`a ${x + `b`} c`, 'it\\'s', () => {})"
+
+	want := []Token{
+		{Kind: Ident, Value: "const"},
+		{Kind: Ident, Value: "re"},
+		{Kind: Punct, Value: "="},
+		{Kind: Regex, Value: "/[)/]+/g"},
+		{Kind: Punct, Value: ";"},
+		{Kind: Ident, Value: "a"},
+		{Kind: Punct, Value: "="},
+		{Kind: Ident, Value: "b"},
+		{Kind: Punct, Value: "/"},
+		{Kind: Number, Value: "2"},
+		{Kind: Punct, Value: ";"},
+		{Kind: Ident, Value: "test"},
+		{Kind: Punct, Value: "("},
+		{Kind: Template, Parts: []TemplatePart{{Text: "a "}, {Text: "x + `b`", IsExpr: true}, {Text: " c"}}},
+		{Kind: Punct, Value: ","},
+		{Kind: String, Value: "it's"},
+		{Kind: Punct, Value: ","},
+		{Kind: Punct, Value: "("},
+		{Kind: Punct, Value: ")"},
+		{Kind: Punct, Value: "=>"},
+		{Kind: Punct, Value: "{"},
+		{Kind: Punct, Value: "}"},
+		{Kind: Punct, Value: ")"},
+	}
+
+	got := Tokenize(input)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize() = %+v, want %+v", got, want)
+	}
+
+	closing := MatchBrackets(got)
+	if closing[12] != 22 || closing[17] != 18 || closing[20] != 21 {
+		t.Errorf("MatchBrackets() = %v", closing)
+	}
+}
@@ -31,6 +31,7 @@ type TestCase struct {
 	// Error, Failure or Skipped or in addition to them.
 	Status     string      `xml:"status,attr,omitempty"`
 	File       string      `xml:"file,attr,omitempty"`
+	Line       int         `xml:"line,attr,omitempty"`
 	SystemErr  string      `xml:"system-err,omitempty"`
 	SystemOut  string      `xml:"system-out,omitempty"`
 	Error      *Error      `xml:"error,omitempty"`
@@ -57,6 +58,17 @@ func (tc TestCase) IsSkipped() bool {
 	return tc.Skipped != nil || tc.Status == "skipped"
 }
 
+// FailureMessage returns the message of the failure or error, if any.
+func (tc TestCase) FailureMessage() string {
+	if tc.Failure != nil && tc.Failure.Message != "" {
+		return tc.Failure.Message
+	}
+	if tc.Error != nil && tc.Error.Message != "" {
+		return tc.Error.Message
+	}
+	return ""
+}
+
 // Failure maps to either a <failure> or <error> element. It usually indicates
 // assertion failures. Depending on the framework, this may also indicate an
 // unexpected error, much like Error does. Some frameworks use Error or the
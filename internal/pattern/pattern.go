@@ -0,0 +1,39 @@
+// Package pattern provides helpers to build regular expressions for test
+// filters.
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ExactMatch returns a regular expression that matches any of the given names
+// exactly.
+func ExactMatch(names []string) string {
+	var quoted []string
+	for _, n := range names {
+		quoted = append(quoted, regexp.QuoteMeta(n))
+	}
+	return fmt.Sprintf("^(%s)$", strings.Join(quoted, "|"))
+}
+
+// Matching returns the names that match the regular expression expr. All names
+// are returned if expr is empty or invalid.
+func Matching(names []string, expr string) []string {
+	if expr == "" {
+		return names
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return names
+	}
+
+	var matched []string
+	for _, n := range names {
+		if re.MatchString(n) {
+			matched = append(matched, n)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,18 @@
+package pattern
+
+import (
+	"fmt"
+)
+
+func ExampleExactMatch() {
+	fmt.Println(ExactMatch([]string{"Login", "Add item (2)"}))
+	// Output: ^(Login|Add item \(2\))$
+}
+
+func ExampleMatching() {
+	fmt.Println(Matching([]string{"Login", "Logout", "Add item"}, "^Log"))
+	fmt.Println(Matching([]string{"Login", "Logout"}, ""))
+	// Output:
+	// [Login Logout]
+	// [Login Logout]
+}
@@ -0,0 +1,437 @@
+package code
+
+import (
+	"strings"
+
+	"github.com/saucelabs/saucectl/internal/js"
+)
+
+// Test describes a playwright test along with the describe blocks it is
+// nested in.
+type Test struct {
+	// TitlePath is the list of titles of the describe blocks the test is
+	// nested in, followed by the title of the test.
+	TitlePath []string
+	// Tags is the list of tags of the test, including the ones inherited from
+	// its describe blocks, e.g. test('title', { tag: '@fast' }, ...).
+	Tags []string
+	// Dynamic is true if the title couldn't be determined statically, e.g.
+	// because it's built from a variable.
+	Dynamic bool
+	// Skipped is true if the test or any of its describe blocks is skipped.
+	Skipped bool
+}
+
+// Title returns the title of the test as playwright matches it against grep
+// expressions, i.e. the title path followed by the tags.
+func (t Test) Title() string {
+	var parts []string
+	for _, p := range append(append([]string{}, t.TitlePath...), t.Tags...) {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Modifiers of test and test.describe calls.
+var (
+	testModifiers     = map[string]bool{"only": true, "skip": true, "fixme": true, "fail": true, "slow": true}
+	describeModifiers = map[string]bool{"only": true, "skip": true, "fixme": true, "serial": true, "parallel": true}
+)
+
+// title represents the possible values of a title. Titles of parametrized
+// tests have a value per parameter.
+type title struct {
+	values  []string
+	dynamic bool
+}
+
+// scope represents a describe block or a loop whose variable is known, which
+// ends at the token with the index end.
+type scope struct {
+	end      int
+	describe bool
+	title    title
+	tags     []string
+	skipped  bool
+	variable string
+	values   []string
+}
+
+// testParser finds the test declarations in the tokens of a spec file.
+type testParser struct {
+	tokens  []js.Token
+	closing map[int]int
+	// names are the identifiers that declare tests, which are "test" and any
+	// test objects derived via test.extend().
+	names map[string]bool
+	// arrays are the variables that are initialized with a list of strings.
+	arrays map[string][]string
+	scopes []scope
+	tests  []Test
+}
+
+// ParseTests takes the contents of a test file and parses the tests. Titles
+// are composed from the describe blocks like playwright does. Tests that are
+// declared in a loop over a list of strings, e.g.
+//
+//	for (const name of ['a', 'b']) {
+//	  test(`login ${name}`, async () => {})
+//	}
+//
+// are expanded into a test per value.
+func ParseTests(input string) []Test {
+	p := testParser{
+		tokens: js.Tokenize(input),
+		names:  map[string]bool{"test": true},
+		arrays: map[string][]string{},
+	}
+	p.closing = js.MatchBrackets(p.tokens)
+	p.findDeclarations()
+
+	for i := 0; i < len(p.tokens); i++ {
+		for len(p.scopes) > 0 && p.scopes[len(p.scopes)-1].end < i {
+			p.scopes = p.scopes[:len(p.scopes)-1]
+		}
+		p.parseLoop(i)
+		p.parseCall(i)
+	}
+
+	return p.tests
+}
+
+func (p *testParser) is(i int, kind js.Kind, value string) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].Kind == kind && p.tokens[i].Value == value
+}
+
+func (p *testParser) end(i int) int {
+	if end, ok := p.closing[i]; ok {
+		return end
+	}
+	return len(p.tokens)
+}
+
+// findDeclarations finds the test objects that are derived from another test
+// object, e.g. "const test = base.extend({...})", and variables that are
+// initialized with a list of strings.
+func (p *testParser) findDeclarations() {
+	for i := 0; i+3 < len(p.tokens); i++ {
+		t := p.tokens[i]
+		if t.Kind != js.Ident || (t.Value != "const" && t.Value != "let" && t.Value != "var") {
+			continue
+		}
+		if p.tokens[i+1].Kind != js.Ident || !p.is(i+2, js.Punct, "=") {
+			continue
+		}
+		name := p.tokens[i+1].Value
+
+		if values, ok := p.stringList(i + 3); ok {
+			p.arrays[name] = values
+			continue
+		}
+		for j := i + 3; j+1 < len(p.tokens) && (p.tokens[j].Kind == js.Ident || p.is(j, js.Punct, ".")); j++ {
+			if p.is(j, js.Punct, ".") && p.is(j+1, js.Ident, "extend") {
+				p.names[name] = true
+				break
+			}
+		}
+	}
+}
+
+// stringList returns the values of the array literal at i, if it only
+// consists of strings.
+func (p *testParser) stringList(i int) ([]string, bool) {
+	if !p.is(i, js.Punct, "[") {
+		return nil, false
+	}
+	var values []string
+	end := p.end(i)
+	for j := i + 1; j < end; j++ {
+		switch {
+		case p.tokens[j].Kind == js.String:
+			values = append(values, p.tokens[j].Value)
+		case p.is(j, js.Punct, ","):
+		default:
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// listValues returns the values of an array literal or a variable that was
+// initialized with one, at i. It also returns the index of the last token of
+// the list.
+func (p *testParser) listValues(i int) ([]string, int, bool) {
+	if i < len(p.tokens) && p.tokens[i].Kind == js.Ident {
+		values, ok := p.arrays[p.tokens[i].Value]
+		return values, i, ok
+	}
+	values, ok := p.stringList(i)
+	return values, p.end(i), ok
+}
+
+// parseLoop adds a scope for loops over a list of strings at i, i.e.
+// "for (const x of [...])" and "[...].forEach((x) => ...)".
+func (p *testParser) parseLoop(i int) {
+	if p.is(i, js.Ident, "for") && p.is(i+1, js.Punct, "(") && i+4 < len(p.tokens) {
+		if p.tokens[i+3].Kind != js.Ident || !p.is(i+4, js.Ident, "of") {
+			return
+		}
+		values, last, ok := p.listValues(i + 5)
+		if !ok || !p.is(last+1, js.Punct, ")") {
+			return
+		}
+		end := p.statementEnd(last + 2)
+		p.scopes = append(p.scopes, scope{end: end, variable: p.tokens[i+3].Value, values: values})
+		return
+	}
+
+	values, last, ok := p.listValues(i)
+	if !ok || !p.is(last+1, js.Punct, ".") || !p.is(last+3, js.Punct, "(") {
+		return
+	}
+	if fn := p.tokens[last+2].Value; fn != "forEach" && fn != "map" {
+		return
+	}
+	callEnd := p.end(last + 3)
+	j := last + 4
+	if p.is(j, js.Ident, "async") {
+		j++
+	}
+	if p.is(j, js.Ident, "function") {
+		j++
+	}
+	if p.is(j, js.Punct, "(") {
+		j++
+	}
+	if j < len(p.tokens) && p.tokens[j].Kind == js.Ident {
+		p.scopes = append(p.scopes, scope{end: callEnd, variable: p.tokens[j].Value, values: values})
+	}
+}
+
+// statementEnd returns the index of the last token of the statement starting
+// at i.
+func (p *testParser) statementEnd(i int) int {
+	if p.is(i, js.Punct, "{") {
+		return p.end(i)
+	}
+	for j := i; j < len(p.tokens); j++ {
+		if p.is(j, js.Punct, ";") || p.is(j, js.Punct, "}") {
+			return j
+		}
+		if end, ok := p.closing[j]; ok {
+			j = end
+		}
+	}
+	return len(p.tokens)
+}
+
+// parseCall parses a test or test.describe call at i.
+func (p *testParser) parseCall(i int) {
+	t := p.tokens[i]
+	if t.Kind != js.Ident || !p.names[t.Value] || p.is(i-1, js.Punct, ".") {
+		return
+	}
+
+	var modifiers []string
+	j := i + 1
+	for p.is(j, js.Punct, ".") && j+1 < len(p.tokens) && p.tokens[j+1].Kind == js.Ident {
+		modifiers = append(modifiers, p.tokens[j+1].Value)
+		j += 2
+	}
+	if !p.is(j, js.Punct, "(") {
+		return
+	}
+
+	describe := len(modifiers) > 0 && modifiers[0] == "describe"
+	allowed := testModifiers
+	if describe {
+		modifiers = modifiers[1:]
+		allowed = describeModifiers
+	}
+	skipped := false
+	for _, m := range modifiers {
+		if !allowed[m] {
+			return
+		}
+		skipped = skipped || m == "skip" || m == "fixme"
+	}
+
+	args := p.arguments(j)
+	if len(args) < 2 && !describe {
+		return
+	}
+	ttl, ok := p.title(args[0])
+	if !ok && describe {
+		// Anonymous describe blocks only group tests.
+		ttl = title{values: []string{""}}
+	}
+	if !describe && (!ok || !p.isFunction(args[len(args)-1])) {
+		// E.g. test.skip(condition, 'reason'), which doesn't declare a test.
+		return
+	}
+	var tags []string
+	if len(args) > 2 {
+		tags = p.tags(args[1])
+	}
+
+	var inherited []string
+	titles := [][]string{{}}
+	dynamic := ttl.dynamic
+	for _, s := range p.scopes {
+		if !s.describe {
+			continue
+		}
+		inherited = append(inherited, s.tags...)
+		skipped = skipped || s.skipped
+		dynamic = dynamic || s.title.dynamic
+		titles = expand(titles, s.title.values)
+	}
+
+	if describe {
+		p.scopes = append(p.scopes, scope{
+			end:      p.end(j),
+			describe: true,
+			title:    ttl,
+			tags:     tags,
+			skipped:  skipped,
+		})
+		return
+	}
+
+	for _, path := range expand(titles, ttl.values) {
+		p.tests = append(p.tests, Test{
+			TitlePath: path,
+			Tags:      append(append([]string{}, inherited...), tags...),
+			Dynamic:   dynamic,
+			Skipped:   skipped,
+		})
+	}
+}
+
+// isFunction reports whether the argument is a function expression.
+func (p *testParser) isFunction(arg [2]int) bool {
+	if p.is(arg[0], js.Ident, "function") || p.is(arg[0], js.Ident, "async") {
+		return true
+	}
+	for j := arg[0]; j < arg[1]; j++ {
+		if p.is(j, js.Punct, "=>") {
+			return true
+		}
+		if e, ok := p.closing[j]; ok {
+			j = e
+		}
+	}
+	return false
+}
+
+// expand appends each value to each of the paths.
+func expand(paths [][]string, values []string) [][]string {
+	var expanded [][]string
+	for _, path := range paths {
+		for _, v := range values {
+			expanded = append(expanded, append(append([]string{}, path...), v))
+		}
+	}
+	return expanded
+}
+
+// arguments returns the token ranges of the arguments of the call whose
+// opening parenthesis is at i.
+func (p *testParser) arguments(i int) [][2]int {
+	var args [][2]int
+	end := p.end(i)
+	start := i + 1
+	for j := start; j < end; j++ {
+		if p.is(j, js.Punct, ",") {
+			args = append(args, [2]int{start, j})
+			start = j + 1
+			continue
+		}
+		if e, ok := p.closing[j]; ok {
+			j = e
+		}
+	}
+	if start < end {
+		args = append(args, [2]int{start, end})
+	}
+	return args
+}
+
+// title returns the title of the argument, which is a string, a template
+// literal or a concatenation of them.
+func (p *testParser) title(arg [2]int) (title, bool) {
+	t := title{values: []string{""}}
+	for j := arg[0]; j < arg[1]; j++ {
+		tok := p.tokens[j]
+		switch {
+		case tok.Kind == js.String:
+			t.values = appendToAll(t.values, []string{tok.Value})
+		case tok.Kind == js.Template:
+			for _, part := range tok.Parts {
+				if !part.IsExpr {
+					t.values = appendToAll(t.values, []string{part.Text})
+					continue
+				}
+				if values, ok := p.variable(part.Text); ok {
+					t.values = appendToAll(t.values, values)
+					continue
+				}
+				t.dynamic = true
+			}
+		case p.is(j, js.Punct, "+") && j > arg[0]:
+		case tok.Kind == js.Ident:
+			if values, ok := p.variable(tok.Value); ok {
+				t.values = appendToAll(t.values, values)
+				continue
+			}
+			t.dynamic = true
+		default:
+			return title{}, false
+		}
+	}
+	return t, true
+}
+
+// variable returns the values of a loop variable that is in scope.
+func (p *testParser) variable(name string) ([]string, bool) {
+	for k := len(p.scopes) - 1; k >= 0; k-- {
+		if p.scopes[k].variable == name {
+			return p.scopes[k].values, true
+		}
+	}
+	return nil, false
+}
+
+func appendToAll(prefixes []string, values []string) []string {
+	var all []string
+	for _, prefix := range prefixes {
+		for _, v := range values {
+			all = append(all, prefix+v)
+		}
+	}
+	return all
+}
+
+// tags returns the tags of a details argument, e.g. { tag: ['@fast'] }.
+func (p *testParser) tags(arg [2]int) []string {
+	if !p.is(arg[0], js.Punct, "{") {
+		return nil
+	}
+	end := p.end(arg[0])
+	for j := arg[0] + 1; j+2 < end; j++ {
+		if !p.is(j, js.Ident, "tag") || !p.is(j+1, js.Punct, ":") {
+			if e, ok := p.closing[j]; ok {
+				j = e
+			}
+			continue
+		}
+		if p.tokens[j+2].Kind == js.String {
+			return []string{p.tokens[j+2].Value}
+		}
+		values, _ := p.stringList(j + 2)
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package code
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTests(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Test
+	}{
+		{
+			name: "nested describe blocks",
+			input: `
+import { test, expect } from '@playwright/test';
+
+test.describe('New Todo', { tag: '@todo' }, () => {
+  test.beforeEach(async ({ page }) => {
+    // test('commented out', async () => {});
+    await page.goto('https://demo.playwright.dev/todomvc?a=(');
+  });
+
+  test('should add items', async ({ page }) => {
+    await expect(page.getByTestId('todo-title')).toHaveText(/^(a|b)$/);
+  });
+
+  test.describe.serial("Editing", () => {
+    test('should edit items', { tag: ['@fast', '@edit'] }, async ({ page }) => {});
+  });
+});
+
+test.describe(() => {
+  test('runs in anonymous describe', async () => {});
+});
+`,
+			want: []Test{
+				{TitlePath: []string{"New Todo", "should add items"}, Tags: []string{"@todo"}},
+				{TitlePath: []string{"New Todo", "Editing", "should edit items"}, Tags: []string{"@todo", "@fast", "@edit"}},
+				{TitlePath: []string{"", "runs in anonymous describe"}, Tags: []string{}},
+			},
+		},
+		{
+			name: "skipped tests and test modifiers",
+			input: `
+test.skip('is skipped', async () => {});
+test.describe.fixme('Broken', () => {
+  test('is skipped too', async () => {});
+});
+test('skips conditionally', async ({ browserName }) => {
+  test.skip(browserName === 'webkit', 'not supported');
+  test.slow();
+});
+test.fail('is expected to fail', async () => {});
+`,
+			want: []Test{
+				{TitlePath: []string{"is skipped"}, Tags: []string{}, Skipped: true},
+				{TitlePath: []string{"Broken", "is skipped too"}, Tags: []string{}, Skipped: true},
+				{TitlePath: []string{"skips conditionally"}, Tags: []string{}},
+				{TitlePath: []string{"is expected to fail"}, Tags: []string{}},
+			},
+		},
+		{
+			name: "parametrized tests",
+			input: `
+const browsers = ['chrome', 'edge'];
+
+for (const name of ['Alice', 'Bob']) {
+  test(` + "`greets ${name}`" + `, async () => {});
+}
+
+browsers.forEach((browser) => {
+  test.describe('on ' + browser, () => {
+    test('works', async () => {});
+  });
+});
+
+for (const user of users) {
+  test(` + "`logs in ${user.name}`" + `, async () => {});
+}
+`,
+			want: []Test{
+				{TitlePath: []string{"greets Alice"}, Tags: []string{}},
+				{TitlePath: []string{"greets Bob"}, Tags: []string{}},
+				{TitlePath: []string{"on chrome", "works"}, Tags: []string{}},
+				{TitlePath: []string{"on edge", "works"}, Tags: []string{}},
+				{TitlePath: []string{"logs in "}, Tags: []string{}, Dynamic: true},
+			},
+		},
+		{
+			name: "extended test object",
+			input: `
+export const it = base.extend<{ todoPage: TodoPage }>({
+  todoPage: async ({ page }, use) => {
+    await use(new TodoPage(page));
+  },
+});
+
+it('uses fixtures', async ({ todoPage }) => {});
+`,
+			want: []Test{
+				{TitlePath: []string{"uses fixtures"}, Tags: []string{}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseTests(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTests() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTest_Title(t *testing.T) {
+	test := Test{TitlePath: []string{"", "New Todo", "should add items"}, Tags: []string{"@fast"}}
+	if got, want := test.Title(), "New Todo should add items @fast"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+}
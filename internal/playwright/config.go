@@ -61,6 +61,9 @@ type Project struct {
 type Playwright struct {
 	Version    string `yaml:"version,omitempty" json:"version,omitempty"`
 	ConfigFile string `yaml:"configFile,omitempty" json:"configFile,omitempty"`
+	// ConfigExport is the path to a JSON export of the playwright config,
+	// which is used to create a suite per playwright project.
+	ConfigExport string `yaml:"configExport,omitempty" json:"-"`
 }
 
 // Suite represents the playwright test suite configuration.
@@ -80,9 +83,21 @@ type Suite struct {
 	PreExec           []string          `yaml:"preExec,omitempty" json:"preExec"`
 	TimeZone          string            `yaml:"timeZone,omitempty" json:"timeZone"`
 	PassThreshold     int               `yaml:"passThreshold,omitempty" json:"-"`
+	Priority          int               `yaml:"priority,omitempty" json:"-"`
+	DependsOn         []string          `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry        config.SmartRetry `yaml:"smartRetry,omitempty" json:"-"`
 	ShardGrepEnabled  bool              `yaml:"shardGrepEnabled,omitempty" json:"-"`
 	ARMRequired       bool              `yaml:"armRequired,omitempty" json:"armRequired"`
+	SplitProjects     bool              `yaml:"splitProjects,omitempty" json:"-"`
+	// ProjectPlatforms maps playwright project names to the platform they run on.
+	ProjectPlatforms map[string]string `yaml:"projectPlatforms,omitempty" json:"-"`
+	// ProjectFiles is set by saucectl (not user) for suites created per project.
+	ProjectFiles ProjectFiles `yaml:"-" json:"-"`
+}
+
+// Dependencies returns the suites that the suite depends on.
+func (s Suite) Dependencies() config.SuiteDependencies {
+	return config.SuiteDependencies{Name: s.Name, DependsOn: s.DependsOn}
 }
 
 // SuiteConfig represents the configuration specific to a suite
@@ -227,6 +242,7 @@ func shardInSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile stri
 		if err != nil {
 			return []Suite{}, err
 		}
+		files = s.ProjectFiles.Filter(files)
 		if len(files) == 0 {
 			msg.SuiteSplitNoMatch(s.Name, rootDir, s.TestMatch)
 			return []Suite{}, fmt.Errorf("suite '%s' patterns have no matching files", s.Name)
@@ -308,6 +324,10 @@ func Validate(p *Project) error {
 		}
 	}
 
+	if err := splitProjects(p); err != nil {
+		return err
+	}
+
 	if err := checkSupportedBrowsers(p); err != nil {
 		return err
 	}
@@ -321,6 +341,18 @@ func Validate(p *Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := config.ValidateRetryPolicy(p.Sauce.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := config.ValidateFailFast(p.Sauce.FailFast); err != nil {
+		return err
+	}
+
+	if err := config.ValidateSuiteDependencies(p.Suites); err != nil {
+		return err
+	}
+
 	err := config.ValidateRegistries(p.Npm.Registries)
 	if err != nil {
 		return err
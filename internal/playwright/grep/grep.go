@@ -10,39 +10,34 @@ import (
 	"github.com/saucelabs/saucectl/internal/playwright/code"
 )
 
-// MatchFiles finds the files whose contents match the grep expression in the title parameter
+// MatchFiles finds the files that contain at least one test that playwright runs
+// for the grep and grepInvert expressions. Like in playwright, the expressions
+// are matched against the file name, the titles of the describe blocks, the
+// test title and the tags of each test.
 func MatchFiles(sys fs.FS, files []string, grep string, grepInvert string) (matched []string, unmatched []string) {
 	grepRE, grepInvertRE := compileRE(grep, grepInvert)
 
 	for _, f := range files {
-		if match(f, grepRE, grepInvertRE) {
-			matched = append(matched, f)
-			continue
-		}
-
-		// When there is a value in grepInvert, if filename matches the pattern, spec file will be skipped.
-		if grepInvertRE != nil {
-			unmatched = append(unmatched, f)
-			continue
-		}
-
 		b, err := fs.ReadFile(sys, f)
 		if err != nil {
 			continue
 		}
 
-		testcases := code.Parse(string(b))
-
 		include := false
-		for _, tc := range testcases {
-			include = include || match(tc.Title, grepRE, grepInvertRE)
-			if include {
-				// As long as one testcase matched, we know the spec will need to be executed
-				matched = append(matched, f)
+		for _, t := range code.ParseTests(string(b)) {
+			if t.Skipped {
+				continue
+			}
+			// Tests with dynamic titles may match, so the spec is kept.
+			if t.Dynamic || match(f+" "+t.Title(), grepRE, grepInvertRE) {
+				// As long as one test matched, we know the spec will need to be executed
+				include = true
 				break
 			}
 		}
-		if !include {
+		if include {
+			matched = append(matched, f)
+		} else {
 			unmatched = append(unmatched, f)
 		}
 	}
@@ -123,3 +123,69 @@ test.describe('New Step', () => {
 		})
 	}
 }
+
+func TestMatchFiles_Parsed(t *testing.T) {
+	mockFS := fstest.MapFS{
+		"login.spec.ts": {
+			Data: []byte(`
+test.describe('Login', { tag: '@auth' }, () => {
+  for (const user of ['admin', 'guest']) {
+    test(` + "`as ${user}`" + `, async ({ page }) => {});
+  }
+});
+`),
+		},
+		"users.spec.ts": {
+			Data: []byte(`
+for (const user of users) {
+  test(` + "`profile of ${user.name}`" + `, async ({ page }) => {});
+}
+`),
+		},
+		"skipped.spec.ts": {
+			Data: []byte(`
+test.skip('Login as admin is skipped', async ({ page }) => {});
+`),
+		},
+	}
+	files := []string{"login.spec.ts", "skipped.spec.ts", "users.spec.ts"}
+
+	testCases := []struct {
+		name          string
+		grep          string
+		grepInvert    string
+		wantMatched   []string
+		wantUnmatched []string
+	}{
+		{
+			name:          "describe path and parametrized title",
+			grep:          "Login as admin",
+			wantMatched:   []string{"login.spec.ts", "users.spec.ts"},
+			wantUnmatched: []string{"skipped.spec.ts"},
+		},
+		{
+			name:          "inherited tag",
+			grep:          "@auth",
+			wantMatched:   []string{"login.spec.ts", "users.spec.ts"},
+			wantUnmatched: []string{"skipped.spec.ts"},
+		},
+		{
+			name:          "grepInvert",
+			grepInvert:    "admin|guest",
+			wantMatched:   []string{"users.spec.ts"},
+			wantUnmatched: []string{"login.spec.ts", "skipped.spec.ts"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, unmatched := MatchFiles(mockFS, files, tt.grep, tt.grepInvert)
+			if diff := cmp.Diff(matched, tt.wantMatched); diff != "" {
+				t.Errorf("MatchFiles: difference in matched: %s", diff)
+			}
+			if diff := cmp.Diff(unmatched, tt.wantUnmatched); diff != "" {
+				t.Errorf("MatchFiles: difference in unmatched: %s", diff)
+			}
+		})
+	}
+}
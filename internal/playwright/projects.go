@@ -0,0 +1,230 @@
+package playwright
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// ProjectFiles represents the test files of a playwright project. It narrows
+// down the files that are sharded for a suite that was created for a project.
+type ProjectFiles struct {
+	// TestDir is the directory of the project tests, relative to rootDir.
+	TestDir    string
+	TestMatch  []string
+	TestIgnore []string
+}
+
+// configExport represents the parts of a JSON export of the playwright config
+// that are relevant for creating a suite per project. Both the config itself
+// and the output of the JSON reporter (which nests the config) are supported.
+type configExport struct {
+	TestDir  string            `json:"testDir"`
+	Projects []exportedProject `json:"projects"`
+	Config   *configExport     `json:"config"`
+}
+
+type exportedProject struct {
+	Name       string         `json:"name"`
+	TestDir    string         `json:"testDir"`
+	TestMatch  globPatterns   `json:"testMatch"`
+	TestIgnore globPatterns   `json:"testIgnore"`
+	Use        exportedDevice `json:"use"`
+}
+
+type exportedDevice struct {
+	BrowserName        string `json:"browserName"`
+	DefaultBrowserType string `json:"defaultBrowserType"`
+	Channel            string `json:"channel"`
+}
+
+// globPatterns represents playwright file patterns, which are either a single
+// pattern or a list of patterns. Regular expressions can't be exported to JSON
+// and are therefore skipped.
+type globPatterns []string
+
+func (g *globPatterns) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			*g = append(*g, s)
+			continue
+		}
+		log.Warn().Msgf("Skipping non-glob file pattern %s of the playwright config export. Regular expressions can't be exported to JSON.", b)
+	}
+	return nil
+}
+
+func readConfigExport(name string) (configExport, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return configExport{}, fmt.Errorf("failed to read playwright config export: %w", err)
+	}
+
+	var c configExport
+	if err := json.Unmarshal(b, &c); err != nil {
+		return configExport{}, fmt.Errorf("invalid playwright config export %q: %w", name, err)
+	}
+	if c.Config != nil {
+		c = *c.Config
+	}
+	return c, nil
+}
+
+// splitProjects replaces every suite that has SplitProjects enabled with one
+// suite per project of the playwright config export.
+func splitProjects(p *Project) error {
+	var export *configExport
+	var suites []Suite
+	for _, s := range p.Suites {
+		if !s.SplitProjects {
+			suites = append(suites, s)
+			continue
+		}
+		if p.Playwright.ConfigExport == "" {
+			return fmt.Errorf("suite '%s' splits projects, but no playwright configExport is set", s.Name)
+		}
+		if export == nil {
+			c, err := readConfigExport(p.Playwright.ConfigExport)
+			if err != nil {
+				return err
+			}
+			export = &c
+		}
+
+		var found bool
+		for _, pr := range export.Projects {
+			if s.Params.Project != "" && s.Params.Project != pr.Name {
+				continue
+			}
+			found = true
+
+			replica := s
+			replica.Name = fmt.Sprintf("%s - %s", s.Name, pr.Name)
+			replica.Params.Project = pr.Name
+			replica.Params.BrowserName = projectBrowser(pr, s.Params.BrowserName)
+			if platform, ok := s.ProjectPlatforms[pr.Name]; ok {
+				replica.PlatformName = platform
+			}
+
+			testDir := pr.TestDir
+			if testDir == "" {
+				testDir = export.TestDir
+			}
+			dir, err := relativeTestDir(p.RootDir, testDir)
+			if err != nil {
+				return fmt.Errorf("invalid testDir of playwright project '%s': %w", pr.Name, err)
+			}
+			replica.ProjectFiles = ProjectFiles{
+				TestDir:    dir,
+				TestMatch:  pr.TestMatch,
+				TestIgnore: pr.TestIgnore,
+			}
+			suites = append(suites, replica)
+		}
+		if !found {
+			return fmt.Errorf("suite '%s' matches no playwright project", s.Name)
+		}
+	}
+	p.Suites = suites
+
+	return nil
+}
+
+// projectBrowser maps the browser of a playwright project to a supported
+// browser. Projects without browser settings fall back to the browser of the
+// suite, and eventually to chromium, which is the default of playwright.
+func projectBrowser(pr exportedProject, fallback string) string {
+	if pr.Use.Channel != "" {
+		if strings.HasPrefix(pr.Use.Channel, "chrome") {
+			return "chrome"
+		}
+		return pr.Use.Channel
+	}
+	if pr.Use.BrowserName != "" {
+		return pr.Use.BrowserName
+	}
+	if pr.Use.DefaultBrowserType != "" {
+		return pr.Use.DefaultBrowserType
+	}
+	if isSupportedBrowser(pr.Name) {
+		return pr.Name
+	}
+	if fallback != "" {
+		return fallback
+	}
+	return "chromium"
+}
+
+// relativeTestDir returns the test directory relative to rootDir.
+func relativeTestDir(rootDir, testDir string) (string, error) {
+	if testDir == "" {
+		return "", nil
+	}
+	if filepath.IsAbs(testDir) {
+		root, err := filepath.Abs(rootDir)
+		if err != nil {
+			return "", err
+		}
+		if testDir, err = filepath.Rel(root, testDir); err != nil {
+			return "", err
+		}
+	}
+	dir := filepath.ToSlash(filepath.Clean(testDir))
+	if dir == "." {
+		return "", nil
+	}
+	if strings.HasPrefix(dir, "../") {
+		return "", fmt.Errorf("%q is outside of rootDir", testDir)
+	}
+	return dir, nil
+}
+
+// Filter returns the files, relative to rootDir, that belong to the project.
+// Like playwright, patterns are matched anywhere in the path.
+func (pf ProjectFiles) Filter(files []string) []string {
+	var filtered []string
+	for _, f := range files {
+		rel := f
+		if pf.TestDir != "" {
+			if !strings.HasPrefix(f, pf.TestDir+"/") {
+				continue
+			}
+			rel = strings.TrimPrefix(f, pf.TestDir+"/")
+		}
+		if len(pf.TestMatch) > 0 && !matchGlobs(pf.TestMatch, rel) {
+			continue
+		}
+		if matchGlobs(pf.TestIgnore, rel) {
+			continue
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
+
+func matchGlobs(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if !strings.HasPrefix(p, "**/") {
+			p = path.Join("**", p)
+		}
+		if ok, _ := doublestar.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package playwright
+
+import (
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+const playwrightConfigExport = `{
+  "testDir": "tests",
+  "projects": [
+    {
+      "name": "setup",
+      "testMatch": "*.setup.ts"
+    },
+    {
+      "name": "chromium",
+      "testIgnore": ["*.setup.ts", {}]
+    },
+    {
+      "name": "Mobile Safari",
+      "testDir": "mobile",
+      "use": {
+        "defaultBrowserType": "webkit",
+        "viewport": {"width": 390, "height": 664}
+      }
+    },
+    {
+      "name": "Google Chrome",
+      "use": {
+        "browserName": "chromium",
+        "channel": "chrome"
+      }
+    }
+  ]
+}`
+
+func TestSplitProjects(t *testing.T) {
+	dir := fs.NewDir(t, "playwright",
+		fs.WithFile("config.json", playwrightConfigExport),
+		fs.WithFile("report.json", `{"config": `+playwrightConfigExport+`, "suites": []}`),
+		fs.WithDir("tests",
+			fs.WithFile("auth.setup.ts", ""),
+			fs.WithFile("login.spec.ts", ""),
+			fs.WithFile("cart.spec.ts", ""),
+		),
+		fs.WithDir("mobile",
+			fs.WithFile("menu.spec.ts", ""),
+		),
+	)
+	defer dir.Remove()
+
+	suite := Suite{
+		Name:             "e2e",
+		PlatformName:     "Windows 11",
+		TestMatch:        []string{".*.ts"},
+		SplitProjects:    true,
+		ProjectPlatforms: map[string]string{"Mobile Safari": "macOS 13"},
+		Shard:            "spec",
+	}
+
+	for _, export := range []string{"config.json", "report.json"} {
+		t.Run(export, func(t *testing.T) {
+			p := &Project{
+				Playwright: Playwright{ConfigExport: dir.Join(export)},
+				RootDir:    dir.Path(),
+				Sauce:      config.SauceConfig{Concurrency: 2},
+				Suites:     []Suite{suite},
+			}
+			err := splitProjects(p)
+			assert.NoError(t, err)
+
+			var got []string
+			for _, s := range p.Suites {
+				got = append(got, s.Name+" "+s.Params.Project+" "+s.Params.BrowserName+" "+s.PlatformName)
+			}
+			assert.Equal(t, []string{
+				"e2e - setup setup chromium Windows 11",
+				"e2e - chromium chromium chromium Windows 11",
+				"e2e - Mobile Safari Mobile Safari webkit macOS 13",
+				"e2e - Google Chrome Google Chrome chrome Windows 11",
+			}, got)
+
+			p.Suites, err = shardInSuites(p.RootDir, p.Suites, p.Sauce.Concurrency, p.Sauce.Sauceignore)
+			assert.NoError(t, err)
+
+			got = nil
+			for _, s := range p.Suites {
+				got = append(got, s.Name)
+			}
+			assert.Equal(t, []string{
+				"e2e - setup - tests/auth.setup.ts",
+				"e2e - chromium - tests/cart.spec.ts",
+				"e2e - chromium - tests/login.spec.ts",
+				"e2e - Mobile Safari - mobile/menu.spec.ts",
+				"e2e - Google Chrome - tests/auth.setup.ts",
+				"e2e - Google Chrome - tests/cart.spec.ts",
+				"e2e - Google Chrome - tests/login.spec.ts",
+			}, got)
+		})
+	}
+}
+
+func TestSplitProjects_Errors(t *testing.T) {
+	dir := fs.NewDir(t, "playwright",
+		fs.WithFile("config.json", playwrightConfigExport),
+	)
+	defer dir.Remove()
+
+	testCases := []struct {
+		name    string
+		export  string
+		suite   Suite
+		wantErr string
+	}{
+		{
+			name:    "missing config export",
+			suite:   Suite{Name: "e2e", SplitProjects: true},
+			wantErr: "suite 'e2e' splits projects, but no playwright configExport is set",
+		},
+		{
+			name:    "unknown project",
+			export:  dir.Join("config.json"),
+			suite:   Suite{Name: "e2e", SplitProjects: true, Params: SuiteConfig{Project: "firefox"}},
+			wantErr: "suite 'e2e' matches no playwright project",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Project{
+				Playwright: Playwright{ConfigExport: tc.export},
+				RootDir:    dir.Path(),
+				Suites:     []Suite{tc.suite},
+			}
+			assert.EqualError(t, splitProjects(p), tc.wantErr)
+		})
+	}
+}
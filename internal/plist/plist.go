@@ -0,0 +1,138 @@
+// Package plist provides a minimal decoder for XML property lists.
+package plist
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Decode decodes an XML property list. Dictionaries are returned as
+// map[string]interface{}, arrays as []interface{}, integers as int64, reals
+// as float64, booleans as bool and any other value as string.
+func Decode(r io.Reader) (interface{}, error) {
+	d := xml.NewDecoder(r)
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil, errors.New("missing plist element")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local != "plist" {
+			return nil, fmt.Errorf("unexpected element <%s>, expected <plist>", start.Name.Local)
+		}
+
+		return decodeNext(d)
+	}
+}
+
+// DecodeFile decodes the XML property list file at the given path.
+func DecodeFile(name string) (interface{}, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Decode(f)
+}
+
+// decodeNext decodes the next value, skipping any whitespace and comments.
+func decodeNext(d *xml.Decoder) (interface{}, error) {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			return decodeValue(d, t)
+		case xml.EndElement:
+			return nil, errEndOfCollection
+		}
+	}
+}
+
+var errEndOfCollection = errors.New("end of collection")
+
+func decodeValue(d *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "dict":
+		return decodeDict(d)
+	case "array":
+		return decodeArray(d)
+	case "true", "false":
+		if err := d.Skip(); err != nil {
+			return nil, err
+		}
+		return start.Name.Local == "true", nil
+	}
+
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return nil, err
+	}
+
+	switch start.Name.Local {
+	case "integer":
+		return strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	case "real":
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	case "string", "key", "date", "data":
+		return text, nil
+	}
+
+	return nil, fmt.Errorf("unsupported element <%s>", start.Name.Local)
+}
+
+func decodeDict(d *xml.Decoder) (map[string]interface{}, error) {
+	dict := map[string]interface{}{}
+	for {
+		key, err := decodeNext(d)
+		if err == errEndOfCollection {
+			return dict, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		k, ok := key.(string)
+		if !ok {
+			return nil, errors.New("invalid dict key")
+		}
+
+		value, err := decodeNext(d)
+		if err == errEndOfCollection {
+			return nil, fmt.Errorf("missing value for key %q", k)
+		}
+		if err != nil {
+			return nil, err
+		}
+		dict[k] = value
+	}
+}
+
+func decodeArray(d *xml.Decoder) ([]interface{}, error) {
+	arr := []interface{}{}
+	for {
+		v, err := decodeNext(d)
+		if err == errEndOfCollection {
+			return arr, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+}
@@ -0,0 +1,93 @@
+package plist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	in := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<!-- comment -->
+	<key>Name</key>
+	<string>Default</string>
+	<key>Enabled</key>
+	<true/>
+	<key>Disabled</key>
+	<false/>
+	<key>Count</key>
+	<integer>42</integer>
+	<key>Ratio</key>
+	<real>0.5</real>
+	<key>Empty</key>
+	<array/>
+	<key>Targets</key>
+	<array>
+		<dict>
+			<key>BlueprintName</key>
+			<string>UITests</string>
+		</dict>
+		<string>&lt;escaped&gt;</string>
+	</array>
+</dict>
+</plist>`
+
+	got, err := Decode(strings.NewReader(in))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"Name":     "Default",
+		"Enabled":  true,
+		"Disabled": false,
+		"Count":    int64(42),
+		"Ratio":    0.5,
+		"Empty":    []interface{}{},
+		"Targets": []interface{}{
+			map[string]interface{}{"BlueprintName": "UITests"},
+			"<escaped>",
+		},
+	}, got)
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		in      string
+		wantErr string
+	}{
+		{
+			name:    "not a plist",
+			in:      `<html></html>`,
+			wantErr: "unexpected element <html>, expected <plist>",
+		},
+		{
+			name:    "empty",
+			in:      ``,
+			wantErr: "missing plist element",
+		},
+		{
+			name:    "missing value",
+			in:      `<plist><dict><key>Name</key></dict></plist>`,
+			wantErr: `missing value for key "Name"`,
+		},
+		{
+			name:    "invalid integer",
+			in:      `<plist><integer>abc</integer></plist>`,
+			wantErr: `strconv.ParseInt: parsing "abc": invalid syntax`,
+		},
+		{
+			name:    "unsupported element",
+			in:      `<plist><uid>1</uid></plist>`,
+			wantErr: "unsupported element <uid>",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(tc.in))
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -52,6 +52,13 @@ type Suite struct {
 
 	Recordings    []string `yaml:"recordings,omitempty" json:"-"`
 	PassThreshold int      `yaml:"passThreshold,omitempty" json:"-"`
+	Priority      int      `yaml:"priority,omitempty" json:"-"`
+	DependsOn     []string `yaml:"dependsOn,omitempty" json:"-"`
+}
+
+// Dependencies returns the suites that the suite depends on.
+func (s Suite) Dependencies() config.SuiteDependencies {
+	return config.SuiteDependencies{Name: s.Name, DependsOn: s.DependsOn}
 }
 
 // FromFile creates a new replay Project based on the filepath cfgPath.
@@ -119,6 +126,18 @@ func Validate(p *Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := config.ValidateRetryPolicy(p.Sauce.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := config.ValidateFailFast(p.Sauce.FailFast); err != nil {
+		return err
+	}
+
+	if err := config.ValidateSuiteDependencies(p.Suites); err != nil {
+		return err
+	}
+
 	if p.Sauce.LaunchOrder != "" && p.Sauce.LaunchOrder != config.LaunchOrderFailRate {
 		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
 	}
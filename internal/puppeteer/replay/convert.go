@@ -0,0 +1,261 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Script formats that recordings can be converted to.
+const (
+	FormatPlaywright = "playwright"
+	FormatPuppeteer  = "puppeteer"
+)
+
+// ScriptFormats are the supported script formats.
+var ScriptFormats = []string{FormatPlaywright, FormatPuppeteer}
+
+// defaultTimeout is the step timeout in milliseconds that chrome devtools uses
+// if the recording doesn't specify one.
+const defaultTimeout = 5000
+
+// ScriptExtension returns the file extension of scripts in the given format.
+func ScriptExtension(format string) string {
+	if format == FormatPlaywright {
+		return ".spec.ts"
+	}
+	return ".js"
+}
+
+// mainTarget is the target of steps that are performed on the main page.
+const mainTarget = "main"
+
+// Convert converts the recording to a script in the given format. Only valid
+// recordings whose steps are all performed on the main page, i.e. not within
+// popups or iframes, can be converted.
+func Convert(r Recording, format string) (string, error) {
+	if errs := r.Validate(); len(errs) > 0 {
+		return "", errs[0]
+	}
+
+	var c converter
+	switch format {
+	case FormatPlaywright:
+		c = playwrightConverter{}
+	case FormatPuppeteer:
+		c = puppeteerConverter{}
+	default:
+		return "", fmt.Errorf("unsupported script format %q, use one of: %s", format, strings.Join(ScriptFormats, ", "))
+	}
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	var b strings.Builder
+	b.WriteString(c.header(r.Title, timeout))
+	for i, s := range r.Steps {
+		if s.Target != "" && s.Target != mainTarget {
+			return "", fmt.Errorf("step %d (%s): target %q is not supported, only steps on the main page can be converted", i+1, s.Type, s.Target)
+		}
+		if len(s.Frame) > 0 {
+			return "", fmt.Errorf("step %d (%s): steps within iframes can't be converted", i+1, s.Type)
+		}
+		line, err := c.step(s)
+		if err != nil {
+			return "", fmt.Errorf("step %d (%s): %w", i+1, s.Type, err)
+		}
+		b.WriteString("  " + line + "\n")
+	}
+	b.WriteString(c.footer())
+
+	return b.String(), nil
+}
+
+type converter interface {
+	header(title string, timeout int) string
+	step(s Step) (string, error)
+	footer() string
+}
+
+// quote returns s as a javascript string literal.
+func quote(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// mouseButtons maps the mouse buttons of recordings to the ones of
+// playwright and puppeteer.
+var mouseButtons = map[string]string{
+	"primary":   "left",
+	"auxiliary": "middle",
+	"secondary": "right",
+	"back":      "back",
+	"forward":   "forward",
+}
+
+type playwrightConverter struct{}
+
+func (playwrightConverter) header(title string, timeout int) string {
+	return fmt.Sprintf("import { test } from '@playwright/test';\n\ntest(%s, async ({ page }) => {\n  page.setDefaultTimeout(%d);\n\n", quote(title), timeout)
+}
+
+func (playwrightConverter) footer() string {
+	return "});\n"
+}
+
+func (c playwrightConverter) step(s Step) (string, error) {
+	switch s.Type {
+	case StepSetViewport:
+		return fmt.Sprintf("await page.setViewportSize({ width: %d, height: %d });", s.Width, s.Height), nil
+	case StepNavigate:
+		return fmt.Sprintf("await page.goto(%s);", quote(s.URL)), nil
+	case StepClick, StepDoubleClick:
+		action := "click"
+		if s.Type == StepDoubleClick {
+			action = "dblclick"
+		}
+		opts := fmt.Sprintf("position: { x: %g, y: %g }", s.OffsetX, s.OffsetY)
+		if button, ok := mouseButtons[s.Button]; ok && button != "left" {
+			opts += fmt.Sprintf(", button: %s", quote(button))
+		}
+		return fmt.Sprintf("await %s.%s({ %s });", c.locator(s.Selectors), action, opts), nil
+	case StepHover:
+		return fmt.Sprintf("await %s.hover();", c.locator(s.Selectors)), nil
+	case StepChange:
+		return fmt.Sprintf("await %s.fill(%s);", c.locator(s.Selectors), quote(s.Value)), nil
+	case StepKeyDown:
+		return fmt.Sprintf("await page.keyboard.down(%s);", quote(s.Key)), nil
+	case StepKeyUp:
+		return fmt.Sprintf("await page.keyboard.up(%s);", quote(s.Key)), nil
+	case StepScroll:
+		if len(s.Selectors) > 0 {
+			return fmt.Sprintf("await %s.evaluate((el) => el.scrollTo(%g, %g));", c.locator(s.Selectors), s.X, s.Y), nil
+		}
+		return fmt.Sprintf("await page.evaluate(() => window.scrollTo(%g, %g));", s.X, s.Y), nil
+	case StepClose:
+		return "await page.close();", nil
+	case StepWaitForElement:
+		state := "visible"
+		if s.Visible != nil && !*s.Visible {
+			state = "hidden"
+		}
+		return fmt.Sprintf("await %s.waitFor({ state: %s });", c.locator(s.Selectors), quote(state)), nil
+	case StepWaitForExpression:
+		return fmt.Sprintf("await page.waitForFunction(%s);", quote(s.Expression)), nil
+	case StepEmulateNetworkConditions:
+		return "// Network conditions can't be emulated with playwright.", nil
+	}
+	return "", fmt.Errorf("unsupported step type %q", s.Type)
+}
+
+// ariaRole matches the role of an aria selector, e.g. aria/Submit[role="button"].
+var ariaRole = regexp.MustCompile(`^(.*)\[role="([^"]+)"\]$`)
+
+// locator returns a playwright locator that matches any of the selectors.
+func (playwrightConverter) locator(selectors []Selector) string {
+	var locators []string
+	for _, sel := range selectors {
+		loc := "page"
+		for _, part := range sel {
+			loc += fmt.Sprintf(".locator(%s)", quote(playwrightSelector(part)))
+		}
+		locators = append(locators, loc)
+	}
+	if len(locators) == 1 {
+		return locators[0] + ".first()"
+	}
+	return locators[0] + ".or(" + strings.Join(locators[1:], ").or(") + ").first()"
+}
+
+func playwrightSelector(sel string) string {
+	switch {
+	case strings.HasPrefix(sel, "aria/"):
+		name := strings.TrimPrefix(sel, "aria/")
+		if m := ariaRole.FindStringSubmatch(name); m != nil {
+			return fmt.Sprintf("role=%s[name=%s]", m[2], quote(m[1]))
+		}
+		return fmt.Sprintf("[aria-label=%s]", quote(name))
+	case strings.HasPrefix(sel, "xpath/"):
+		return "xpath=" + strings.TrimPrefix(sel, "xpath/")
+	case strings.HasPrefix(sel, "text/"):
+		return "text=" + strings.TrimPrefix(sel, "text/")
+	case strings.HasPrefix(sel, "pierce/"):
+		// CSS selectors of playwright pierce shadow roots by default.
+		return strings.TrimPrefix(sel, "pierce/")
+	}
+	return sel
+}
+
+type puppeteerConverter struct{}
+
+func (puppeteerConverter) header(title string, timeout int) string {
+	return fmt.Sprintf("const puppeteer = require('puppeteer');\n\n// %s\n(async () => {\n  const browser = await puppeteer.launch();\n  const page = await browser.newPage();\n  const timeout = %d;\n  page.setDefaultTimeout(timeout);\n\n", strings.ReplaceAll(title, "\n", " "), timeout)
+}
+
+func (puppeteerConverter) footer() string {
+	return "\n  await browser.close();\n})().catch((err) => {\n  console.error(err);\n  process.exit(1);\n});\n"
+}
+
+func (c puppeteerConverter) step(s Step) (string, error) {
+	switch s.Type {
+	case StepSetViewport:
+		return fmt.Sprintf("await page.setViewport({ width: %d, height: %d, deviceScaleFactor: %g, isMobile: %t, hasTouch: %t, isLandscape: %t });",
+			s.Width, s.Height, s.DeviceScaleFactor, s.IsMobile, s.HasTouch, s.IsLandscape), nil
+	case StepNavigate:
+		return fmt.Sprintf("await page.goto(%s);", quote(s.URL)), nil
+	case StepClick, StepDoubleClick:
+		opts := fmt.Sprintf("offset: { x: %g, y: %g }", s.OffsetX, s.OffsetY)
+		if s.Type == StepDoubleClick {
+			opts += ", count: 2"
+		}
+		if button, ok := mouseButtons[s.Button]; ok && button != "left" {
+			opts += fmt.Sprintf(", button: %s", quote(button))
+		}
+		return fmt.Sprintf("await %s.click({ %s });", c.locator(s.Selectors), opts), nil
+	case StepHover:
+		return fmt.Sprintf("await %s.hover();", c.locator(s.Selectors)), nil
+	case StepChange:
+		return fmt.Sprintf("await %s.fill(%s);", c.locator(s.Selectors), quote(s.Value)), nil
+	case StepKeyDown:
+		return fmt.Sprintf("await page.keyboard.down(%s);", quote(s.Key)), nil
+	case StepKeyUp:
+		return fmt.Sprintf("await page.keyboard.up(%s);", quote(s.Key)), nil
+	case StepScroll:
+		if len(s.Selectors) > 0 {
+			return fmt.Sprintf("await %s.scroll({ scrollLeft: %g, scrollTop: %g });", c.locator(s.Selectors), s.X, s.Y), nil
+		}
+		return fmt.Sprintf("await page.evaluate(() => window.scrollTo(%g, %g));", s.X, s.Y), nil
+	case StepClose:
+		return "await page.close();", nil
+	case StepWaitForElement:
+		loc := c.locator(s.Selectors)
+		if s.Visible != nil && !*s.Visible {
+			loc += ".setVisibility('hidden')"
+		}
+		return fmt.Sprintf("await %s.wait();", loc), nil
+	case StepWaitForExpression:
+		return fmt.Sprintf("await page.waitForFunction(%s);", quote(s.Expression)), nil
+	case StepEmulateNetworkConditions:
+		return fmt.Sprintf("await page.emulateNetworkConditions({ download: %g, upload: %g, latency: %g });", s.Download, s.Upload, s.Latency), nil
+	}
+	return "", fmt.Errorf("unsupported step type %q", s.Type)
+}
+
+// locator returns a puppeteer locator that matches any of the selectors.
+// Selector paths pierce through shadow roots.
+func (puppeteerConverter) locator(selectors []Selector) string {
+	var locators []string
+	for _, sel := range selectors {
+		locators = append(locators, fmt.Sprintf("page.locator(%s)", quote(strings.Join(sel, " >>> "))))
+	}
+	if len(locators) == 1 {
+		return locators[0] + ".setTimeout(timeout)"
+	}
+	return "puppeteer.Locator.race([" + strings.Join(locators, ", ") + "]).setTimeout(timeout)"
+}
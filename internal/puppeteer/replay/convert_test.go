@@ -0,0 +1,95 @@
+package replay
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestConvert(t *testing.T) {
+	r, err := ReadRecording(recordingFile)
+	assert.NilError(t, err)
+
+	testCases := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: FormatPlaywright,
+			want: `import { test } from '@playwright/test';
+
+test("Checkout", async ({ page }) => {
+  page.setDefaultTimeout(5000);
+
+  await page.setViewportSize({ width: 1280, height: 720 });
+  await page.goto("https://www.saucedemo.com/");
+  await page.locator("role=textbox[name=\"Username\"]").or(page.locator("[data-test='username']")).or(page.locator("#user-name")).first().click({ position: { x: 97, y: 23.5 } });
+  await page.locator("#user-name").or(page.locator("xpath=//*[@id=\"user-name\"]")).first().fill("standard_user");
+  await page.keyboard.down("Enter");
+  await page.keyboard.up("Enter");
+  await page.locator("#shadow-host").locator("button.checkout").first().waitFor({ state: "hidden" });
+});
+`,
+		},
+		{
+			format: FormatPuppeteer,
+			want: `const puppeteer = require('puppeteer');
+
+// Checkout
+(async () => {
+  const browser = await puppeteer.launch();
+  const page = await browser.newPage();
+  const timeout = 5000;
+  page.setDefaultTimeout(timeout);
+
+  await page.setViewport({ width: 1280, height: 720, deviceScaleFactor: 1, isMobile: false, hasTouch: false, isLandscape: false });
+  await page.goto("https://www.saucedemo.com/");
+  await puppeteer.Locator.race([page.locator("aria/Username[role=\"textbox\"]"), page.locator("[data-test='username']"), page.locator("pierce/#user-name")]).setTimeout(timeout).click({ offset: { x: 97, y: 23.5 } });
+  await puppeteer.Locator.race([page.locator("#user-name"), page.locator("xpath///*[@id=\"user-name\"]")]).setTimeout(timeout).fill("standard_user");
+  await page.keyboard.down("Enter");
+  await page.keyboard.up("Enter");
+  await page.locator("#shadow-host >>> button.checkout").setTimeout(timeout).setVisibility('hidden').wait();
+
+  await browser.close();
+})().catch((err) => {
+  console.error(err);
+  process.exit(1);
+});
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.format, func(t *testing.T) {
+			got, err := Convert(r, tc.format)
+			assert.NilError(t, err)
+			assert.Equal(t, got, tc.want)
+		})
+	}
+}
+
+func TestConvert_Errors(t *testing.T) {
+	r, err := ReadRecording(recordingFile)
+	assert.NilError(t, err)
+
+	_, err = Convert(r, "cypress")
+	assert.Error(t, err, `unsupported script format "cypress", use one of: playwright, puppeteer`)
+
+	_, err = Convert(Recording{Title: "t", Steps: []Step{{Type: StepCustomStep}}}, FormatPlaywright)
+	assert.Error(t, err, "step 1 (customStep): custom steps require a replay extension, which is not supported")
+
+	var popup Recording
+	assert.NilError(t, json.Unmarshal([]byte(`{"title": "t", "steps": [
+		{"type": "navigate", "url": "https://saucelabs.com", "target": "main"},
+		{"type": "hover", "selectors": ["#help"], "target": "https://saucelabs.com/help"}
+	]}`), &popup))
+	_, err = Convert(popup, FormatPuppeteer)
+	assert.Error(t, err, `step 2 (hover): target "https://saucelabs.com/help" is not supported, only steps on the main page can be converted`)
+
+	var iframe Recording
+	assert.NilError(t, json.Unmarshal([]byte(`{"title": "t", "steps": [
+		{"type": "hover", "selectors": ["#pay"], "target": "main", "frame": [0]}
+	]}`), &iframe))
+	_, err = Convert(iframe, FormatPlaywright)
+	assert.Error(t, err, "step 1 (hover): steps within iframes can't be converted")
+}
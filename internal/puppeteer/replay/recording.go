@@ -0,0 +1,302 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Step types of chrome devtools recordings.
+const (
+	StepSetViewport              = "setViewport"
+	StepNavigate                 = "navigate"
+	StepClick                    = "click"
+	StepDoubleClick              = "doubleClick"
+	StepHover                    = "hover"
+	StepChange                   = "change"
+	StepKeyDown                  = "keyDown"
+	StepKeyUp                    = "keyUp"
+	StepScroll                   = "scroll"
+	StepClose                    = "close"
+	StepWaitForElement           = "waitForElement"
+	StepWaitForExpression        = "waitForExpression"
+	StepEmulateNetworkConditions = "emulateNetworkConditions"
+	StepCustomStep               = "customStep"
+)
+
+// supportedSteps maps the step types that can be replayed on Sauce Labs to
+// their required properties.
+var supportedSteps = map[string][]string{
+	StepSetViewport:              {"width", "height", "deviceScaleFactor", "isMobile", "hasTouch", "isLandscape"},
+	StepNavigate:                 {"url"},
+	StepClick:                    {"selectors", "offsetX", "offsetY"},
+	StepDoubleClick:              {"selectors", "offsetX", "offsetY"},
+	StepHover:                    {"selectors"},
+	StepChange:                   {"selectors", "value"},
+	StepKeyDown:                  {"key"},
+	StepKeyUp:                    {"key"},
+	StepScroll:                   {},
+	StepClose:                    {},
+	StepWaitForElement:           {"selectors"},
+	StepWaitForExpression:        {"expression"},
+	StepEmulateNetworkConditions: {"download", "upload", "latency"},
+}
+
+// Recording represents a chrome devtools recording.
+type Recording struct {
+	Title             string `json:"title"`
+	Timeout           int    `json:"timeout,omitempty"`
+	SelectorAttribute string `json:"selectorAttribute,omitempty"`
+	Steps             []Step `json:"steps"`
+}
+
+// Step represents a single step of a chrome devtools recording. Only the
+// properties that are relevant for validation and conversion are parsed.
+type Step struct {
+	Type       string     `json:"type"`
+	Target     string     `json:"target,omitempty"`
+	Frame      []int      `json:"frame,omitempty"`
+	Selectors  []Selector `json:"selectors,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	Value      string     `json:"value,omitempty"`
+	Key        string     `json:"key,omitempty"`
+	Expression string     `json:"expression,omitempty"`
+	OffsetX    float64    `json:"offsetX,omitempty"`
+	OffsetY    float64    `json:"offsetY,omitempty"`
+	X          float64    `json:"x,omitempty"`
+	Y          float64    `json:"y,omitempty"`
+	Button     string     `json:"button,omitempty"`
+	Visible    *bool      `json:"visible,omitempty"`
+
+	// Viewport properties.
+	Width             int     `json:"width,omitempty"`
+	Height            int     `json:"height,omitempty"`
+	DeviceScaleFactor float64 `json:"deviceScaleFactor,omitempty"`
+	IsMobile          bool    `json:"isMobile,omitempty"`
+	HasTouch          bool    `json:"hasTouch,omitempty"`
+	IsLandscape       bool    `json:"isLandscape,omitempty"`
+
+	// Network condition properties.
+	Download float64 `json:"download,omitempty"`
+	Upload   float64 `json:"upload,omitempty"`
+	Latency  float64 `json:"latency,omitempty"`
+
+	// properties are the names of all properties of the step.
+	properties map[string]bool
+}
+
+// UnmarshalJSON unmarshals the step and keeps track of its properties, so that
+// missing required properties can be told apart from zero values.
+func (s *Step) UnmarshalJSON(b []byte) error {
+	var props map[string]json.RawMessage
+	if err := json.Unmarshal(b, &props); err != nil {
+		return err
+	}
+
+	type step Step
+	var v step
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*s = Step(v)
+
+	s.properties = map[string]bool{}
+	for k := range props {
+		s.properties[k] = true
+	}
+	return nil
+}
+
+// Selector represents a step selector. Chrome devtools records a list of
+// selectors per step. Each of them is either a single selector or a path of
+// selectors that pierce through shadow roots and iframes.
+type Selector []string
+
+func (s *Selector) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*s = Selector{single}
+		return nil
+	}
+
+	var path []string
+	if err := json.Unmarshal(b, &path); err != nil {
+		return fmt.Errorf("selector must be a string or a list of strings: %s", b)
+	}
+	*s = path
+	return nil
+}
+
+// ReadRecording reads the recording at the given path.
+func ReadRecording(name string) (Recording, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return Recording{}, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	var r Recording
+	if err := json.Unmarshal(b, &r); err != nil {
+		return Recording{}, fmt.Errorf("invalid recording %q: %w", name, err)
+	}
+
+	return r, nil
+}
+
+// Validate validates the recording and returns all errors that were found.
+func (r Recording) Validate() []error {
+	var errs []error
+	if r.Title == "" {
+		errs = append(errs, errors.New("missing title"))
+	}
+	if len(r.Steps) == 0 {
+		errs = append(errs, errors.New("recording has no steps"))
+	}
+
+	for i, s := range r.Steps {
+		if err := s.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("step %d (%s): %w", i+1, s.Type, err))
+		}
+	}
+
+	return errs
+}
+
+func (s Step) validate() error {
+	if s.Type == "" {
+		return errors.New("missing type")
+	}
+	if s.Type == StepCustomStep {
+		return errors.New("custom steps require a replay extension, which is not supported")
+	}
+	required, ok := supportedSteps[s.Type]
+	if !ok {
+		return fmt.Errorf("unsupported step type %q", s.Type)
+	}
+
+	for _, p := range required {
+		if !s.properties[p] {
+			return fmt.Errorf("missing required property %q", p)
+		}
+	}
+
+	for _, sel := range s.Selectors {
+		if len(sel) == 0 {
+			return errors.New("empty selector")
+		}
+		for _, part := range sel {
+			if err := validateSelector(part); err != nil {
+				return err
+			}
+		}
+	}
+	if s.properties["selectors"] && len(s.Selectors) == 0 {
+		return errors.New("no selectors")
+	}
+
+	return nil
+}
+
+// selectorTypes are the prefixes of selectors that aren't CSS selectors.
+var selectorTypes = []string{"aria/", "xpath/", "pierce/", "text/"}
+
+// validateSelector does a basic sanity check of the selector. CSS selectors are
+// checked for balanced brackets and quotes only.
+func validateSelector(sel string) error {
+	if strings.TrimSpace(sel) == "" {
+		return errors.New("empty selector")
+	}
+	for _, prefix := range selectorTypes {
+		if strings.HasPrefix(sel, prefix) {
+			if strings.TrimSpace(strings.TrimPrefix(sel, prefix)) == "" {
+				return fmt.Errorf("empty %s selector", strings.TrimSuffix(prefix, "/"))
+			}
+			if prefix != "pierce/" {
+				return nil
+			}
+			sel = strings.TrimPrefix(sel, prefix)
+		}
+	}
+
+	var stack []rune
+	var quote rune
+	escaped := false
+	for _, c := range sel {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '(':
+			stack = append(stack, c)
+		case c == ']' || c == ')':
+			open := '['
+			if c == ')' {
+				open = '('
+			}
+			if len(stack) == 0 || stack[len(stack)-1] != open {
+				return fmt.Errorf("invalid selector %q", sel)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if quote != 0 || len(stack) > 0 {
+		return fmt.Errorf("invalid selector %q", sel)
+	}
+	return nil
+}
+
+// ValidateRecordings validates the recordings of the suites and logs all
+// errors that were found. It returns an error if any recording is invalid.
+func ValidateRecordings(suites []Suite) error {
+	invalid := 0
+	for _, s := range suites {
+		r, err := ReadRecording(s.Recording)
+		if err != nil {
+			log.Error().Err(err).Str("suite", s.Name).Msg("Invalid recording.")
+			invalid++
+			continue
+		}
+
+		errs := r.Validate()
+		for _, err := range errs {
+			log.Error().Err(err).Str("suite", s.Name).Str("recording", s.Recording).Msg("Invalid recording.")
+		}
+		if len(errs) > 0 {
+			invalid++
+		}
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d recordings are invalid", invalid, len(suites))
+	}
+	return nil
+}
+
+// Summary returns the number of steps per step type, in the order in which
+// the step types first appear.
+func (r Recording) Summary() string {
+	var types []string
+	counts := map[string]int{}
+	for _, s := range r.Steps {
+		if counts[s.Type] == 0 {
+			types = append(types, s.Type)
+		}
+		counts[s.Type]++
+	}
+
+	var parts []string
+	for _, t := range types {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[t], t))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,116 @@
+package replay
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+	"gotest.tools/v3/fs"
+)
+
+// recordingFile is resolved before any test changes the working directory.
+var recordingFile, _ = filepath.Abs("testdata/recording.json")
+
+func TestReadRecording(t *testing.T) {
+	r, err := ReadRecording(recordingFile)
+	assert.NilError(t, err)
+
+	assert.Equal(t, r.Title, "Checkout")
+	assert.Equal(t, len(r.Steps), 7)
+	assert.DeepEqual(t, r.Steps[2].Selectors, []Selector{{`aria/Username[role="textbox"]`}, {"[data-test='username']"}, {"pierce/#user-name"}})
+	assert.DeepEqual(t, r.Steps[3].Selectors, []Selector{{"#user-name"}, {`xpath///*[@id="user-name"]`}})
+	assert.Equal(t, len(r.Validate()), 0)
+	assert.Equal(t, r.Summary(), "1 setViewport, 1 navigate, 1 click, 1 change, 1 keyDown, 1 keyUp, 1 waitForElement")
+}
+
+func TestRecording_Validate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		recording string
+		want      []string
+	}{
+		{
+			name:      "missing title and steps",
+			recording: `{"steps": []}`,
+			want:      []string{"missing title", "recording has no steps"},
+		},
+		{
+			name: "unsupported step types",
+			recording: `{"title": "t", "steps": [
+				{"type": "customStep", "name": "login", "parameters": {}},
+				{"type": "tap", "selectors": ["#button"]}
+			]}`,
+			want: []string{
+				"step 1 (customStep): custom steps require a replay extension, which is not supported",
+				`step 2 (tap): unsupported step type "tap"`,
+			},
+		},
+		{
+			name: "missing required properties",
+			recording: `{"title": "t", "steps": [
+				{"type": "navigate"},
+				{"type": "click", "selectors": ["#button"], "offsetX": 0},
+				{"type": "change", "selectors": [], "value": ""},
+				{"type": "keyDown", "key": "Enter"}
+			]}`,
+			want: []string{
+				`step 1 (navigate): missing required property "url"`,
+				`step 2 (click): missing required property "offsetY"`,
+				"step 3 (change): no selectors",
+			},
+		},
+		{
+			name: "invalid selectors",
+			recording: `{"title": "t", "steps": [
+				{"type": "hover", "selectors": ["aria/"]},
+				{"type": "hover", "selectors": [["#frame", "button[name='submit'"]]},
+				{"type": "hover", "selectors": ["pierce/div:not(.hidden"]},
+				{"type": "hover", "selectors": [[]]},
+				{"type": "hover", "selectors": ["xpath///div[@class=\"a]\"]", "text/Log in", "a[href=\"/(\"]"]}
+			]}`,
+			want: []string{
+				"step 1 (hover): empty aria selector",
+				`step 2 (hover): invalid selector "button[name='submit'"`,
+				`step 3 (hover): invalid selector "div:not(.hidden"`,
+				"step 4 (hover): empty selector",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var r Recording
+			assert.NilError(t, json.Unmarshal([]byte(tc.recording), &r))
+
+			var got []string
+			for _, err := range r.Validate() {
+				got = append(got, err.Error())
+			}
+			assert.DeepEqual(t, got, tc.want)
+		})
+	}
+}
+
+func TestValidateRecordings(t *testing.T) {
+	dir := fs.NewDir(t, "replay",
+		fs.WithFile("valid.json", `{"title": "t", "steps": [{"type": "navigate", "url": "https://saucelabs.com"}]}`),
+		fs.WithFile("invalid.json", `{"title": "t", "steps": [{"type": "navigate"}]}`),
+		fs.WithFile("malformed.json", `{"title": "t", "steps": [{"type": "click", "selectors": [1]}]}`),
+	)
+	defer dir.Remove()
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	assert.NilError(t, os.Chdir(dir.Path()))
+
+	assert.NilError(t, ValidateRecordings([]Suite{{Name: "valid", Recording: "valid.json"}}))
+
+	err := ValidateRecordings([]Suite{
+		{Name: "valid", Recording: "valid.json"},
+		{Name: "invalid", Recording: "invalid.json"},
+		{Name: "malformed", Recording: "malformed.json"},
+		{Name: "missing", Recording: "missing.json"},
+	})
+	assert.Error(t, err, "3 of 4 recordings are invalid")
+}
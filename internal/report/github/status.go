@@ -0,0 +1,306 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/ci"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/junit"
+	"github.com/saucelabs/saucectl/internal/report"
+)
+
+// maxAnnotations is the maximum number of annotations GitHub accepts per
+// check run request.
+const maxAnnotations = 50
+
+// StatusReporter reports the overall outcome of a run as a commit status or,
+// alternatively, as a check run that annotates failed tests.
+// https://docs.github.com/en/rest/commits/statuses
+// https://docs.github.com/en/rest/checks/runs
+type StatusReporter struct {
+	// Name is the context of the commit status, or the name of the check run.
+	Name     string
+	CheckRun bool
+
+	APIURL    string
+	Token     string
+	Repo      string
+	SHA       string
+	TargetURL string
+
+	HTTPClient *http.Client
+
+	results []report.TestResult
+	lock    sync.Mutex
+}
+
+// NewStatusReporter creates a StatusReporter for the current GitHub Actions
+// workflow run. The reporter is inactive if GITHUB_TOKEN is not set.
+func NewStatusReporter(name string, checkRun bool) StatusReporter {
+	c := ci.GetCI(ci.GitHub)
+
+	apiURL := os.Getenv("GITHUB_API_URL")
+	if apiURL == "" {
+		apiURL = "https://api.github.com"
+	}
+
+	sha := pullRequestHeadSHA(os.Getenv("GITHUB_EVENT_PATH"))
+	if sha == "" {
+		sha = c.SHA
+	}
+
+	return StatusReporter{
+		Name:       name,
+		CheckRun:   checkRun,
+		APIURL:     apiURL,
+		Token:      os.Getenv("GITHUB_TOKEN"),
+		Repo:       c.Repo,
+		SHA:        sha,
+		TargetURL:  c.URL,
+		HTTPClient: &http.Client{Timeout: 1 * time.Minute},
+	}
+}
+
+// pullRequestHeadSHA returns the head commit of the pull request that
+// triggered the workflow. GITHUB_SHA refers to the merge commit instead, which
+// isn't shown on the pull request.
+func pullRequestHeadSHA(eventPath string) string {
+	if eventPath == "" {
+		return ""
+	}
+
+	b, err := os.ReadFile(eventPath)
+	if err != nil {
+		return ""
+	}
+
+	var event struct {
+		PullRequest struct {
+			Head struct {
+				SHA string `json:"sha"`
+			} `json:"head"`
+		} `json:"pull_request"`
+	}
+	if err := json.Unmarshal(b, &event); err != nil {
+		return ""
+	}
+
+	return event.PullRequest.Head.SHA
+}
+
+func (r *StatusReporter) isActive() bool {
+	return r.Token != "" && r.Repo != "" && r.SHA != ""
+}
+
+// Add adds the test result to the report.
+func (r *StatusReporter) Add(t report.TestResult) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.results = append(r.results, t)
+}
+
+// Render posts the commit status or check run to GitHub.
+func (r *StatusReporter) Render() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.isActive() {
+		log.Warn().Msg("Unable to report to GitHub pull request. Make sure GITHUB_TOKEN is set.")
+		return
+	}
+
+	var err error
+	if r.CheckRun {
+		err = r.postCheckRun()
+	} else {
+		err = r.postStatus()
+	}
+	if err != nil {
+		log.Err(err).Msg("Failed to report to GitHub pull request.")
+	}
+}
+
+func (r *StatusReporter) postStatus() error {
+	state := "success"
+	if failed := countFailed(r.results); failed > 0 {
+		state = "failure"
+	}
+
+	return r.post(fmt.Sprintf("%s/repos/%s/statuses/%s", r.APIURL, r.Repo, r.SHA), map[string]string{
+		"state":       state,
+		"target_url":  r.TargetURL,
+		"description": describe(r.results),
+		"context":     r.Name,
+	})
+}
+
+type checkRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
+
+func (r *StatusReporter) postCheckRun() error {
+	conclusion := "success"
+	if countFailed(r.results) > 0 {
+		conclusion = "failure"
+	}
+
+	// Failures are annotated at their source location. Failures without a
+	// known location, or beyond the annotation limit, are listed in the summary.
+	annotations := []checkRunAnnotation{}
+	var unannotated []string
+	for _, t := range r.results {
+		for _, tc := range report.FailedTestCases(t) {
+			title := fmt.Sprintf("%s: %s", t.Name, tc.Name)
+			line := failureLine(tc)
+			if tc.File == "" || line == 0 || len(annotations) == maxAnnotations {
+				unannotated = append(unannotated, fmt.Sprintf("- **%s**: %s", title, failureMessage(tc)))
+				continue
+			}
+			annotations = append(annotations, checkRunAnnotation{
+				Path:            tc.File,
+				StartLine:       line,
+				EndLine:         line,
+				AnnotationLevel: "failure",
+				Title:           title,
+				Message:         failureMessage(tc),
+			})
+		}
+	}
+
+	summary := summarize(r.results)
+	if len(unannotated) > 0 {
+		summary += "\n### Failed tests\n\n" + strings.Join(unannotated, "\n") + "\n"
+	}
+
+	return r.post(fmt.Sprintf("%s/repos/%s/check-runs", r.APIURL, r.Repo), map[string]interface{}{
+		"name":        r.Name,
+		"head_sha":    r.SHA,
+		"status":      "completed",
+		"conclusion":  conclusion,
+		"details_url": r.TargetURL,
+		"output": map[string]interface{}{
+			"title":       describe(r.results),
+			"summary":     summary,
+			"annotations": annotations,
+		},
+	})
+}
+
+func (r *StatusReporter) post(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+r.Token)
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status '%d' from GitHub: %s", resp.StatusCode, string(b))
+	}
+
+	return nil
+}
+
+// Reset resets the reporter to its initial state. This action will delete all test results.
+func (r *StatusReporter) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.results = make([]report.TestResult, 0)
+}
+
+// ArtifactRequirements returns a list of artifact types this reporter requires to create a proper report.
+func (r *StatusReporter) ArtifactRequirements() []report.ArtifactType {
+	if r.CheckRun {
+		return []report.ArtifactType{report.JUnitArtifact}
+	}
+	return nil
+}
+
+func countFailed(results []report.TestResult) int {
+	failed := 0
+	for _, t := range results {
+		if t.Status == job.StateFailed || t.Status == job.StateError {
+			failed++
+		}
+	}
+	return failed
+}
+
+// describe returns a short description of the results. Commit status
+// descriptions are limited to 140 characters.
+func describe(results []report.TestResult) string {
+	if failed := countFailed(results); failed > 0 {
+		return fmt.Sprintf("%d of %d suites have failed", failed, len(results))
+	}
+	return fmt.Sprintf("All %d suites have passed", len(results))
+}
+
+func summarize(results []report.TestResult) string {
+	content := renderHeader(hasDevice(results), hasRetries(results))
+	for _, t := range results {
+		content += renderTestResult(t, hasDevice(results), hasRetries(results))
+	}
+	return content
+}
+
+// failureLine returns the line of the test file at which the test case failed,
+// or 0 if it's unknown. Unless the report provides the line, it's taken from the
+// first reference to the test file in the failure details, e.g. a stack trace.
+func failureLine(tc junit.TestCase) int {
+	if tc.Line > 0 {
+		return tc.Line
+	}
+	if tc.File == "" {
+		return 0
+	}
+
+	var details string
+	if tc.Failure != nil {
+		details = tc.Failure.Text
+	} else if tc.Error != nil {
+		details = tc.Error.Text
+	}
+
+	re := regexp.MustCompile(regexp.QuoteMeta(path.Base(tc.File)) + `:(\d+)`)
+	if m := re.FindStringSubmatch(details); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line
+	}
+	return 0
+}
+
+func failureMessage(tc junit.TestCase) string {
+	if msg := tc.FailureMessage(); msg != "" {
+		return msg
+	}
+	return "Test failed"
+}
@@ -0,0 +1,110 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/junit"
+	"github.com/saucelabs/saucectl/internal/report"
+)
+
+func TestStatusReporter_Render(t *testing.T) {
+	tests := []struct {
+		name     string
+		checkRun bool
+		wantPath string
+		verify   func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			name:     "commit status",
+			wantPath: "/repos/saucelabs/saucectl/statuses/abc123",
+			verify: func(t *testing.T, body map[string]interface{}) {
+				if body["state"] != "failure" {
+					t.Errorf("state got = %v, want failure", body["state"])
+				}
+				if body["context"] != "saucectl" {
+					t.Errorf("context got = %v, want saucectl", body["context"])
+				}
+			},
+		},
+		{
+			name:     "check run",
+			checkRun: true,
+			wantPath: "/repos/saucelabs/saucectl/check-runs",
+			verify: func(t *testing.T, body map[string]interface{}) {
+				if body["conclusion"] != "failure" {
+					t.Errorf("conclusion got = %v, want failure", body["conclusion"])
+				}
+				output := body["output"].(map[string]interface{})
+				annotations := output["annotations"].([]interface{})
+				if len(annotations) != 1 {
+					t.Fatalf("annotations got = %d, want 1", len(annotations))
+				}
+				a := annotations[0].(map[string]interface{})
+				if a["path"] != "tests/login.spec.js" || a["start_line"] != float64(12) || a["message"] != "expected true" {
+					t.Errorf("unexpected annotation: %v", a)
+				}
+				summary := output["summary"].(string)
+				for _, want := range []string{"**firefox: unknown line**: expected false", "**firefox: no file**: boom"} {
+					if !strings.Contains(summary, want) {
+						t.Errorf("summary %q does not list %q", summary, want)
+					}
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			var gotBody map[string]interface{}
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				if r.Header.Get("Authorization") != "Bearer token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_ = json.NewDecoder(r.Body).Decode(&gotBody)
+				w.WriteHeader(http.StatusCreated)
+			}))
+			defer ts.Close()
+
+			r := &StatusReporter{
+				Name:       "saucectl",
+				CheckRun:   tt.checkRun,
+				APIURL:     ts.URL,
+				Token:      "token",
+				Repo:       "saucelabs/saucectl",
+				SHA:        "abc123",
+				HTTPClient: ts.Client(),
+			}
+			r.Add(report.TestResult{Name: "chrome", Status: job.StatePassed})
+			r.Add(report.TestResult{
+				Name:   "firefox",
+				Status: job.StateFailed,
+				Attempts: []report.Attempt{{
+					TestSuites: junit.TestSuites{TestSuites: []junit.TestSuite{{
+						TestCases: []junit.TestCase{
+							{Name: "passes", File: "tests/login.spec.js"},
+							{Name: "fails", File: "tests/login.spec.js", Failure: &junit.Failure{
+								Message: "expected true",
+								Text:    "Error: expected true\n    at Context.<anonymous> (/home/runner/work/app/tests/login.spec.js:12:5)",
+							}},
+							{Name: "unknown line", File: "tests/login.spec.js", Failure: &junit.Failure{Message: "expected false"}},
+							{Name: "no file", Failure: &junit.Failure{Message: "boom"}},
+						},
+					}}},
+				}},
+			})
+			r.Render()
+
+			if gotPath != tt.wantPath {
+				t.Errorf("path got = %s, want %s", gotPath, tt.wantPath)
+			}
+			tt.verify(t, gotBody)
+		})
+	}
+}
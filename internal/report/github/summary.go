@@ -62,6 +62,8 @@ func (r *Reporter) Render() {
 	hasDevices := hasDevice(r.results)
 	showRetries := hasRetries(r.results)
 	errors := 0
+	cancelled := 0
+	skipped := 0
 	inProgress := 0
 
 	content := renderHeader(hasDevices, showRetries)
@@ -72,9 +74,15 @@ func (r *Reporter) Render() {
 		if result.Status == job.StateFailed || result.Status == job.StateError {
 			errors++
 		}
+		if result.Status == job.StateCancelled {
+			cancelled++
+		}
+		if result.Status == job.StateSkipped {
+			skipped++
+		}
 		content += renderTestResult(result, hasDevices, showRetries)
 	}
-	content += renderFooter(errors, inProgress, len(r.results), endTime.Sub(r.startTime))
+	content += renderFooter(errors, cancelled, skipped, inProgress, len(r.results), endTime.Sub(r.startTime))
 
 	err := os.WriteFile(r.stepSummaryFile, []byte(content), 0x644)
 	if err != nil {
@@ -120,6 +128,8 @@ func statusToEmoji(status string) string {
 		return ":interrobang:"
 	case job.StateError, job.StateFailed:
 		return ":x:"
+	case job.StateCancelled, job.StateSkipped:
+		return ":no_entry_sign:"
 	default:
 		return ":warning:"
 	}
@@ -143,10 +153,20 @@ func renderTestResult(t report.TestResult, hasDevices bool, showRetries bool) st
 	return content
 }
 
-func renderFooter(errors, inProgress, tests int, dur time.Duration) string {
+func renderFooter(errors, cancelled, skipped, inProgress, tests int, dur time.Duration) string {
 	if errors != 0 {
 		relative := float64(errors) / float64(tests) * 100
-		return fmt.Sprintf("\n:x: %d of %d suites have failed (%.0f%%) in %s\n\n", errors, tests, relative, dur.Truncate(1*time.Second))
+		msg := fmt.Sprintf("%d of %d suites have failed (%.0f%%)", errors, tests, relative)
+		if cancelled != 0 {
+			msg = fmt.Sprintf("%s, %d cancelled", msg, cancelled)
+		}
+		if skipped != 0 {
+			msg = fmt.Sprintf("%s, %d skipped", msg, skipped)
+		}
+		return fmt.Sprintf("\n:x: %s in %s\n\n", msg, dur.Truncate(1*time.Second))
+	}
+	if cancelled != 0 || skipped != 0 {
+		return fmt.Sprintf("\n:no_entry_sign: %d of %d suites did not run in %s\n\n", cancelled+skipped, tests, dur.Truncate(1*time.Second))
 	}
 	if inProgress != 0 {
 		return fmt.Sprintf("\n:clock10: All suites have launched in %s\n\n", dur.Truncate(1*time.Second))
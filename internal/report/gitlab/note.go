@@ -0,0 +1,174 @@
+package gitlab
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/ci"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/report"
+)
+
+// NoteReporter reports the test results as a note on the merge request that
+// triggered the pipeline.
+// https://docs.gitlab.com/ee/api/notes.html#create-new-merge-request-note
+type NoteReporter struct {
+	// Name is used as the title of the note.
+	Name string
+
+	APIURL       string
+	Token        string
+	Project      string
+	MergeRequest string
+	PipelineURL  string
+
+	HTTPClient *http.Client
+
+	results []report.TestResult
+	lock    sync.Mutex
+}
+
+// NewNoteReporter creates a NoteReporter for the current GitLab pipeline. The
+// reporter is inactive if GITLAB_TOKEN is not set or if the pipeline wasn't
+// triggered by a merge request.
+func NewNoteReporter(name string) NoteReporter {
+	c := ci.GetCI(ci.GitLab)
+
+	apiURL := os.Getenv("CI_API_V4_URL")
+	if apiURL == "" {
+		apiURL = "https://gitlab.com/api/v4"
+	}
+
+	return NoteReporter{
+		Name:         name,
+		APIURL:       apiURL,
+		Token:        os.Getenv("GITLAB_TOKEN"),
+		Project:      c.Repo,
+		MergeRequest: c.PR,
+		PipelineURL:  c.URL,
+		HTTPClient:   &http.Client{Timeout: 1 * time.Minute},
+	}
+}
+
+func (r *NoteReporter) isActive() bool {
+	return r.Token != "" && r.Project != "" && r.MergeRequest != ""
+}
+
+// Add adds the test result to the report.
+func (r *NoteReporter) Add(t report.TestResult) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.results = append(r.results, t)
+}
+
+// Render posts the note to the merge request.
+func (r *NoteReporter) Render() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.isActive() {
+		log.Warn().Msg("Unable to report to GitLab merge request. Make sure GITLAB_TOKEN is set and the pipeline runs for a merge request.")
+		return
+	}
+
+	if err := r.post(r.render()); err != nil {
+		log.Err(err).Msg("Failed to report to GitLab merge request.")
+	}
+}
+
+func (r *NoteReporter) render() string {
+	var b strings.Builder
+
+	failed := 0
+	for _, t := range r.results {
+		if t.Status == job.StateFailed || t.Status == job.StateError {
+			failed++
+		}
+	}
+
+	mark := ":white_check_mark:"
+	summary := fmt.Sprintf("All %d suites have passed", len(r.results))
+	if failed > 0 {
+		mark = ":x:"
+		summary = fmt.Sprintf("%d of %d suites have failed", failed, len(r.results))
+	}
+	fmt.Fprintf(&b, "### %s %s\n\n%s", mark, r.Name, summary)
+	if r.PipelineURL != "" {
+		fmt.Fprintf(&b, " ([pipeline](%s))", r.PipelineURL)
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString("| Name | Status | Browser | Platform | Duration |\n")
+	b.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, t := range r.results {
+		fmt.Fprintf(&b, "| [%s](%s) | %s | %s | %s | %.0fs |\n",
+			t.Name, t.URL, t.Status, t.Browser, t.Platform, t.Duration.Seconds())
+	}
+
+	var failures []string
+	for _, t := range r.results {
+		for _, tc := range report.FailedTestCases(t) {
+			line := fmt.Sprintf("- **%s**: `%s`", t.Name, tc.Name)
+			if msg := tc.FailureMessage(); msg != "" {
+				line += fmt.Sprintf(" — %s", strings.ReplaceAll(msg, "\n", " "))
+			}
+			failures = append(failures, line)
+		}
+	}
+	if len(failures) > 0 {
+		b.WriteString("\n<details><summary>Failed tests</summary>\n\n")
+		b.WriteString(strings.Join(failures, "\n"))
+		b.WriteString("\n\n</details>\n")
+	}
+
+	return b.String()
+}
+
+func (r *NoteReporter) post(body string) error {
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("%s/projects/%s/merge_requests/%s/notes", r.APIURL, url.PathEscape(r.Project), r.MergeRequest)
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", r.Token)
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status '%d' from GitLab: %s", resp.StatusCode, string(b))
+	}
+
+	return nil
+}
+
+// Reset resets the reporter to its initial state. This action will delete all test results.
+func (r *NoteReporter) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.results = make([]report.TestResult, 0)
+}
+
+// ArtifactRequirements returns a list of artifact types this reporter requires to create a proper report.
+func (r *NoteReporter) ArtifactRequirements() []report.ArtifactType {
+	return []report.ArtifactType{report.JUnitArtifact}
+}
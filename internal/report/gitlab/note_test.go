@@ -0,0 +1,58 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/junit"
+	"github.com/saucelabs/saucectl/internal/report"
+)
+
+func TestNoteReporter_Render(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		if r.Header.Get("PRIVATE-TOKEN") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer ts.Close()
+
+	r := &NoteReporter{
+		Name:         "saucectl",
+		APIURL:       ts.URL,
+		Token:        "token",
+		Project:      "group/project",
+		MergeRequest: "7",
+		HTTPClient:   ts.Client(),
+	}
+	r.Add(report.TestResult{
+		Name:   "firefox",
+		Status: job.StateFailed,
+		Attempts: []report.Attempt{{
+			TestSuites: junit.TestSuites{TestSuites: []junit.TestSuite{{
+				TestCases: []junit.TestCase{
+					{Name: "fails", Failure: &junit.Failure{Message: "expected true"}},
+				},
+			}}},
+		}},
+	})
+	r.Render()
+
+	if want := "/projects/group%2Fproject/merge_requests/7/notes"; gotPath != want {
+		t.Errorf("path got = %s, want %s", gotPath, want)
+	}
+	for _, want := range []string{"1 of 1 suites have failed", "**firefox**: `fails` — expected true"} {
+		if !strings.Contains(gotBody["body"], want) {
+			t.Errorf("body %q does not contain %q", gotBody["body"], want)
+		}
+	}
+}
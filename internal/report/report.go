@@ -87,3 +87,20 @@ func IsArtifactRequired(reps []Reporter, at ArtifactType) bool {
 
 	return false
 }
+
+// FailedTestCases returns the test cases that failed or errored in the last
+// attempt of the given TestResult. Requires the JUnitArtifact.
+func FailedTestCases(t TestResult) []junit.TestCase {
+	if len(t.Attempts) == 0 {
+		return nil
+	}
+
+	var failed []junit.TestCase
+	for _, tc := range t.Attempts[len(t.Attempts)-1].TestSuites.TestCases() {
+		if tc.IsFailure() || tc.IsError() {
+			failed = append(failed, tc)
+		}
+	}
+
+	return failed
+}
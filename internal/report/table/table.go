@@ -95,11 +95,17 @@ func (r *Reporter) Render() {
 
 	var (
 		errors     int
+		cancelled  int
+		skipped    int
 		inProgress int
 		totalDur   time.Duration
 	)
 	for _, ts := range r.TestResults {
-		if !job.Done(ts.Status) && !ts.TimedOut {
+		if ts.Status == job.StateCancelled {
+			cancelled++
+		} else if ts.Status == job.StateSkipped {
+			skipped++
+		} else if !job.Done(ts.Status) && !ts.TimedOut {
 			inProgress++
 		}
 		if ts.Status == job.StateFailed {
@@ -117,7 +123,7 @@ func (r *Reporter) Render() {
 			statusText(ts.Status), ts.Browser, ts.Platform, ts.DeviceName, len(ts.Attempts)})
 	}
 
-	t.AppendFooter(footer(errors, inProgress, len(r.TestResults), calDuration(r.TestResults)))
+	t.AppendFooter(footer(errors, cancelled, skipped, inProgress, len(r.TestResults), calDuration(r.TestResults)))
 
 	_, _ = fmt.Fprintln(r.Dst)
 	t.Render()
@@ -135,10 +141,20 @@ func (r *Reporter) ArtifactRequirements() []report.ArtifactType {
 	return nil
 }
 
-func footer(errors, inProgress, tests int, dur time.Duration) table.Row {
+func footer(errors, cancelled, skipped, inProgress, tests int, dur time.Duration) table.Row {
 	if errors != 0 {
 		relative := float64(errors) / float64(tests) * 100
-		return table.Row{statusSymbol(job.StateError), fmt.Sprintf("%d of %d suites have failed (%.0f%%)", errors, tests, relative), dur.Truncate(1 * time.Second)}
+		msg := fmt.Sprintf("%d of %d suites have failed (%.0f%%)", errors, tests, relative)
+		if cancelled != 0 {
+			msg = fmt.Sprintf("%s, %d cancelled", msg, cancelled)
+		}
+		if skipped != 0 {
+			msg = fmt.Sprintf("%s, %d skipped", msg, skipped)
+		}
+		return table.Row{statusSymbol(job.StateError), msg, dur.Truncate(1 * time.Second)}
+	}
+	if cancelled != 0 || skipped != 0 {
+		return table.Row{statusSymbol(job.StateCancelled), fmt.Sprintf("%d of %d suites did not run", cancelled+skipped, tests), dur.Truncate(1 * time.Second)}
 	}
 	if inProgress != 0 {
 		return table.Row{statusSymbol(job.StateInProgress), "All suites have launched", dur.Truncate(1 * time.Second)}
@@ -152,6 +168,8 @@ func statusText(status string) string {
 		return color.GreenString(status)
 	case job.StateInProgress, job.StateQueued, job.StateNew:
 		return color.BlueString(status)
+	case job.StateCancelled, job.StateSkipped:
+		return color.YellowString(status)
 	default:
 		return color.RedString(status)
 	}
@@ -163,6 +181,8 @@ func statusSymbol(status string) string {
 		return color.GreenString("✔")
 	case job.StateInProgress, job.StateQueued, job.StateNew:
 		return color.BlueString("*")
+	case job.StateCancelled, job.StateSkipped:
+		return color.YellowString("-")
 	default:
 		return color.RedString("✖")
 	}
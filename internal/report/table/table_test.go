@@ -102,6 +102,39 @@ func TestReporter_Render(t *testing.T) {
   ✖    Chrome                                2m51s    failed    Chrome     Windows 10           3  
 ───────────────────────────────────────────────────────────────────────────────────────────────────
   ✖    1 of 2 suites have failed (50%)       2m51s                                                 
+`,
+		},
+		{
+			name: "with cancellation",
+			fields: fields{
+				TestResults: []report.TestResult{
+					{
+						Name:      "Firefox",
+						Duration:  34479 * time.Millisecond,
+						StartTime: startTime,
+						EndTime:   startTime.Add(34479 * time.Millisecond),
+						Status:    job.StateFailed,
+						Browser:   "Firefox",
+						Platform:  "Windows 10",
+						Attempts: []report.Attempt{
+							{Status: job.StateFailed},
+						},
+					},
+					{
+						Name:     "Chrome",
+						Status:   job.StateCancelled,
+						Browser:  "Chrome",
+						Platform: "Windows 10",
+					},
+				},
+			},
+			want: `
+       Name                                            Duration    Status       Browser    Platform      Attempts  
+───────────────────────────────────────────────────────────────────────────────────────────────────────────────────
+  ✖    Firefox                                              34s    failed       Firefox    Windows 10           1  
+  -    Chrome                                                0s    cancelled    Chrome     Windows 10           0  
+───────────────────────────────────────────────────────────────────────────────────────────────────────────────────
+  ✖    1 of 2 suites have failed (50%), 1 cancelled         34s                                                    
 `,
 		},
 	}
@@ -6,10 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	retryutil "github.com/saucelabs/saucectl/internal/retry"
@@ -37,6 +40,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/saucecloud/zip"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
 	"github.com/saucelabs/saucectl/internal/saucereport"
+	"github.com/saucelabs/saucectl/internal/secret"
 	"github.com/saucelabs/saucectl/internal/storage"
 	"github.com/saucelabs/saucectl/internal/tunnel"
 )
@@ -58,8 +62,21 @@ type CloudRunner struct {
 
 	Reporters []report.Reporter
 
-	Async    bool
-	FailFast bool
+	Async bool
+	// FailFast defines when the run is stopped early due to failing suites.
+	FailFast config.FailFast
+
+	// ConfigUpload controls how the config and CLI flags are attached to jobs.
+	ConfigUpload config.ConfigUpload
+
+	// RetryPolicy controls when and how failed suites are retried.
+	RetryPolicy config.RetryPolicy
+	// retryCount is the number of retries issued so far across all suites.
+	retryCount int64
+	// failFastTracker tracks suite outcomes of the current run. See FailFast.
+	failFastTracker *failFastTracker
+	// scheduler launches the jobs of the current run. See launch.
+	scheduler *scheduler
 
 	NPMDependencies []string
 
@@ -73,9 +90,11 @@ type Cache struct {
 
 type result struct {
 	name      string
+	suite     string
 	browser   string
 	job       job.Job
 	skipped   bool
+	cancelled bool
 	err       error
 	duration  time.Duration
 	startTime time.Time
@@ -94,15 +113,30 @@ func (r *CloudRunner) createWorkerPool(ctx context.Context, ccy int, maxRetries
 	jobOpts := make(chan job.StartOptions, maxRetries+1)
 	results := make(chan result, ccy)
 
+	// Jobs are cancelled either by the caller or once the fail fast threshold
+	// is reached.
+	jobCtx, cancel := context.WithCancel(ctx)
+	r.failFastTracker = &failFastTracker{policy: r.FailFast, cancel: cancel}
+
 	log.Info().Int("concurrency", ccy).Msg("Launching workers.")
 	for i := 0; i < ccy; i++ {
-		go r.runJobs(ctx, jobOpts, results)
+		go r.runJobs(ctx, jobCtx, jobOpts, results)
 	}
 
 	return jobOpts, results
 }
 
+// launch submits the jobs to the worker pool created by createWorkerPool. Jobs
+// are launched in order of their priority, once the suites they depend on have
+// passed.
+func (r *CloudRunner) launch(jobOpts chan<- job.StartOptions, opts []job.StartOptions) {
+	r.scheduler = newScheduler(jobOpts, opts, r.Async)
+	r.scheduler.start()
+}
+
 func (r *CloudRunner) collectResults(ctx context.Context, results chan result, expected int) bool {
+	defer r.failFastTracker.release()
+
 	// TODO find a better way to get the expected
 	completed := 0
 	inProgress := expected
@@ -124,8 +158,17 @@ func (r *CloudRunner) collectResults(ctx context.Context, results chan result, e
 		}
 	}()
 
+	// Results of jobs that were skipped by the scheduler.
+	var skipped []result
 	for i := 0; i < expected; i++ {
-		res := <-results
+		var res result
+		if len(skipped) > 0 {
+			res, skipped = skipped[0], skipped[1:]
+		} else {
+			res = <-results
+		}
+		skipped = append(skipped, r.scheduler.done(res)...)
+
 		// in case one of test suites not passed
 		// ignore jobs that are still in progress (i.e. async execution or client timeout)
 		// since their status is unknown
@@ -135,7 +178,9 @@ func (r *CloudRunner) collectResults(ctx context.Context, results chan result, e
 		completed++
 		inProgress--
 
-		if !res.skipped {
+		// Suites that didn't run due to fail fast or failed dependencies are
+		// reported nonetheless.
+		if !res.skipped || res.cancelled || res.job.Status == job.StateSkipped {
 			platform := res.job.OS
 			if res.job.OSVersion != "" {
 				platform = fmt.Sprintf("%s %s", platform, res.job.OSVersion)
@@ -147,7 +192,14 @@ func (r *CloudRunner) collectResults(ctx context.Context, results chan result, e
 				browser = fmt.Sprintf("%s %s", browser, res.job.BrowserVersion)
 			}
 
-			r.FetchJUnitReports(ctx, &res, res.artifacts)
+			if !res.skipped {
+				r.FetchJUnitReports(ctx, &res, res.artifacts)
+			}
+
+			var buildURL string
+			if res.job.ID != "" {
+				buildURL = r.findBuild(ctx, res.job.ID, res.job.IsRDC).URL
+			}
 
 			tr := report.TestResult{
 				Name:       res.name,
@@ -164,7 +216,7 @@ func (r *CloudRunner) collectResults(ctx context.Context, results chan result, e
 				RDC:        res.job.IsRDC,
 				TimedOut:   res.job.TimedOut,
 				Attempts:   res.attempts,
-				BuildURL:   r.findBuild(ctx, res.job.ID, res.job.IsRDC).URL,
+				BuildURL:   buildURL,
 			}
 			for _, rep := range r.Reporters {
 				rep.Add(tr)
@@ -233,8 +285,7 @@ func (r *CloudRunner) runJob(ctx context.Context, opts job.StartOptions) (j job.
 		return job.Job{Status: job.StateError}, false, err
 	}
 
-	r.uploadSauceConfig(ctx, j.ID, opts.RealDevice, opts.ConfigFilePath)
-	r.uploadCLIFlags(ctx, j.ID, opts.RealDevice, opts.CLIFlags)
+	r.attachConfig(ctx, opts, j.ID)
 
 	l := log.Info().Str("url", j.URL).Str("suite", opts.DisplayName).Str("platform", opts.PlatformName)
 
@@ -299,15 +350,122 @@ func shouldRetryJob(jobData job.Job, skipped bool) bool {
 	return !jobData.IsSuccessful() && !skipped
 }
 
+// isInfrastructureError checks if the job failed for reasons other than test
+// failures, e.g. because it errored, timed out or could not be started.
+func isInfrastructureError(jobData job.Job) bool {
+	return jobData.Error != "" || jobData.TimedOut || jobData.Status == job.StateError || jobData.ID == ""
+}
+
 // shouldRetry determines whether a job should be retried.
 func (r *CloudRunner) shouldRetry(opts job.StartOptions, jobData job.Job, skipped bool) bool {
-	return !r.Async && belowRetryLimit(opts) &&
-		(shouldRetryJob(jobData, skipped) || belowThreshold(opts))
+	if r.Async || !belowRetryLimit(opts) {
+		return false
+	}
+
+	if shouldRetryJob(jobData, skipped) {
+		// Failed suites are retried only if the policy allows it, even if
+		// they haven't reached their pass threshold yet.
+		if !r.RetryPolicy.RetriesOn(isInfrastructureError(jobData)) {
+			return false
+		}
+	} else if !belowThreshold(opts) {
+		return false
+	}
+
+	return r.reserveRetry()
 }
 
-func (r *CloudRunner) runJobs(ctx context.Context, jobOpts chan job.StartOptions, results chan<- result) {
-	skipStart := false
+// reserveRetry claims a retry from the budget of the run. Returns false if the
+// budget is exhausted.
+func (r *CloudRunner) reserveRetry() bool {
+	if r.RetryPolicy.MaxRetries == 0 {
+		return true
+	}
+	if atomic.AddInt64(&r.retryCount, 1) > int64(r.RetryPolicy.MaxRetries) {
+		log.Warn().
+			Int("maxRetries", r.RetryPolicy.MaxRetries).
+			Msg("Retry limit for this run reached. Not retrying any further suites.")
+		return false
+	}
+	return true
+}
 
+// retry hands the job over to the retrier after the delay defined by the
+// retry policy.
+func (r *CloudRunner) retry(ctx context.Context, jobOpts chan<- job.StartOptions, opts job.StartOptions, previous job.Job) {
+	if delay := r.RetryPolicy.DelayFor(opts.Attempt); delay > 0 {
+		log.Info().
+			Str("suite", opts.DisplayName).
+			Str("delay", delay.String()).
+			Msg("Waiting before retrying suite.")
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+	r.Retrier.Retry(ctx, jobOpts, opts, previous)
+}
+
+// failFastTracker counts completed and failed suites across workers and
+// cancels the remaining jobs once the fail fast threshold is reached.
+type failFastTracker struct {
+	policy config.FailFast
+	cancel context.CancelFunc
+
+	mu        sync.Mutex
+	completed int
+	failed    int
+	tripped   bool
+}
+
+// record records the outcome of a suite.
+func (t *failFastTracker) record(passed bool) {
+	if t == nil || !t.policy.Enabled() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.completed++
+	if !passed {
+		t.failed++
+	}
+	if t.tripped || !t.policy.Exceeded(t.failed, t.completed) {
+		return
+	}
+
+	t.tripped = true
+	log.Warn().
+		Int("failed", t.failed).
+		Int("completed", t.completed).
+		Msg("FailFast threshold reached. Stopping running suites and skipping upcoming suites.")
+	t.cancel()
+}
+
+// release releases the resources of the job context once all results were
+// collected.
+func (t *failFastTracker) release() {
+	if t == nil {
+		return
+	}
+	t.cancel()
+}
+
+// stopped returns true if the run was stopped due to the fail fast threshold.
+func (t *failFastTracker) stopped() bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tripped
+}
+
+// runJobs runs the jobs that are submitted to the worker pool. Jobs run with
+// jobCtx, which is cancelled once the fail fast threshold is reached, whereas
+// artifacts of finished jobs are downloaded with ctx.
+func (r *CloudRunner) runJobs(ctx, jobCtx context.Context, jobOpts chan job.StartOptions, results chan<- result) {
 	for opts := range jobOpts {
 		start := time.Now()
 
@@ -318,9 +476,10 @@ func (r *CloudRunner) runJobs(ctx context.Context, jobOpts chan job.StartOptions
 			BuildName: opts.Build,
 		}
 
-		if ctx.Err() != nil || skipStart {
-			results <- result{
+		if jobCtx.Err() != nil {
+			res := result{
 				name:     opts.DisplayName,
+				suite:    opts.SuiteName,
 				browser:  opts.BrowserName,
 				skipped:  true,
 				err:      nil,
@@ -328,6 +487,11 @@ func (r *CloudRunner) runJobs(ctx context.Context, jobOpts chan job.StartOptions
 				retries:  opts.Retries,
 				details:  details,
 			}
+			if r.failFastTracker.stopped() {
+				res.cancelled = true
+				res.job.Status = job.StateCancelled
+			}
+			results <- res
 			continue
 		}
 
@@ -335,7 +499,11 @@ func (r *CloudRunner) runJobs(ctx context.Context, jobOpts chan job.StartOptions
 			opts.StartTime = start
 		}
 
-		jobData, skipped, err := r.runJob(ctx, opts)
+		jobData, skipped, err := r.runJob(jobCtx, opts)
+		cancelled := skipped && r.failFastTracker.stopped()
+		if cancelled {
+			jobData.Status = job.StateCancelled
+		}
 
 		if jobData.IsSuccessful() {
 			opts.CurrentPassCount++
@@ -360,16 +528,11 @@ func (r *CloudRunner) runJobs(ctx context.Context, jobOpts chan job.StartOptions
 				Status:     jobData.Status,
 				TestSuites: junit.TestSuites{},
 			})
-			go r.Retrier.Retry(ctx, jobOpts, opts, jobData)
+			go r.retry(jobCtx, jobOpts, opts, jobData)
 
 			continue
 		}
 
-		if r.FailFast && !jobData.Passed {
-			log.Warn().Err(err).Msg("FailFast mode enabled. Skipping upcoming suites.")
-			skipStart = true
-		}
-
 		if !r.Async && !skipped {
 			if opts.CurrentPassCount < opts.PassThreshold {
 				log.Error().Str("suite", opts.DisplayName).Msg("Failed to pass threshold")
@@ -393,11 +556,19 @@ func (r *CloudRunner) runJobs(ctx context.Context, jobOpts chan job.StartOptions
 			}
 		}
 
+		// Recorded only after the artifacts were downloaded, since reaching
+		// the fail fast threshold cancels the jobs that are still running.
+		if !r.Async && !skipped {
+			r.failFastTracker.record(jobData.Passed)
+		}
+
 		results <- result{
 			name:      opts.DisplayName,
+			suite:     opts.SuiteName,
 			browser:   opts.BrowserName,
 			job:       jobData,
 			skipped:   skipped,
+			cancelled: cancelled,
 			err:       err,
 			startTime: opts.StartTime,
 			endTime:   time.Now(),
@@ -816,6 +987,11 @@ func (r *CloudRunner) logSuite(ctx context.Context, res result) {
 		Str("url", res.job.URL).
 		Logger()
 
+	if res.cancelled {
+		logger.Warn().Msg("Suite cancelled.")
+		return
+	}
+
 	if res.err != nil {
 		if res.skipped {
 			logger.Error().Err(res.err).Msg("Suite skipped.")
@@ -944,8 +1120,18 @@ func (r *CloudRunner) stopSuiteExecution(ctx context.Context, jobID string, real
 	_, _ = r.JobService.StopJob(ctx, jobID, realDevice)
 }
 
-// uploadSauceConfig adds job configuration as an asset.
-func (r *CloudRunner) uploadSauceConfig(ctx context.Context, jobID string, realDevice bool, cfgFile string) {
+// attachConfig attaches the config and CLI flags to the job, unless disabled.
+func (r *CloudRunner) attachConfig(ctx context.Context, opts job.StartOptions, jobID string) {
+	if r.ConfigUpload.Disabled {
+		return
+	}
+	r.uploadSauceConfig(ctx, jobID, opts.RealDevice, opts.ConfigFilePath, opts.CLIFlags)
+	r.uploadCLIFlags(ctx, jobID, opts.RealDevice, opts.CLIFlags)
+}
+
+// uploadSauceConfig adds job configuration as an asset. Sensitive env values,
+// as well as env values that were overridden via CLI flags, are redacted.
+func (r *CloudRunner) uploadSauceConfig(ctx context.Context, jobID string, realDevice bool, cfgFile string, cliFlags map[string]interface{}) {
 	// A config file is optional.
 	if cfgFile == "" {
 		return
@@ -961,23 +1147,63 @@ func (r *CloudRunner) uploadSauceConfig(ctx context.Context, jobID string, realD
 		log.Warn().Msgf("failed to read configuration: %v", err)
 		return
 	}
+	content, err = config.RedactEnv(content, r.ConfigUpload.Patterns(), envFlagKeys(cliFlags))
+	if err != nil {
+		log.Warn().Msgf("failed to redact configuration: %v", err)
+		return
+	}
+	content = secret.MaskBytes(content)
 	if err := r.JobService.UploadArtifact(ctx, jobID, realDevice, filepath.Base(cfgFile), "text/plain", content); err != nil {
 		log.Warn().Msgf("failed to attach configuration: %v", err)
 	}
 }
 
-// uploadCLIFlags adds commandline parameters as an asset.
-func (r *CloudRunner) uploadCLIFlags(ctx context.Context, jobID string, realDevice bool, content interface{}) {
-	encoded, err := json.Marshal(content)
+// envFlagKeys returns the keys of env variables that were passed via the --env
+// flag.
+func envFlagKeys(cliFlags map[string]interface{}) []string {
+	env, ok := cliFlags["env"].(map[string]string)
+	if !ok {
+		return nil
+	}
+
+	var keys []string
+	for k := range env {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// uploadCLIFlags adds commandline parameters as an asset. Values of env
+// variables passed via --env are redacted.
+func (r *CloudRunner) uploadCLIFlags(ctx context.Context, jobID string, realDevice bool, cliFlags map[string]interface{}) {
+	encoded, err := json.Marshal(redactEnvFlag(cliFlags))
 	if err != nil {
 		log.Warn().Msgf("Failed to encode CLI flags: %v", err)
 		return
 	}
+	encoded = secret.MaskBytes(encoded)
 	if err := r.JobService.UploadArtifact(ctx, jobID, realDevice, "flags.json", "text/plain", encoded); err != nil {
 		log.Warn().Msgf("Failed to report CLI flags: %v", err)
 	}
 }
 
+// redactEnvFlag returns a copy of cliFlags in which all values of the --env
+// flag are redacted, just like they are in the uploaded config.
+func redactEnvFlag(cliFlags map[string]interface{}) map[string]interface{} {
+	env, ok := cliFlags["env"].(map[string]string)
+	if !ok {
+		return cliFlags
+	}
+
+	redacted := make(map[string]string, len(env))
+	for k := range env {
+		redacted[k] = config.Redacted
+	}
+	out := maps.Clone(cliFlags)
+	out["env"] = redacted
+	return out
+}
+
 func (r *CloudRunner) logFrameworkError(ctx context.Context, err error) {
 	var unavailableErr *framework.UnavailableError
 	if errors.As(err, &unavailableErr) {
@@ -1125,3 +1351,27 @@ func printDryRunSuiteNames(suites []string) {
 	}
 	fmt.Println()
 }
+
+// plannedShard represents a shard of a dry run and the tests planned for it.
+type plannedShard struct {
+	name string
+	// summary describes the planned tests, e.g. "3 fixtures".
+	summary string
+	items   []string
+}
+
+// printDryRunShardPlan prints the tests that were planned for each shard.
+func printDryRunShardPlan(shards []plannedShard) {
+	if len(shards) == 0 {
+		return
+	}
+
+	fmt.Println("The following shards were planned:")
+	for _, s := range shards {
+		fmt.Printf("  - %s (%s)\n", s.name, s.summary)
+		for _, item := range s.items {
+			fmt.Printf("      %s\n", item)
+		}
+	}
+	fmt.Println()
+}
@@ -1,13 +1,19 @@
 package saucecloud
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/mocks"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
 )
 
 func Test_arrayContains(t *testing.T) {
@@ -278,3 +284,156 @@ func Test_shouldRetryJob(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudRunner_shouldRetry(t *testing.T) {
+	failed := job.Job{ID: "1", Status: job.StateFailed}
+	errored := job.Job{ID: "2", Status: job.StateError, Error: "no device available"}
+	passed := job.Job{ID: "4", Status: job.StatePassed, Passed: true}
+	opts := job.StartOptions{Retries: 3}
+	thresholdOpts := job.StartOptions{Retries: 3, PassThreshold: 2, CurrentPassCount: 1}
+
+	tests := []struct {
+		name     string
+		policy   config.RetryPolicy
+		opts     *job.StartOptions
+		jobData  job.Job
+		expected bool
+	}{
+		{
+			name:     "retry test failures by default",
+			jobData:  failed,
+			expected: true,
+		},
+		{
+			name:     "don't retry test failures when retrying on errors only",
+			policy:   config.RetryPolicy{On: config.RetryOnError},
+			jobData:  failed,
+			expected: false,
+		},
+		{
+			name:     "retry infrastructure errors when retrying on errors only",
+			policy:   config.RetryPolicy{On: config.RetryOnError},
+			jobData:  errored,
+			expected: true,
+		},
+		{
+			name:     "retry jobs that timed out when retrying on errors only",
+			policy:   config.RetryPolicy{On: config.RetryOnError},
+			jobData:  job.Job{ID: "3", Status: job.StateComplete, TimedOut: true},
+			expected: true,
+		},
+		{
+			name:     "don't retry test failures below the pass threshold when retrying on errors only",
+			policy:   config.RetryPolicy{On: config.RetryOnError},
+			opts:     &thresholdOpts,
+			jobData:  failed,
+			expected: false,
+		},
+		{
+			name:     "retry passed suites below the pass threshold when retrying on errors only",
+			policy:   config.RetryPolicy{On: config.RetryOnError},
+			opts:     &thresholdOpts,
+			jobData:  passed,
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := CloudRunner{RetryPolicy: tt.policy}
+			o := opts
+			if tt.opts != nil {
+				o = *tt.opts
+			}
+			assert.Equal(t, tt.expected, r.shouldRetry(o, tt.jobData, false))
+		})
+	}
+}
+
+func TestCloudRunner_shouldRetry_MaxRetries(t *testing.T) {
+	r := CloudRunner{RetryPolicy: config.RetryPolicy{MaxRetries: 2}}
+	failed := job.Job{ID: "1", Status: job.StateFailed}
+	opts := job.StartOptions{Retries: 5}
+
+	assert.True(t, r.shouldRetry(opts, failed, false))
+	assert.True(t, r.shouldRetry(opts, failed, false))
+	assert.False(t, r.shouldRetry(opts, failed, false))
+}
+
+func Test_failFastTracker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tracker := &failFastTracker{policy: config.FailFast{MaxFailures: 2}, cancel: cancel}
+
+	tracker.record(true)
+	tracker.record(false)
+	assert.False(t, tracker.stopped())
+	assert.NoError(t, ctx.Err())
+
+	tracker.record(false)
+	assert.True(t, tracker.stopped())
+	assert.Error(t, ctx.Err())
+}
+
+func TestCloudRunner_uploadSauceConfig(t *testing.T) {
+	dir := fs.NewDir(t, "config", fs.WithFile("config.yml", `env:
+  SAUCE_TOKEN: secret
+  BASE_URL: https://example.com
+  OVERRIDDEN: value
+`))
+	defer dir.Remove()
+
+	var uploaded map[string]string
+	r := CloudRunner{
+		JobService: &mocks.FakeJobService{
+			UploadAssetFn: func(_ context.Context, _ string, _ bool, fileName string, _ string, content []byte) error {
+				uploaded[fileName] = string(content)
+				return nil
+			},
+		},
+	}
+	cliFlags := map[string]interface{}{"env": map[string]string{"OVERRIDDEN": "***REDACTED***"}}
+
+	uploaded = map[string]string{}
+	r.uploadSauceConfig(context.Background(), "job-id", false, dir.Join("config.yml"), cliFlags)
+	assert.Equal(t, map[string]string{
+		"config.yml": `env:
+  SAUCE_TOKEN: '***REDACTED***'
+  BASE_URL: https://example.com
+  OVERRIDDEN: '***REDACTED***'
+`,
+	}, uploaded)
+
+	uploaded = map[string]string{}
+	r.ConfigUpload = config.ConfigUpload{Disabled: true}
+	r.attachConfig(context.Background(), job.StartOptions{ConfigFilePath: dir.Join("config.yml"), CLIFlags: cliFlags}, "job-id")
+	assert.Empty(t, uploaded)
+}
+
+func TestCloudRunner_uploadCLIFlags(t *testing.T) {
+	var uploaded string
+	r := CloudRunner{
+		JobService: &mocks.FakeJobService{
+			UploadAssetFn: func(_ context.Context, _ string, _ bool, fileName string, _ string, content []byte) error {
+				assert.Equal(t, "flags.json", fileName)
+				uploaded = string(content)
+				return nil
+			},
+		},
+	}
+	cliFlags := map[string]interface{}{
+		"env":    map[string]string{"BASE_URL": "https://staging.example.com"},
+		"region": "us-west-1",
+	}
+
+	r.uploadCLIFlags(context.Background(), "job-id", false, cliFlags)
+	assert.NotContains(t, uploaded, "staging.example.com")
+	assert.JSONEq(t, `{"env":{"BASE_URL":"***REDACTED***"},"region":"us-west-1"}`, uploaded)
+	assert.Equal(t, "https://staging.example.com", cliFlags["env"].(map[string]string)["BASE_URL"])
+
+	set := pflag.NewFlagSet("run", pflag.ContinueOnError)
+	set.StringToString("env", map[string]string{}, "")
+	assert.NoError(t, set.Parse([]string{"--env", "BASE_URL=https://staging.example.com"}))
+
+	r.uploadCLIFlags(context.Background(), "job-id", false, flags.CaptureCommandLineFlags(set))
+	assert.NotContains(t, uploaded, "staging.example.com")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/saucelabs/saucectl/internal/cucumber"
@@ -54,6 +55,7 @@ func (r *CucumberRunner) RunProject(ctx context.Context) (int, error) {
 
 	if r.Project.DryRun {
 		printDryRunSuiteNames(r.getSuiteNames())
+		printDryRunShardPlan(cucumberShardPlan(r.Project.Suites))
 		return 0, nil
 	}
 
@@ -149,41 +151,66 @@ func (r *CucumberRunner) runSuites(ctx context.Context, app string, otherApps []
 	}
 
 	// Submit suites to work on
-	go func() {
-		for _, s := range suites {
-			jobOpts <- job.StartOptions{
-				ConfigFilePath:   r.Project.ConfigFilePath,
-				DisplayName:      s.Name,
-				App:              app,
-				OtherApps:        otherApps,
-				Suite:            s.Name,
-				Framework:        "playwright",
-				FrameworkVersion: r.Project.Playwright.Version,
-				NodeVersion:      r.Project.NodeVersion,
-				BrowserName:      s.BrowserName,
-				BrowserVersion:   s.BrowserVersion,
-				PlatformName:     s.PlatformName,
-				Name:             s.Name,
-				Build:            r.Project.Sauce.Metadata.Build,
-				Tags:             r.Project.Sauce.Metadata.Tags,
-				Tunnel: job.TunnelOptions{
-					Name:  r.Project.Sauce.Tunnel.Name,
-					Owner: r.Project.Sauce.Tunnel.Owner,
-				},
-				ScreenResolution: s.ScreenResolution,
-				RunnerVersion:    r.Project.RunnerVersion,
-				Experiments:      r.Project.Sauce.Experiments,
-				Attempt:          0,
-				Retries:          r.Project.Sauce.Retries,
-				Visibility:       r.Project.Sauce.Visibility,
-				PassThreshold:    s.PassThreshold,
-				SmartRetry: job.SmartRetry{
-					FailedOnly: s.SmartRetry.IsRetryFailedOnly(),
-				},
-				ARMRequired: s.ARMRequired,
-			}
-		}
-	}()
+	var startOptions []job.StartOptions
+	for _, s := range suites {
+		startOptions = append(startOptions, job.StartOptions{
+			ConfigFilePath:   r.Project.ConfigFilePath,
+			DisplayName:      s.Name,
+			App:              app,
+			OtherApps:        otherApps,
+			Suite:            s.Name,
+			Framework:        "playwright",
+			FrameworkVersion: r.Project.Playwright.Version,
+			NodeVersion:      r.Project.NodeVersion,
+			BrowserName:      s.BrowserName,
+			BrowserVersion:   s.BrowserVersion,
+			PlatformName:     s.PlatformName,
+			Name:             s.Name,
+			Build:            r.Project.Sauce.Metadata.Build,
+			Tags:             r.Project.Sauce.Metadata.Tags,
+			CustomData:       r.Project.Sauce.Metadata.CustomData,
+			Tunnel: job.TunnelOptions{
+				Name:  r.Project.Sauce.Tunnel.Name,
+				Owner: r.Project.Sauce.Tunnel.Owner,
+			},
+			ScreenResolution: s.ScreenResolution,
+			RunnerVersion:    r.Project.RunnerVersion,
+			Experiments:      r.Project.Sauce.Experiments,
+			Attempt:          0,
+			Retries:          r.Project.Sauce.Retries,
+			Visibility:       r.Project.Sauce.Visibility,
+			PassThreshold:    s.PassThreshold,
+			SmartRetry: job.SmartRetry{
+				FailedOnly: s.SmartRetry.IsRetryFailedOnly(),
+			},
+			ARMRequired: s.ARMRequired,
+			SuiteName:   s.Name,
+			Priority:    s.Priority,
+			DependsOn:   s.DependsOn,
+		})
+	}
+	r.launch(jobOpts, startOptions)
 
 	return r.collectResults(ctx, results, len(r.Project.Suites))
 }
+
+// cucumberShardPlan returns the scenarios that were planned for suites that
+// are sharded by duration.
+func cucumberShardPlan(suites []cucumber.Suite) []plannedShard {
+	var shards []plannedShard
+	for _, s := range suites {
+		if len(s.ShardPlan.Scenarios) == 0 {
+			continue
+		}
+		estimate := "no previous runs"
+		if s.ShardPlan.Estimated > 0 {
+			estimate = fmt.Sprintf("estimated %s", s.ShardPlan.Estimated.Round(time.Second))
+		}
+		shards = append(shards, plannedShard{
+			name:    s.Name,
+			summary: fmt.Sprintf("%d scenarios, %s", len(s.ShardPlan.Scenarios), estimate),
+			items:   s.ShardPlan.Scenarios,
+		})
+	}
+	return shards
+}
@@ -141,44 +141,48 @@ func (r *CypressRunner) runSuites(ctx context.Context, app string, otherApps []s
 	}
 
 	// Submit suites to work on.
-	go func() {
-		for _, s := range suites {
-			smartRetry := (*r.Project).GetSmartRetry(s.Name)
-			jobOpts <- job.StartOptions{
-				ConfigFilePath:   (*r.Project).GetCfgPath(),
-				CLIFlags:         (*r.Project).GetCLIFlags(),
-				DisplayName:      s.Name,
-				Timeout:          s.Timeout,
-				App:              app,
-				OtherApps:        otherApps,
-				Suite:            s.Name,
-				Framework:        "cypress",
-				FrameworkVersion: (*r.Project).GetVersion(),
-				NodeVersion:      (*r.Project).GetNodeVersion(),
-				BrowserName:      s.Browser,
-				BrowserVersion:   s.BrowserVersion,
-				PlatformName:     s.PlatformName,
-				Name:             s.Name,
-				Build:            (*r.Project).GetSauceCfg().Metadata.Build,
-				Tags:             (*r.Project).GetSauceCfg().Metadata.Tags,
-				Tunnel: job.TunnelOptions{
-					Name:  (*r.Project).GetSauceCfg().Tunnel.Name,
-					Owner: (*r.Project).GetSauceCfg().Tunnel.Owner,
-				},
-				ScreenResolution: s.ScreenResolution,
-				RunnerVersion:    (*r.Project).GetRunnerVersion(),
-				Experiments:      (*r.Project).GetSauceCfg().Experiments,
-				Attempt:          0,
-				Retries:          (*r.Project).GetSauceCfg().Retries,
-				TimeZone:         s.TimeZone,
-				Visibility:       (*r.Project).GetSauceCfg().Visibility,
-				PassThreshold:    s.PassThreshold,
-				SmartRetry: job.SmartRetry{
-					FailedOnly: smartRetry.IsRetryFailedOnly(),
-				},
-			}
-		}
-	}()
+	var startOptions []job.StartOptions
+	for _, s := range suites {
+		smartRetry := (*r.Project).GetSmartRetry(s.Name)
+		startOptions = append(startOptions, job.StartOptions{
+			ConfigFilePath:   (*r.Project).GetCfgPath(),
+			CLIFlags:         (*r.Project).GetCLIFlags(),
+			DisplayName:      s.Name,
+			Timeout:          s.Timeout,
+			App:              app,
+			OtherApps:        otherApps,
+			Suite:            s.Name,
+			Framework:        "cypress",
+			FrameworkVersion: (*r.Project).GetVersion(),
+			NodeVersion:      (*r.Project).GetNodeVersion(),
+			BrowserName:      s.Browser,
+			BrowserVersion:   s.BrowserVersion,
+			PlatformName:     s.PlatformName,
+			Name:             s.Name,
+			Build:            (*r.Project).GetSauceCfg().Metadata.Build,
+			Tags:             (*r.Project).GetSauceCfg().Metadata.Tags,
+			CustomData:       (*r.Project).GetSauceCfg().Metadata.CustomData,
+			Tunnel: job.TunnelOptions{
+				Name:  (*r.Project).GetSauceCfg().Tunnel.Name,
+				Owner: (*r.Project).GetSauceCfg().Tunnel.Owner,
+			},
+			ScreenResolution: s.ScreenResolution,
+			RunnerVersion:    (*r.Project).GetRunnerVersion(),
+			Experiments:      (*r.Project).GetSauceCfg().Experiments,
+			Attempt:          0,
+			Retries:          (*r.Project).GetSauceCfg().Retries,
+			TimeZone:         s.TimeZone,
+			Visibility:       (*r.Project).GetSauceCfg().Visibility,
+			PassThreshold:    s.PassThreshold,
+			SmartRetry: job.SmartRetry{
+				FailedOnly: smartRetry.IsRetryFailedOnly(),
+			},
+			SuiteName: s.Name,
+			Priority:  s.Priority,
+			DependsOn: s.DependsOn,
+		})
+	}
+	r.launch(jobOpts, startOptions)
 
 	return r.collectResults(ctx, results, (*r.Project).GetSuiteCount())
 }
@@ -148,11 +148,7 @@ func (r *EspressoRunner) runSuites(ctx context.Context) bool {
 		}
 	}
 
-	go func() {
-		for _, opt := range startOptions {
-			jobOpts <- opt
-		}
-	}()
+	r.launch(jobOpts, startOptions)
 
 	return r.collectResults(ctx, results, len(startOptions))
 }
@@ -231,6 +227,7 @@ func (r *EspressoRunner) newStartOptions(
 		Name:              displayName,
 		Build:             r.Project.Sauce.Metadata.Build,
 		Tags:              r.Project.Sauce.Metadata.Tags,
+		CustomData:        r.Project.Sauce.Metadata.CustomData,
 		Tunnel: job.TunnelOptions{
 			Name:  r.Project.Sauce.Tunnel.Name,
 			Owner: r.Project.Sauce.Tunnel.Owner,
@@ -281,5 +278,10 @@ func (r *EspressoRunner) newStartOptions(
 				BiometricsInterception:      s.AppSettings.Instrumentation.Biometrics,
 			},
 		},
+
+		// Scheduling
+		SuiteName: s.Name,
+		Priority:  s.Priority,
+		DependsOn: s.DependsOn,
 	}
 }
@@ -153,48 +153,52 @@ func (r *PlaywrightRunner) runSuites(ctx context.Context, app string, otherApps
 		}
 	}
 	// Submit suites to work on.
-	go func() {
-		for _, s := range suites {
-			// Define frameworkVersion if not set at suite level
-			if s.PlaywrightVersion == "" {
-				s.PlaywrightVersion = r.Project.Playwright.Version
-			}
-			jobOpts <- job.StartOptions{
-				ConfigFilePath:   r.Project.ConfigFilePath,
-				CLIFlags:         r.Project.CLIFlags,
-				DisplayName:      s.Name,
-				Timeout:          s.Timeout,
-				App:              app,
-				OtherApps:        otherApps,
-				Suite:            s.Name,
-				Framework:        "playwright",
-				FrameworkVersion: s.PlaywrightVersion,
-				NodeVersion:      r.Project.NodeVersion,
-				BrowserName:      s.Params.BrowserName,
-				BrowserVersion:   s.Params.BrowserVersion,
-				PlatformName:     s.PlatformName,
-				Name:             s.Name,
-				Build:            r.Project.Sauce.Metadata.Build,
-				Tags:             r.Project.Sauce.Metadata.Tags,
-				Tunnel: job.TunnelOptions{
-					Name:  r.Project.Sauce.Tunnel.Name,
-					Owner: r.Project.Sauce.Tunnel.Owner,
-				},
-				ScreenResolution: s.ScreenResolution,
-				RunnerVersion:    r.Project.RunnerVersion,
-				Experiments:      r.Project.Sauce.Experiments,
-				Attempt:          0,
-				Retries:          r.Project.Sauce.Retries,
-				TimeZone:         s.TimeZone,
-				Visibility:       r.Project.Sauce.Visibility,
-				PassThreshold:    s.PassThreshold,
-				SmartRetry: job.SmartRetry{
-					FailedOnly: s.SmartRetry.IsRetryFailedOnly(),
-				},
-				ARMRequired: s.ARMRequired,
-			}
+	var startOptions []job.StartOptions
+	for _, s := range suites {
+		// Define frameworkVersion if not set at suite level
+		if s.PlaywrightVersion == "" {
+			s.PlaywrightVersion = r.Project.Playwright.Version
 		}
-	}()
+		startOptions = append(startOptions, job.StartOptions{
+			ConfigFilePath:   r.Project.ConfigFilePath,
+			CLIFlags:         r.Project.CLIFlags,
+			DisplayName:      s.Name,
+			Timeout:          s.Timeout,
+			App:              app,
+			OtherApps:        otherApps,
+			Suite:            s.Name,
+			Framework:        "playwright",
+			FrameworkVersion: s.PlaywrightVersion,
+			NodeVersion:      r.Project.NodeVersion,
+			BrowserName:      s.Params.BrowserName,
+			BrowserVersion:   s.Params.BrowserVersion,
+			PlatformName:     s.PlatformName,
+			Name:             s.Name,
+			Build:            r.Project.Sauce.Metadata.Build,
+			Tags:             r.Project.Sauce.Metadata.Tags,
+			CustomData:       r.Project.Sauce.Metadata.CustomData,
+			Tunnel: job.TunnelOptions{
+				Name:  r.Project.Sauce.Tunnel.Name,
+				Owner: r.Project.Sauce.Tunnel.Owner,
+			},
+			ScreenResolution: s.ScreenResolution,
+			RunnerVersion:    r.Project.RunnerVersion,
+			Experiments:      r.Project.Sauce.Experiments,
+			Attempt:          0,
+			Retries:          r.Project.Sauce.Retries,
+			TimeZone:         s.TimeZone,
+			Visibility:       r.Project.Sauce.Visibility,
+			PassThreshold:    s.PassThreshold,
+			SmartRetry: job.SmartRetry{
+				FailedOnly: s.SmartRetry.IsRetryFailedOnly(),
+			},
+			ARMRequired: s.ARMRequired,
+			SuiteName:   s.Name,
+			Priority:    s.Priority,
+			DependsOn:   s.DependsOn,
+		})
+	}
+	r.launch(jobOpts, startOptions)
 
 	return r.collectResults(ctx, results, len(r.Project.Suites))
 }
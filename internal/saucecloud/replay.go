@@ -3,6 +3,7 @@ package saucecloud
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/rs/zerolog/log"
 	"github.com/saucelabs/saucectl/internal/framework"
@@ -61,6 +62,7 @@ func (r *ReplayRunner) RunProject(ctx context.Context) (int, error) {
 
 	if r.Project.DryRun {
 		printDryRunSuiteNames(suiteNames)
+		printDryRunRecordings(r.Project.Suites)
 		return 0, nil
 	}
 
@@ -72,6 +74,21 @@ func (r *ReplayRunner) RunProject(ctx context.Context) (int, error) {
 	return exitCode, nil
 }
 
+// printDryRunRecordings prints a summary of the steps per recording.
+func printDryRunRecordings(suites []replay.Suite) {
+	fmt.Println("The following recordings would have been replayed:")
+	for _, s := range suites {
+		rec, err := replay.ReadRecording(s.Recording)
+		if err != nil {
+			fmt.Printf("  - %s: %v\n", s.Recording, err)
+			continue
+		}
+		fmt.Printf("  - %s: %q (%d steps)\n", s.Recording, rec.Title, len(rec.Steps))
+		fmt.Printf("      %s\n", rec.Summary())
+	}
+	fmt.Println()
+}
+
 func (r *ReplayRunner) runSuites(ctx context.Context, fileURI string) bool {
 	jobOpts, results := r.createWorkerPool(ctx, r.Project.Sauce.Concurrency, r.Project.Sauce.Retries)
 	defer close(results)
@@ -86,35 +103,39 @@ func (r *ReplayRunner) runSuites(ctx context.Context, fileURI string) bool {
 		}
 	}
 	// Submit suites to work on.
-	go func() {
-		for _, s := range suites {
-			jobOpts <- job.StartOptions{
-				ConfigFilePath:   r.Project.ConfigFilePath,
-				DisplayName:      s.Name,
-				Timeout:          s.Timeout,
-				App:              fileURI,
-				Suite:            s.Name,
-				Framework:        "puppeteer-replay",
-				FrameworkVersion: "latest",
-				RunnerVersion:    r.Project.RunnerVersion,
-				BrowserName:      s.BrowserName,
-				BrowserVersion:   s.BrowserVersion,
-				PlatformName:     s.Platform,
-				Name:             s.Name,
-				Build:            r.Project.Sauce.Metadata.Build,
-				Tags:             r.Project.Sauce.Metadata.Tags,
-				Tunnel: job.TunnelOptions{
-					Name:  r.Project.Sauce.Tunnel.Name,
-					Owner: r.Project.Sauce.Tunnel.Owner,
-				},
-				Experiments:   r.Project.Sauce.Experiments,
-				Attempt:       0,
-				Retries:       r.Project.Sauce.Retries,
-				Visibility:    r.Project.Sauce.Visibility,
-				PassThreshold: s.PassThreshold,
-			}
-		}
-	}()
+	var startOptions []job.StartOptions
+	for _, s := range suites {
+		startOptions = append(startOptions, job.StartOptions{
+			ConfigFilePath:   r.Project.ConfigFilePath,
+			DisplayName:      s.Name,
+			Timeout:          s.Timeout,
+			App:              fileURI,
+			Suite:            s.Name,
+			Framework:        "puppeteer-replay",
+			FrameworkVersion: "latest",
+			RunnerVersion:    r.Project.RunnerVersion,
+			BrowserName:      s.BrowserName,
+			BrowserVersion:   s.BrowserVersion,
+			PlatformName:     s.Platform,
+			Name:             s.Name,
+			Build:            r.Project.Sauce.Metadata.Build,
+			Tags:             r.Project.Sauce.Metadata.Tags,
+			CustomData:       r.Project.Sauce.Metadata.CustomData,
+			Tunnel: job.TunnelOptions{
+				Name:  r.Project.Sauce.Tunnel.Name,
+				Owner: r.Project.Sauce.Tunnel.Owner,
+			},
+			Experiments:   r.Project.Sauce.Experiments,
+			Attempt:       0,
+			Retries:       r.Project.Sauce.Retries,
+			Visibility:    r.Project.Sauce.Visibility,
+			PassThreshold: s.PassThreshold,
+			SuiteName:     s.Name,
+			Priority:      s.Priority,
+			DependsOn:     s.DependsOn,
+		})
+	}
+	r.launch(jobOpts, startOptions)
 
 	return r.collectResults(ctx, results, len(r.Project.Suites))
 }
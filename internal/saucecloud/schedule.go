@@ -0,0 +1,160 @@
+package saucecloud
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/job"
+)
+
+// scheduler launches jobs in order of their priority, once the suites they
+// depend on have passed. Jobs that depend on a suite that didn't pass are
+// skipped.
+type scheduler struct {
+	jobOpts chan<- job.StartOptions
+
+	mu sync.Mutex
+	// suites lists the suites of the run in order, for deterministic results.
+	suites []string
+	// remaining counts the unfinished jobs per suite.
+	remaining map[string]int
+	// failed flags suites of which at least one job didn't pass.
+	failed map[string]bool
+	// waiting holds the jobs whose dependencies haven't finished yet.
+	waiting []job.StartOptions
+}
+
+func newScheduler(jobOpts chan<- job.StartOptions, opts []job.StartOptions, async bool) *scheduler {
+	s := &scheduler{
+		jobOpts:   jobOpts,
+		remaining: map[string]int{},
+		failed:    map[string]bool{},
+	}
+
+	warned := false
+	for _, o := range opts {
+		if o.SuiteName == "" {
+			o.SuiteName = o.DisplayName
+		}
+		// The outcome of async jobs is unknown, hence there's nothing to wait for.
+		if async && len(o.DependsOn) > 0 {
+			if !warned {
+				log.Warn().Msg("Suite dependencies are ignored in async mode.")
+				warned = true
+			}
+			o.DependsOn = nil
+		}
+		if _, ok := s.remaining[o.SuiteName]; !ok {
+			s.suites = append(s.suites, o.SuiteName)
+		}
+		s.remaining[o.SuiteName]++
+		s.waiting = append(s.waiting, o)
+	}
+
+	return s
+}
+
+// start launches all jobs that don't depend on other suites.
+func (s *scheduler) start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.launch(s.release(nil))
+}
+
+// done records the result of a job and launches the jobs that became ready as
+// a consequence. Returns the results of jobs that were skipped, since a suite
+// they depend on didn't pass.
+func (s *scheduler) done(res result) []result {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remaining[res.suite]--
+	if !res.job.Passed {
+		s.failed[res.suite] = true
+	}
+
+	var skipped []result
+	s.launch(s.release(&skipped))
+	return skipped
+}
+
+// release removes all jobs from the waiting list whose dependencies are
+// resolved. Returns the jobs that are ready to run. Jobs that can't run
+// anymore are appended to skipped, which in turn may unblock further jobs.
+func (s *scheduler) release(skipped *[]result) []job.StartOptions {
+	var ready []job.StartOptions
+
+	for changed := true; changed; {
+		changed = false
+		var waiting []job.StartOptions
+		for _, o := range s.waiting {
+			ok, blocker := s.resolve(o)
+			switch {
+			case blocker != "" && skipped != nil:
+				*skipped = append(*skipped, result{
+					name:    o.DisplayName,
+					suite:   o.SuiteName,
+					browser: o.BrowserName,
+					job:     job.Job{Status: job.StateSkipped},
+					skipped: true,
+					err:     fmt.Errorf("suite '%s' did not pass", blocker),
+					retries: o.Retries,
+				})
+				s.remaining[o.SuiteName]--
+				s.failed[o.SuiteName] = true
+				changed = true
+			case ok:
+				ready = append(ready, o)
+			default:
+				waiting = append(waiting, o)
+			}
+		}
+		s.waiting = waiting
+	}
+
+	return ready
+}
+
+// resolve checks the dependencies of the job. Returns true if all suites the
+// job depends on have passed. Returns the name of the suite that blocks the
+// job if any of them didn't pass.
+func (s *scheduler) resolve(o job.StartOptions) (bool, string) {
+	ok := true
+	for _, dep := range o.DependsOn {
+		for _, suite := range s.suites {
+			if suite == o.SuiteName || !config.DependencyMatches(dep, suite) {
+				continue
+			}
+			if s.failed[suite] {
+				return false, suite
+			}
+			if s.remaining[suite] > 0 {
+				ok = false
+			}
+		}
+	}
+	return ok, ""
+}
+
+// launch submits the jobs to the worker pool, highest priority first.
+func (s *scheduler) launch(opts []job.StartOptions) {
+	if len(opts) == 0 {
+		return
+	}
+	sort.SliceStable(opts, func(i, j int) bool {
+		return opts[i].Priority > opts[j].Priority
+	})
+	go func() {
+		for _, o := range opts {
+			s.jobOpts <- o
+		}
+	}()
+}
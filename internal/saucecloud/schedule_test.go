@@ -0,0 +1,87 @@
+package saucecloud
+
+import (
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/stretchr/testify/assert"
+)
+
+func receive(t *testing.T, c chan job.StartOptions, n int) []string {
+	t.Helper()
+	var names []string
+	for i := 0; i < n; i++ {
+		names = append(names, (<-c).DisplayName)
+	}
+	select {
+	case o := <-c:
+		t.Fatalf("unexpected job %s", o.DisplayName)
+	default:
+	}
+	return names
+}
+
+func TestScheduler_Priority(t *testing.T) {
+	c := make(chan job.StartOptions, 10)
+	s := newScheduler(c, []job.StartOptions{
+		{DisplayName: "low", SuiteName: "low"},
+		{DisplayName: "high", SuiteName: "high", Priority: 10},
+		{DisplayName: "medium", SuiteName: "medium", Priority: 5},
+		{DisplayName: "low too", SuiteName: "low too"},
+	}, false)
+	s.start()
+
+	assert.Equal(t, []string{"high", "medium", "low", "low too"}, receive(t, c, 4))
+}
+
+func TestScheduler_DependsOn(t *testing.T) {
+	c := make(chan job.StartOptions, 10)
+	s := newScheduler(c, []job.StartOptions{
+		{DisplayName: "smoke (chrome)", SuiteName: "smoke"},
+		{DisplayName: "smoke (firefox)", SuiteName: "smoke"},
+		{DisplayName: "regression", SuiteName: "regression", DependsOn: []string{"smoke"}},
+		{DisplayName: "unit", SuiteName: "unit"},
+	}, false)
+	s.start()
+	assert.ElementsMatch(t, []string{"smoke (chrome)", "smoke (firefox)", "unit"}, receive(t, c, 3))
+
+	assert.Empty(t, s.done(result{suite: "smoke", job: job.Job{Passed: true}}))
+	assert.Empty(t, receive(t, c, 0), "regression must wait for all smoke jobs")
+
+	assert.Empty(t, s.done(result{suite: "smoke", job: job.Job{Passed: true}}))
+	assert.Equal(t, []string{"regression"}, receive(t, c, 1))
+}
+
+func TestScheduler_DependsOn_Failed(t *testing.T) {
+	c := make(chan job.StartOptions, 10)
+	s := newScheduler(c, []job.StartOptions{
+		{DisplayName: "smoke", SuiteName: "smoke"},
+		{DisplayName: "regression", SuiteName: "regression", DependsOn: []string{"smoke"}},
+		{DisplayName: "e2e", SuiteName: "e2e", DependsOn: []string{"regression"}},
+	}, false)
+	s.start()
+	assert.Equal(t, []string{"smoke"}, receive(t, c, 1))
+
+	skipped := s.done(result{suite: "smoke", job: job.Job{Status: job.StateFailed}})
+	assert.Empty(t, receive(t, c, 0))
+
+	var names []string
+	for _, res := range skipped {
+		assert.True(t, res.skipped)
+		assert.Equal(t, job.StateSkipped, res.job.Status)
+		names = append(names, res.name)
+	}
+	assert.Equal(t, []string{"regression", "e2e"}, names)
+	assert.EqualError(t, skipped[1].err, "suite 'regression' did not pass")
+}
+
+func TestScheduler_DependsOn_Async(t *testing.T) {
+	c := make(chan job.StartOptions, 10)
+	s := newScheduler(c, []job.StartOptions{
+		{DisplayName: "smoke", SuiteName: "smoke"},
+		{DisplayName: "regression", SuiteName: "regression", DependsOn: []string{"smoke"}},
+	}, true)
+	s.start()
+
+	assert.ElementsMatch(t, []string{"smoke", "regression"}, receive(t, c, 2))
+}
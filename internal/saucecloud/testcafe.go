@@ -53,6 +53,7 @@ func (r *TestcafeRunner) RunProject(ctx context.Context) (int, error) {
 
 	if r.Project.DryRun {
 		printDryRunSuiteNames(r.getSuiteNames())
+		printDryRunShardPlan(testcafeShardPlan(r.Project.Suites))
 		return 0, nil
 	}
 
@@ -134,6 +135,22 @@ func (r *TestcafeRunner) getSuiteNames() []string {
 	return names
 }
 
+// testcafeShardPlan returns the fixtures that were found for sharded suites.
+func testcafeShardPlan(suites []testcafe.Suite) []plannedShard {
+	var shards []plannedShard
+	for _, s := range suites {
+		if len(s.Fixtures) == 0 {
+			continue
+		}
+		shards = append(shards, plannedShard{
+			name:    s.Name,
+			summary: fmt.Sprintf("%d fixtures", len(s.Fixtures)),
+			items:   s.Fixtures,
+		})
+	}
+	return shards
+}
+
 func (r *TestcafeRunner) runSuites(ctx context.Context, app string, otherApps []string) bool {
 	jobOpts, results := r.createWorkerPool(ctx, r.Project.Sauce.Concurrency, r.Project.Sauce.Retries)
 	defer close(results)
@@ -150,32 +167,32 @@ func (r *TestcafeRunner) runSuites(ctx context.Context, app string, otherApps []
 
 	// Submit suites to work on
 	jobsCount := r.calcTestcafeJobsCount(r.Project.Suites)
-	go func() {
-		for _, s := range suites {
-			if len(s.Simulators) > 0 {
-				for _, d := range s.Simulators {
-					for _, pv := range d.PlatformVersions {
-						opts := r.generateStartOpts(s)
-						opts.App = app
-						opts.OtherApps = otherApps
-						opts.PlatformName = d.PlatformName
-						opts.DeviceName = d.Name
-						opts.PlatformVersion = pv
-						opts.ARMRequired = d.ARMRequired
-
-						jobOpts <- opts
-					}
+	var startOptions []job.StartOptions
+	for _, s := range suites {
+		if len(s.Simulators) > 0 {
+			for _, d := range s.Simulators {
+				for _, pv := range d.PlatformVersions {
+					opts := r.generateStartOpts(s)
+					opts.App = app
+					opts.OtherApps = otherApps
+					opts.PlatformName = d.PlatformName
+					opts.DeviceName = d.Name
+					opts.PlatformVersion = pv
+					opts.ARMRequired = d.ARMRequired
+
+					startOptions = append(startOptions, opts)
 				}
-			} else {
-				opts := r.generateStartOpts(s)
-				opts.App = app
-				opts.OtherApps = otherApps
-				opts.PlatformName = s.PlatformName
-
-				jobOpts <- opts
 			}
+		} else {
+			opts := r.generateStartOpts(s)
+			opts.App = app
+			opts.OtherApps = otherApps
+			opts.PlatformName = s.PlatformName
+
+			startOptions = append(startOptions, opts)
 		}
-	}()
+	}
+	r.launch(jobOpts, startOptions)
 
 	return r.collectResults(ctx, results, jobsCount)
 }
@@ -195,6 +212,7 @@ func (r *TestcafeRunner) generateStartOpts(s testcafe.Suite) job.StartOptions {
 		Name:             s.Name,
 		Build:            r.Project.Sauce.Metadata.Build,
 		Tags:             r.Project.Sauce.Metadata.Tags,
+		CustomData:       r.Project.Sauce.Metadata.CustomData,
 		Tunnel: job.TunnelOptions{
 			Name:  r.Project.Sauce.Tunnel.Name,
 			Owner: r.Project.Sauce.Tunnel.Owner,
@@ -211,6 +229,9 @@ func (r *TestcafeRunner) generateStartOpts(s testcafe.Suite) job.StartOptions {
 			FailedOnly: s.SmartRetry.IsRetryFailedOnly(),
 		},
 		ARMRequired: s.ARMRequired,
+		SuiteName:   s.Name,
+		Priority:    s.Priority,
+		DependsOn:   s.DependsOn,
 	}
 }
 
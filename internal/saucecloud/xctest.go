@@ -148,23 +148,23 @@ func (r *XctestRunner) runSuites(ctx context.Context) bool {
 
 	// Submit suites to work on.
 	jobsCount := r.calculateJobsCount(suites)
-	go func() {
-		for _, s := range suites {
-			for _, d := range enumerateDevices(s.Devices, s.Simulators) {
-				log.Debug().Str("suite", s.Name).
-					Str("deviceName", d.name).Str("deviceID", d.ID).
-					Str("platformVersion", d.platformVersion).
-					Msg("Starting job")
-				r.startJob(jobOpts, s.App, s.XCTestRunFile, s.OtherApps, s, d)
-			}
+	var startOptions []job.StartOptions
+	for _, s := range suites {
+		for _, d := range enumerateDevices(s.Devices, s.Simulators) {
+			log.Debug().Str("suite", s.Name).
+				Str("deviceName", d.name).Str("deviceID", d.ID).
+				Str("platformVersion", d.platformVersion).
+				Msg("Starting job")
+			startOptions = append(startOptions, r.newStartOptions(s.App, s.XCTestRunFile, s.OtherApps, s, d))
 		}
-	}()
+	}
+	r.launch(jobOpts, startOptions)
 
 	return r.collectResults(ctx, results, jobsCount)
 }
 
-func (r *XctestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, xcTestRunFileID string, otherAppsIDs []string, s xctest.Suite, d deviceConfig) {
-	jobOpts <- job.StartOptions{
+func (r *XctestRunner) newStartOptions(appFileID, xcTestRunFileID string, otherAppsIDs []string, s xctest.Suite, d deviceConfig) job.StartOptions {
+	return job.StartOptions{
 		ConfigFilePath:   r.Project.ConfigFilePath,
 		CLIFlags:         r.Project.CLIFlags,
 		DisplayName:      s.Name,
@@ -182,6 +182,7 @@ func (r *XctestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, xcTe
 		Name:             s.Name,
 		Build:            r.Project.Sauce.Metadata.Build,
 		Tags:             r.Project.Sauce.Metadata.Tags,
+		CustomData:       r.Project.Sauce.Metadata.CustomData,
 		Tunnel: job.TunnelOptions{
 			Name:  r.Project.Sauce.Tunnel.Name,
 			Owner: r.Project.Sauce.Tunnel.Owner,
@@ -219,6 +220,11 @@ func (r *XctestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, xcTe
 				NetworkCapture: s.AppSettings.Instrumentation.NetworkCapture,
 			},
 		},
+
+		// Scheduling
+		SuiteName: s.Name,
+		Priority:  s.Priority,
+		DependsOn: s.DependsOn,
 	}
 }
 
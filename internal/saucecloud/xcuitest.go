@@ -151,23 +151,23 @@ func (r *XcuitestRunner) runSuites(ctx context.Context) bool {
 
 	// Submit suites to work on.
 	jobsCount := r.calculateJobsCount(suites)
-	go func() {
-		for _, s := range suites {
-			for _, d := range enumerateDevices(s.Devices, s.Simulators) {
-				log.Debug().Str("suite", s.Name).
-					Str("deviceName", d.name).Str("deviceID", d.ID).
-					Str("platformVersion", d.platformVersion).
-					Msg("Starting job")
-				r.startJob(jobOpts, s.App, s.TestApp, s.OtherApps, s, d)
-			}
+	var startOptions []job.StartOptions
+	for _, s := range suites {
+		for _, d := range enumerateDevices(s.Devices, s.Simulators) {
+			log.Debug().Str("suite", s.Name).
+				Str("deviceName", d.name).Str("deviceID", d.ID).
+				Str("platformVersion", d.platformVersion).
+				Msg("Starting job")
+			startOptions = append(startOptions, r.newStartOptions(s.App, s.TestApp, s.OtherApps, s, d))
 		}
-	}()
+	}
+	r.launch(jobOpts, startOptions)
 
 	return r.collectResults(ctx, results, jobsCount)
 }
 
-func (r *XcuitestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, testAppFileID string, otherAppsIDs []string, s xcuitest.Suite, d deviceConfig) {
-	jobOpts <- job.StartOptions{
+func (r *XcuitestRunner) newStartOptions(appFileID, testAppFileID string, otherAppsIDs []string, s xcuitest.Suite, d deviceConfig) job.StartOptions {
+	return job.StartOptions{
 		ConfigFilePath:   r.Project.ConfigFilePath,
 		CLIFlags:         r.Project.CLIFlags,
 		DisplayName:      s.Name,
@@ -185,6 +185,7 @@ func (r *XcuitestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, te
 		Name:             s.Name,
 		Build:            r.Project.Sauce.Metadata.Build,
 		Tags:             r.Project.Sauce.Metadata.Tags,
+		CustomData:       r.Project.Sauce.Metadata.CustomData,
 		Tunnel: job.TunnelOptions{
 			Name:  r.Project.Sauce.Tunnel.Name,
 			Owner: r.Project.Sauce.Tunnel.Owner,
@@ -228,6 +229,11 @@ func (r *XcuitestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, te
 				NetworkCapture:         s.AppSettings.Instrumentation.NetworkCapture,
 			},
 		},
+
+		// Scheduling
+		SuiteName: s.Name,
+		Priority:  s.Priority,
+		DependsOn: s.DependsOn,
 	}
 }
 
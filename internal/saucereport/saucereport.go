@@ -83,6 +83,21 @@ func GetFailedTests(report SauceReport) []string {
 	return failedTests
 }
 
+// FailedTests returns the failed tests of the suite, including those of
+// nested suites.
+func FailedTests(suite Suite) []Test {
+	var failed []Test
+	for _, s := range suite.Suites {
+		failed = append(failed, FailedTests(s)...)
+	}
+	for _, t := range suite.Tests {
+		if t.Status == StatusFailed {
+			failed = append(failed, t)
+		}
+	}
+	return failed
+}
+
 func collectFailedTests(suite Suite) []string {
 	if len(suite.Suites) == 0 && len(suite.Tests) == 0 {
 		return []string{}
@@ -0,0 +1,68 @@
+package secret
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask is the replacement for secret values.
+const Mask = "****"
+
+var (
+	secrets []string
+	lock    sync.RWMutex
+)
+
+// Register adds a value that is masked from now on.
+func Register(v string) {
+	if v == "" {
+		return
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	for _, s := range secrets {
+		if s == v {
+			return
+		}
+	}
+	secrets = append(secrets, v)
+	// Replace longer secrets first, in case one contains another.
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+}
+
+// MaskString replaces all registered secrets in s.
+func MaskString(s string) string {
+	lock.RLock()
+	defer lock.RUnlock()
+	for _, v := range secrets {
+		s = strings.ReplaceAll(s, v, Mask)
+	}
+	return s
+}
+
+// MaskBytes replaces all registered secrets in b.
+func MaskBytes(b []byte) []byte {
+	return []byte(MaskString(string(b)))
+}
+
+type maskWriter struct {
+	w io.Writer
+}
+
+// NewMaskWriter returns a writer that masks all registered secrets before
+// writing to w. Each write is expected to be complete, e.g. a log line.
+func NewMaskWriter(w io.Writer) io.Writer {
+	return maskWriter{w: w}
+}
+
+func (m maskWriter) Write(p []byte) (int, error) {
+	if _, err := m.w.Write(MaskBytes(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,127 @@
+// Package secret resolves secret references in config files and masks
+// resolved secrets in any output.
+package secret
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Scheme is the prefix of a secret reference, e.g. "secret://api-key".
+const Scheme = "secret://"
+
+// Environment variables that configure the secret provider.
+const (
+	// FileEnv points to a file with KEY=VALUE lines.
+	FileEnv = "SAUCE_SECRETS_FILE"
+	// CommandEnv is a command that prints the secret whose name is passed as
+	// the last argument, e.g. "vault-get".
+	CommandEnv = "SAUCE_SECRETS_COMMAND"
+)
+
+// Provider looks up secrets by name.
+type Provider interface {
+	Get(name string) (string, error)
+}
+
+// EnvFileProvider reads secrets from a file with KEY=VALUE lines, as used by
+// .env files.
+type EnvFileProvider struct {
+	Path string
+}
+
+// Get returns the value of the secret.
+func (p EnvFileProvider) Get(name string) (string, error) {
+	f, err := os.Open(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open secrets file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.TrimSpace(key) != name {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		return value, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	return "", fmt.Errorf("secret '%s' not found in %s", name, p.Path)
+}
+
+// CommandProvider runs an external command that prints the secret to stdout.
+// The name of the secret is passed as the last argument.
+type CommandProvider struct {
+	Command string
+}
+
+// Get returns the value of the secret.
+func (p CommandProvider) Get(name string) (string, error) {
+	args := strings.Fields(p.Command)
+	if len(args) == 0 {
+		return "", errors.New("no secrets command specified")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(args[0], append(args[1:], name)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to get secret '%s': %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
+
+// DefaultProvider returns the provider that is configured via environment
+// variables. Returns nil if none is configured.
+func DefaultProvider() Provider {
+	if c := os.Getenv(CommandEnv); c != "" {
+		return CommandProvider{Command: c}
+	}
+	if f := os.Getenv(FileEnv); f != "" {
+		return EnvFileProvider{Path: f}
+	}
+	return nil
+}
+
+// IsReference returns true if the value is a secret reference.
+func IsReference(v string) bool {
+	return strings.HasPrefix(v, Scheme)
+}
+
+// Resolve looks up the referenced secret with the given provider and
+// registers its value for masking.
+func Resolve(p Provider, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, Scheme)
+	if p == nil {
+		return "", fmt.Errorf("unable to resolve '%s': no secret provider configured, set %s or %s", ref, FileEnv, CommandEnv)
+	}
+
+	v, err := p.Get(name)
+	if err != nil {
+		return "", err
+	}
+	Register(v)
+
+	return v, nil
+}
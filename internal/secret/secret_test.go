@@ -0,0 +1,69 @@
+package secret
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestEnvFileProvider_Get(t *testing.T) {
+	dir := fs.NewDir(t, "secrets", fs.WithFile(".env", `# comment
+API_KEY=abc123
+export TOKEN = "quoted value"
+EMPTY=
+`))
+	defer dir.Remove()
+
+	p := EnvFileProvider{Path: filepath.Join(dir.Path(), ".env")}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "API_KEY", want: "abc123"},
+		{name: "TOKEN", want: "quoted value"},
+		{name: "EMPTY", want: ""},
+		{name: "MISSING", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Get(tt.name)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommandProvider_Get(t *testing.T) {
+	got, err := CommandProvider{Command: "echo secret-for"}.Get("db")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret-for db", got)
+
+	_, err = CommandProvider{Command: "false"}.Get("db")
+	assert.Error(t, err)
+}
+
+func TestResolve(t *testing.T) {
+	_, err := Resolve(nil, "secret://db")
+	assert.ErrorContains(t, err, "no secret provider configured")
+
+	got, err := Resolve(CommandProvider{Command: "echo s3cr3t"}, "secret://db")
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cr3t db", got)
+
+	assert.Equal(t, "password: ****", MaskString("password: s3cr3t db"))
+
+	var buf bytes.Buffer
+	n, err := NewMaskWriter(&buf).Write([]byte("using s3cr3t db\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, len("using s3cr3t db\n"), n)
+	assert.Equal(t, "using ****\n", buf.String())
+}
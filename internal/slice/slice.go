@@ -36,3 +36,17 @@ func Join(value any, sep string) string {
 		return fmt.Sprintf("%v", value)
 	}
 }
+
+// Dedupe returns the values without duplicates, in the order of their first
+// occurrence.
+func Dedupe[T comparable](values []T) []T {
+	seen := map[T]bool{}
+	var out []T
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -31,3 +31,8 @@ func ExampleJoin_struct() {
 	fmt.Println(Join(Person{Name: "Someone Else"}, ","))
 	// Output: {Someone Else}
 }
+
+func ExampleDedupe() {
+	fmt.Println(Dedupe([]string{"b", "a", "b", "c", "a"}))
+	// Output: [b a c]
+}
@@ -0,0 +1,257 @@
+// Package code implements functions to statically parse testcafe spec files
+// for fixtures, tests and their metadata.
+package code
+
+import (
+	"github.com/saucelabs/saucectl/internal/js"
+)
+
+// Fixture describes a testcafe fixture and the tests that belong to it.
+type Fixture struct {
+	Name string
+	Meta map[string]string
+	// Dynamic is true if the name or the metadata couldn't be determined
+	// statically, e.g. because they are built from variables.
+	Dynamic bool
+	Skipped bool
+	Tests   []Test
+}
+
+// Test describes a testcafe test.
+type Test struct {
+	Name string
+	Meta map[string]string
+	// Dynamic is true if the name or the metadata couldn't be determined
+	// statically, e.g. because they are built from variables.
+	Dynamic bool
+	Skipped bool
+}
+
+// parser finds the fixture and test declarations in the tokens of a spec file.
+type parser struct {
+	tokens  []js.Token
+	closing map[int]int
+}
+
+// Parse takes the contents of a test file and parses the fixtures, e.g.
+//
+//	fixture`Login`.page`https://example.com`.meta('area', 'auth');
+//
+//	test.meta({ priority: 'high' })('logs in', async t => {});
+//
+// Like in testcafe, tests belong to the fixture that is declared before them.
+// Tests that are declared before any fixture are ignored.
+func Parse(input string) []Fixture {
+	p := parser{tokens: js.Tokenize(input)}
+	p.closing = js.MatchBrackets(p.tokens)
+
+	var fixtures []Fixture
+	for i := 0; i < len(p.tokens); i++ {
+		t := p.tokens[i]
+		if t.Kind != js.Ident || p.is(i-1, js.Punct, ".") {
+			continue
+		}
+		switch t.Value {
+		case "fixture":
+			if f, ok := p.parseFixture(i); ok {
+				fixtures = append(fixtures, f)
+			}
+		case "test":
+			if len(fixtures) == 0 {
+				continue
+			}
+			if test, ok := p.parseTest(i); ok {
+				f := &fixtures[len(fixtures)-1]
+				f.Tests = append(f.Tests, test)
+			}
+		}
+	}
+
+	return fixtures
+}
+
+func (p *parser) is(i int, kind js.Kind, value string) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].Kind == kind && p.tokens[i].Value == value
+}
+
+func (p *parser) end(i int) int {
+	if end, ok := p.closing[i]; ok {
+		return end
+	}
+	return len(p.tokens)
+}
+
+// parseFixture parses the fixture declaration at i, which is followed by the
+// fixture name and any chained calls, e.g. meta().
+func (p *parser) parseFixture(i int) (Fixture, bool) {
+	f := Fixture{Meta: map[string]string{}}
+	j := i + 1
+	for p.is(j, js.Punct, ".") && (p.is(j+1, js.Ident, "skip") || p.is(j+1, js.Ident, "only")) {
+		f.Skipped = f.Skipped || p.tokens[j+1].Value == "skip"
+		j += 2
+	}
+
+	name, dynamic, next, ok := p.name(j)
+	if !ok {
+		return Fixture{}, false
+	}
+	f.Name = name
+	f.Dynamic = dynamic
+
+	for j = next; p.is(j, js.Punct, ".") && j+1 < len(p.tokens) && p.tokens[j+1].Kind == js.Ident; {
+		method := p.tokens[j+1].Value
+		j += 2
+		if method == "skip" || method == "only" {
+			f.Skipped = f.Skipped || method == "skip"
+			continue
+		}
+		if method == "meta" && p.is(j, js.Punct, "(") {
+			f.Dynamic = !p.meta(j, f.Meta) || f.Dynamic
+		}
+		j = p.skipArguments(j)
+	}
+
+	return f, true
+}
+
+// parseTest parses the test declaration at i, which may be preceded by
+// chained calls, e.g. test.meta('key', 'value')('name', fn).
+func (p *parser) parseTest(i int) (Test, bool) {
+	t := Test{Meta: map[string]string{}}
+	j := i + 1
+	for p.is(j, js.Punct, ".") && j+1 < len(p.tokens) && p.tokens[j+1].Kind == js.Ident {
+		method := p.tokens[j+1].Value
+		j += 2
+		if method == "skip" || method == "only" {
+			t.Skipped = t.Skipped || method == "skip"
+			continue
+		}
+		if method == "meta" && p.is(j, js.Punct, "(") {
+			t.Dynamic = !p.meta(j, t.Meta) || t.Dynamic
+		}
+		j = p.skipArguments(j)
+	}
+	if !p.is(j, js.Punct, "(") {
+		return Test{}, false
+	}
+
+	name, dynamic, _, ok := p.name(j + 1)
+	if !ok {
+		// E.g. a reference to an imported test helper.
+		t.Dynamic = true
+	}
+	t.Name = name
+	t.Dynamic = t.Dynamic || dynamic
+
+	return t, true
+}
+
+// name returns the name at i, which is either a tagged template or the first
+// argument of a call, as well as the index of the token after it.
+func (p *parser) name(i int) (name string, dynamic bool, next int, ok bool) {
+	if i >= len(p.tokens) {
+		return "", false, i, false
+	}
+
+	if p.tokens[i].Kind == js.Template {
+		name, dynamic = templateValue(p.tokens[i])
+		return name, dynamic, i + 1, true
+	}
+	if p.is(i, js.Punct, "(") {
+		name, dynamic, _, ok = p.name(i + 1)
+		return name, dynamic, p.end(i) + 1, ok
+	}
+	if p.tokens[i].Kind == js.String {
+		// Concatenated names, e.g. 'Login ' + role, aren't known statically.
+		return p.tokens[i].Value, p.is(i+1, js.Punct, "+"), i + 1, true
+	}
+	return "", true, i + 1, false
+}
+
+func templateValue(t js.Token) (string, bool) {
+	var value string
+	dynamic := false
+	for _, part := range t.Parts {
+		if part.IsExpr {
+			dynamic = true
+			continue
+		}
+		value += part.Text
+	}
+	return value, dynamic
+}
+
+// skipArguments returns the index of the token after the arguments of a
+// chained call at i, which is either a tagged template or a call.
+func (p *parser) skipArguments(i int) int {
+	if i < len(p.tokens) && p.tokens[i].Kind == js.Template {
+		return i + 1
+	}
+	if p.is(i, js.Punct, "(") {
+		return p.end(i) + 1
+	}
+	return i
+}
+
+// meta adds the metadata of a meta() call, whose arguments start at i, to m.
+// It returns false if any of the metadata couldn't be determined statically.
+func (p *parser) meta(i int, m map[string]string) bool {
+	end := p.end(i)
+
+	// meta('key', 'value')
+	if key, ok := p.literal(i + 1); ok && p.is(i+2, js.Punct, ",") {
+		value, ok := p.literal(i + 3)
+		if !ok || i+4 != end {
+			return false
+		}
+		m[key] = value
+		return true
+	}
+
+	// meta({ key: 'value', ... })
+	if !p.is(i+1, js.Punct, "{") || p.end(i+1)+1 != end {
+		return false
+	}
+	objEnd := p.end(i + 1)
+	for j := i + 2; j < objEnd; j += 4 {
+		key, ok := p.key(j)
+		if !ok || !p.is(j+1, js.Punct, ":") {
+			return false
+		}
+		value, ok := p.literal(j + 2)
+		if !ok {
+			return false
+		}
+		m[key] = value
+		if !p.is(j+3, js.Punct, ",") && j+3 != objEnd {
+			return false
+		}
+	}
+	return true
+}
+
+// key returns the object key at i.
+func (p *parser) key(i int) (string, bool) {
+	if i < len(p.tokens) && p.tokens[i].Kind == js.Ident {
+		return p.tokens[i].Value, true
+	}
+	return p.literal(i)
+}
+
+// literal returns the string representation of the literal at i.
+func (p *parser) literal(i int) (string, bool) {
+	if i >= len(p.tokens) {
+		return "", false
+	}
+	t := p.tokens[i]
+	switch {
+	case t.Kind == js.String, t.Kind == js.Number:
+		return t.Value, true
+	case t.Kind == js.Template:
+		v, dynamic := templateValue(t)
+		return v, !dynamic
+	case t.Kind == js.Ident && (t.Value == "true" || t.Value == "false"):
+		return t.Value, true
+	}
+	return "", false
+}
@@ -0,0 +1,61 @@
+package code
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := `
+import { Selector } from 'testcafe';
+
+test('is declared before any fixture', async t => {});
+
+fixture` + "`Login`" + `
+    .page` + "`https://example.com/login?a=(`" + `
+    .meta('area', 'auth')
+    .meta({ priority: 'high', 'flaky': false });
+
+test('logs in', async t => {
+    await t.click(Selector('button'));
+});
+
+test.meta('type', 'smoke').page('https://example.com')('logs out', async t => {});
+
+test.skip('is skipped', async t => {});
+
+for (const role of roles) {
+    test.meta({ role })('logs in as ' + role, async t => {});
+}
+
+fixture.skip('Cart')
+    .page('https://example.com/cart');
+
+test.before(async t => {})(` + "`adds ${item}`" + `, async t => {});
+`
+
+	want := []Fixture{
+		{
+			Name: "Login",
+			Meta: map[string]string{"area": "auth", "priority": "high", "flaky": "false"},
+			Tests: []Test{
+				{Name: "logs in", Meta: map[string]string{}},
+				{Name: "logs out", Meta: map[string]string{"type": "smoke"}},
+				{Name: "is skipped", Meta: map[string]string{}, Skipped: true},
+				{Name: "logs in as ", Meta: map[string]string{}, Dynamic: true},
+			},
+		},
+		{
+			Name:    "Cart",
+			Meta:    map[string]string{},
+			Skipped: true,
+			Tests: []Test{
+				{Name: "adds ", Meta: map[string]string{}, Dynamic: true},
+			},
+		},
+	}
+
+	if got := Parse(input); !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
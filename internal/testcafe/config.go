@@ -3,6 +3,7 @@ package testcafe
 import (
 	"errors"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -15,9 +16,11 @@ import (
 	"github.com/saucelabs/saucectl/internal/insights"
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/node"
+	"github.com/saucelabs/saucectl/internal/pattern"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
 	"github.com/saucelabs/saucectl/internal/saucereport"
+	"github.com/saucelabs/saucectl/internal/slice"
 )
 
 // Config descriptors.
@@ -117,6 +120,8 @@ type Suite struct {
 	Headless             bool                   `yaml:"headless,omitempty" json:"headless"`
 	TimeZone             string                 `yaml:"timeZone,omitempty" json:"timeZone"`
 	PassThreshold        int                    `yaml:"passThreshold,omitempty" json:"-"`
+	Priority             int                    `yaml:"priority,omitempty" json:"-"`
+	DependsOn            []string               `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry           config.SmartRetry      `yaml:"smartRetry,omitempty" json:"-"`
 	ESM                  bool                   `yaml:"esm,omitempty" json:"esm"`
 	ARMRequired          bool                   `yaml:"armRequired,omitempty" json:"armRequired"`
@@ -125,6 +130,14 @@ type Suite struct {
 	// Deprecated. Reserved for future use for actual devices.
 	Devices    []config.Simulator `yaml:"devices,omitempty" json:"devices"`
 	Simulators []config.Simulator `yaml:"simulators,omitempty" json:"simulators"`
+	// Fixtures are the names of the fixtures that were found in the files of a
+	// sharded suite.
+	Fixtures []string `yaml:"-" json:"-"`
+}
+
+// Dependencies returns the suites that the suite depends on.
+func (s Suite) Dependencies() config.SuiteDependencies {
+	return config.SuiteDependencies{Name: s.Name, DependsOn: s.DependsOn}
 }
 
 // Screenshots represents screenshots configuration.
@@ -255,6 +268,18 @@ func Validate(p *Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := config.ValidateRetryPolicy(p.Sauce.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := config.ValidateFailFast(p.Sauce.FailFast); err != nil {
+		return err
+	}
+
+	if err := config.ValidateSuiteDependencies(p.Suites); err != nil {
+		return err
+	}
+
 	err := config.ValidateRegistries(p.Npm.Registries)
 	if err != nil {
 		return err
@@ -368,11 +393,22 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 		files = sauceignore.ExcludeSauceIgnorePatterns(files, sauceignoreFile)
 		testFiles := fpath.ExcludeFiles(files, excludedFiles)
 
+		testFiles, unmatched, fixtures := matchFiles(os.DirFS(rootDir), testFiles, s.Filter)
+		if s.Filter.IsSet() {
+			if len(testFiles) == 0 {
+				log.Error().Str("suiteName", s.Name).Msg("No files match the configured filter")
+				return []Suite{}, errors.New(msg.ShardingConfigurationNoMatchingTests)
+			} else if len(unmatched) > 0 {
+				log.Info().Str("suiteName", s.Name).Msgf("Files filtered out by filter: [%s]", unmatched)
+			}
+		}
+
 		if s.Shard == "spec" {
 			for _, f := range testFiles {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %s", s.Name, f)
 				replica.Src = []string{f}
+				replica.Fixtures = fixtures[f]
 				shardedSuites = append(shardedSuites, replica)
 			}
 		}
@@ -382,6 +418,10 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %d/%d", s.Name, i+1, len(groups))
 				replica.Src = group
+				replica.Fixtures = nil
+				for _, f := range group {
+					replica.Fixtures = append(replica.Fixtures, fixtures[f]...)
+				}
 				shardedSuites = append(shardedSuites, replica)
 			}
 		}
@@ -444,12 +484,31 @@ func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceRe
 	if len(failedTests) == 0 {
 		return nil
 	}
+	failedTests = slice.Dedupe(failedTests)
+
 	var found bool
 	for i, s := range p.Suites {
 		if s.Name != suiteName {
 			continue
 		}
-		p.Suites[i].Filter.TestGrep = strings.Join(failedTests, "|")
+		// Prefer an exact match over a pattern, if possible.
+		if len(failedTests) == 1 {
+			p.Suites[i].Filter.Test = failedTests[0]
+			p.Suites[i].Filter.TestGrep = ""
+		} else {
+			p.Suites[i].Filter.Test = ""
+			p.Suites[i].Filter.TestGrep = pattern.ExactMatch(failedTests)
+		}
+
+		// Narrow down the fixtures as well, since test names are only unique
+		// within a fixture. A fixture that's set by name is narrow enough.
+		fixtures := pattern.Matching(failedFixtures(report), s.Filter.FixtureGrep)
+		if s.Filter.Fixture == "" && len(fixtures) == 1 {
+			p.Suites[i].Filter.Fixture = fixtures[0]
+			p.Suites[i].Filter.FixtureGrep = ""
+		} else if s.Filter.Fixture == "" && len(fixtures) > 1 {
+			p.Suites[i].Filter.FixtureGrep = pattern.ExactMatch(fixtures)
+		}
 		found = true
 		break
 	}
@@ -459,6 +518,27 @@ func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceRe
 	return nil
 }
 
+// failedFixtures returns the names of the suites in the report that directly
+// contain failed tests, i.e. the fixtures of the failed tests.
+func failedFixtures(report saucereport.SauceReport) []string {
+	var fixtures []string
+	var walk func(suites []saucereport.Suite)
+	walk = func(suites []saucereport.Suite) {
+		for _, s := range suites {
+			for _, t := range s.Tests {
+				if t.Status == saucereport.StatusFailed {
+					fixtures = append(fixtures, s.Name)
+					break
+				}
+			}
+			walk(s.Suites)
+		}
+	}
+	walk(report.Suites)
+
+	return slice.Dedupe(fixtures)
+}
+
 // IsSmartRetried checks if the suites contain a smartRetried suite
 func (p *Project) IsSmartRetried() bool {
 	for _, s := range p.Suites {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/saucereport"
 	"github.com/stretchr/testify/assert"
 	"gotest.tools/v3/fs"
@@ -393,7 +394,7 @@ func TestTestcafe_FilterFailedTests(t *testing.T) {
 					},
 				},
 			},
-			expResult: "failed test1|failed test2",
+			expResult: "^(failed test1|failed test2)$",
 			expErr:    nil,
 		},
 		{
@@ -469,3 +470,132 @@ func TestTestcafe_FilterFailedTests(t *testing.T) {
 		})
 	}
 }
+
+func TestTestcafe_FilterFailedTests_Exact(t *testing.T) {
+	report := saucereport.SauceReport{
+		Status: saucereport.StatusFailed,
+		Suites: []saucereport.Suite{
+			{
+				Name:   "fixture one",
+				Status: saucereport.StatusFailed,
+				Tests: []saucereport.Test{
+					{Status: saucereport.StatusFailed, Name: "costs $5 (incl. tax)"},
+					{Status: saucereport.StatusPassed, Name: "passed test"},
+				},
+			},
+		},
+	}
+
+	p := &Project{Suites: []Suite{{Name: "my suite", Filter: Filter{TestGrep: "costs"}}}}
+	err := p.FilterFailedTests("my suite", report)
+	assert.NoError(t, err)
+	assert.Equal(t, Filter{Test: "costs $5 (incl. tax)", Fixture: "fixture one"}, p.Suites[0].Filter)
+
+	report.Suites = append(report.Suites, saucereport.Suite{
+		Name:   "fixture two",
+		Status: saucereport.StatusFailed,
+		Tests: []saucereport.Test{
+			{Status: saucereport.StatusFailed, Name: "costs $5 (incl. tax)"},
+			{Status: saucereport.StatusFailed, Name: "another"},
+		},
+	})
+	p.Suites[0].Filter = Filter{FixtureGrep: "fixture"}
+	err = p.FilterFailedTests("my suite", report)
+	assert.NoError(t, err)
+	assert.Equal(t, Filter{TestGrep: `^(costs \$5 \(incl\. tax\)|another)$`, FixtureGrep: "^(fixture one|fixture two)$"}, p.Suites[0].Filter)
+
+	p.Suites[0].Filter = Filter{Fixture: "fixture two"}
+	err = p.FilterFailedTests("my suite", report)
+	assert.NoError(t, err)
+	assert.Equal(t, Filter{TestGrep: `^(costs \$5 \(incl\. tax\)|another)$`, Fixture: "fixture two"}, p.Suites[0].Filter)
+}
+
+func Test_shardSuites_withFilter(t *testing.T) {
+	dir := fs.NewDir(t, "testcafe",
+		fs.WithFile(".sauceignore", "", fs.WithMode(0644)),
+		fs.WithDir("tests",
+			fs.WithMode(0755),
+			fs.WithFile("login.tests.js", `
+fixture`+"`Login`"+`.meta('area', 'auth');
+
+test.meta({ priority: 'high' })('logs in', async t => {});
+test('logs out', async t => {});
+`, fs.WithMode(0644)),
+			fs.WithFile("cart.tests.js", `
+fixture('Cart').meta({ area: 'shop' });
+
+test.meta('priority', 'high')('adds items', async t => {});
+`, fs.WithMode(0644)),
+			fs.WithFile("search.tests.js", `
+fixture.skip('Search').meta('area', 'shop');
+
+test.meta('priority', 'high')('finds items', async t => {});
+`, fs.WithMode(0644)),
+		),
+	)
+	defer dir.Remove()
+
+	testCases := []struct {
+		name    string
+		shard   string
+		filter  Filter
+		want    map[string][]string
+		wantErr error
+	}{
+		{
+			name:   "spec shards by test meta",
+			shard:  "spec",
+			filter: Filter{TestMeta: map[string]string{"priority": "high"}},
+			want: map[string][]string{
+				"Demo Suite - tests/cart.tests.js":  {"Cart"},
+				"Demo Suite - tests/login.tests.js": {"Login"},
+			},
+		},
+		{
+			name:   "spec shards by fixture meta and test grep",
+			shard:  "spec",
+			filter: Filter{FixtureMeta: map[string]string{"area": "auth"}, TestGrep: "out$"},
+			want: map[string][]string{
+				"Demo Suite - tests/login.tests.js": {"Login"},
+			},
+		},
+		{
+			name:   "concurrency shards by fixture",
+			shard:  "concurrency",
+			filter: Filter{FixtureGrep: "^(Cart|Search)$"},
+			want: map[string][]string{
+				"Demo Suite - 1/1": {"Cart"},
+			},
+		},
+		{
+			name:    "no matching tests",
+			shard:   "spec",
+			filter:  Filter{Test: "checks out"},
+			wantErr: errors.New(msg.ShardingConfigurationNoMatchingTests),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			suites := []Suite{
+				{
+					Name:   "Demo Suite",
+					Src:    []string{"tests/*.js"},
+					Shard:  tc.shard,
+					Filter: tc.filter,
+				},
+			}
+			got, err := shardSuites(dir.Path(), suites, 2, dir.Join(".sauceignore"))
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			fixtures := map[string][]string{}
+			for _, s := range got {
+				fixtures[s.Name] = s.Fixtures
+			}
+			assert.Equal(t, tc.want, fixtures)
+		})
+	}
+}
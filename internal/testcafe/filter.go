@@ -0,0 +1,126 @@
+package testcafe
+
+import (
+	"io/fs"
+	"regexp"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/testcafe/code"
+)
+
+// IsSet returns true if any of the filters is configured.
+func (f Filter) IsSet() bool {
+	return f.Test != "" || f.TestGrep != "" || f.Fixture != "" || f.FixtureGrep != "" ||
+		len(f.TestMeta) > 0 || len(f.FixtureMeta) > 0
+}
+
+// matcher evaluates a Filter against statically parsed fixtures and tests.
+// Names and metadata that can't be determined statically are assumed to match,
+// so that no file that testcafe would run is filtered out.
+type matcher struct {
+	filter      Filter
+	testGrep    *regexp.Regexp
+	fixtureGrep *regexp.Regexp
+}
+
+func newMatcher(f Filter) matcher {
+	return matcher{
+		filter:      f,
+		testGrep:    compileGrep("testGrep", f.TestGrep),
+		fixtureGrep: compileGrep("fixtureGrep", f.FixtureGrep),
+	}
+}
+
+// compileGrep compiles the grep expression. Expressions that aren't supported
+// by go, e.g. lookaheads, yield nil and are assumed to match.
+func compileGrep(name, expr string) *regexp.Regexp {
+	if expr == "" {
+		return nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		log.Warn().Err(err).Str(name, expr).Msg("Unable to evaluate the filter locally. All files are assumed to match.")
+		return nil
+	}
+	return re
+}
+
+func (m matcher) matchFixture(f code.Fixture) bool {
+	if f.Skipped {
+		return false
+	}
+	if f.Dynamic {
+		return true
+	}
+	if m.filter.Fixture != "" && m.filter.Fixture != f.Name {
+		return false
+	}
+	if m.fixtureGrep != nil && !m.fixtureGrep.MatchString(f.Name) {
+		return false
+	}
+	return hasMeta(f.Meta, m.filter.FixtureMeta)
+}
+
+func (m matcher) matchTest(t code.Test) bool {
+	if t.Skipped {
+		return false
+	}
+	if t.Dynamic {
+		return true
+	}
+	if m.filter.Test != "" && m.filter.Test != t.Name {
+		return false
+	}
+	if m.testGrep != nil && !m.testGrep.MatchString(t.Name) {
+		return false
+	}
+	return hasMeta(t.Meta, m.filter.TestMeta)
+}
+
+// hasMeta returns true if meta contains all the key-value pairs of want.
+func hasMeta(meta, want map[string]string) bool {
+	for k, v := range want {
+		if meta[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// matchFiles parses the given files and returns the ones that contain at least
+// one test that testcafe runs with the given filter, as well as the ones that
+// don't. Additionally, it returns the names of the fixtures with matching tests
+// per file. Files that can't be read are assumed to match.
+func matchFiles(sys fs.FS, files []string, f Filter) (matched []string, unmatched []string, fixtures map[string][]string) {
+	m := newMatcher(f)
+	fixtures = map[string][]string{}
+
+	for _, file := range files {
+		b, err := fs.ReadFile(sys, file)
+		if err != nil {
+			log.Warn().Err(err).Str("file", file).Msg("Unable to read test file. The file is assumed to match.")
+			matched = append(matched, file)
+			continue
+		}
+
+		for _, fx := range code.Parse(string(b)) {
+			if !m.matchFixture(fx) {
+				continue
+			}
+			for _, t := range fx.Tests {
+				if m.matchTest(t) {
+					fixtures[file] = append(fixtures[file], fx.Name)
+					break
+				}
+			}
+		}
+
+		if len(fixtures[file]) > 0 || !f.IsSet() {
+			matched = append(matched, file)
+		} else {
+			unmatched = append(unmatched, file)
+		}
+	}
+
+	return matched, unmatched, fixtures
+}
@@ -3,6 +3,8 @@
 package viper
 
 import (
+	"io"
+
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
@@ -25,6 +27,14 @@ func BindPFlag(key string, flag *pflag.Flag) error { return Default.BindPFlag(ke
 // and key/value stores, searching in one of the defined paths.
 func ReadInConfig() error { return Default.ReadInConfig() }
 
+// ReadConfig will read a configuration file, setting existing keys to nil if the
+// key does not exist in the file.
+func ReadConfig(in io.Reader) error { return Default.ReadConfig(in) }
+
+// SetConfigType sets the type of the configuration returned by the
+// remote source, e.g. "json".
+func SetConfigType(in string) { Default.SetConfigType(in) }
+
 // Set sets the value for the key in the override register.
 // Set is case-insensitive for a key.
 // Will be used instead of values obtained via
@@ -0,0 +1,186 @@
+package vmd
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// latestRegex matches the "latest" and "latest-N" version aliases.
+var latestRegex = regexp.MustCompile(`^(?i)latest(?:-(\d+))?$`)
+
+// rangeRegex matches a single comparison of a version range, e.g. ">=13".
+var rangeRegex = regexp.MustCompile(`^(>=|<=|>|<|=)(\S+)$`)
+
+// operatorSpaceRegex matches the spaces between comparison operators and
+// versions, e.g. ">= 13".
+var operatorSpaceRegex = regexp.MustCompile(`([<>=]+)\s+`)
+
+// IsConstraint returns true if the version is an alias or a range that needs
+// to be resolved against the available versions, e.g. "latest-1", ">=16" or
+// "17.x".
+func IsConstraint(version string) bool {
+	v := strings.TrimSpace(version)
+	return latestRegex.MatchString(v) || strings.ContainsAny(v, "<>=") || isWildcard(v)
+}
+
+func isWildcard(version string) bool {
+	return strings.HasSuffix(version, ".x") || strings.HasSuffix(version, ".*")
+}
+
+// ResolveVersions resolves the version constraints against the available
+// versions. Supported constraints are
+//   - exact versions, e.g. "16.2"
+//   - "latest" and "latest-N", which is the Nth version before the latest one
+//   - wildcards, e.g. "16.x", which match all versions with that prefix
+//   - space separated comparisons, e.g. ">=15 <17", which match all versions
+//     that satisfy every comparison
+//
+// Constraints that match multiple versions yield all of them, latest first.
+// The resolved versions are deduplicated in the order of the constraints.
+func ResolveVersions(available []string, constraints []string) ([]string, error) {
+	sorted := make([]string, len(available))
+	copy(sorted, available)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return CompareVersions(sorted[i], sorted[j]) > 0
+	})
+
+	var resolved []string
+	seen := map[string]bool{}
+	for _, c := range constraints {
+		versions, err := resolveVersion(sorted, strings.TrimSpace(c))
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range versions {
+			if !seen[v] {
+				seen[v] = true
+				resolved = append(resolved, v)
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+// resolveVersion resolves a single constraint against the available versions,
+// which are sorted from latest to oldest.
+func resolveVersion(sorted []string, constraint string) ([]string, error) {
+	if m := latestRegex.FindStringSubmatch(constraint); m != nil {
+		n := 0
+		if m[1] != "" {
+			n, _ = strconv.Atoi(m[1])
+		}
+		if n >= len(sorted) {
+			return nil, fmt.Errorf("version %q is not available, only %d versions exist: %s", constraint, len(sorted), strings.Join(sorted, ", "))
+		}
+		return []string{sorted[n]}, nil
+	}
+
+	match, err := versionMatcher(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, v := range sorted {
+		if match(v) {
+			versions = append(versions, v)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("version %q is not available, choose from: %s", constraint, strings.Join(sorted, ", "))
+	}
+	return versions, nil
+}
+
+// versionMatcher returns a function that tests versions against the constraint.
+func versionMatcher(constraint string) (func(string) bool, error) {
+	if isWildcard(constraint) {
+		prefix := constraint[:len(constraint)-1]
+		return func(v string) bool {
+			return strings.HasPrefix(v+".", prefix)
+		}, nil
+	}
+	if !strings.ContainsAny(constraint, "<>=") {
+		return func(v string) bool {
+			return CompareVersions(v, constraint) == 0
+		}, nil
+	}
+
+	var matchers []func(string) bool
+	for _, c := range strings.Fields(operatorSpaceRegex.ReplaceAllString(constraint, "$1")) {
+		m := rangeRegex.FindStringSubmatch(c)
+		if m == nil {
+			return nil, fmt.Errorf("invalid version range %q", constraint)
+		}
+		op, bound := m[1], m[2]
+		matchers = append(matchers, func(v string) bool {
+			diff := CompareVersions(v, bound)
+			switch op {
+			case ">=":
+				return diff >= 0
+			case "<=":
+				return diff <= 0
+			case ">":
+				return diff > 0
+			case "<":
+				return diff < 0
+			}
+			return diff == 0
+		})
+	}
+
+	return func(v string) bool {
+		for _, match := range matchers {
+			if !match(v) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// CompareVersions compares two dot separated versions segment by segment and
+// returns -1, 0 or 1. Missing segments count as 0, e.g. "16" equals "16.0".
+// Non-numeric segments are compared lexically.
+func CompareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		x, y := "0", "0"
+		if i < len(as) {
+			x = as[i]
+		}
+		if i < len(bs) {
+			y = bs[i]
+		}
+
+		xn, xerr := strconv.Atoi(x)
+		yn, yerr := strconv.Atoi(y)
+		if xerr == nil && yerr == nil {
+			if xn != yn {
+				return cmp.Compare(xn, yn)
+			}
+			continue
+		}
+		if x != y {
+			return strings.Compare(x, y)
+		}
+	}
+	return 0
+}
+
+// Find returns the device with the given name. Names are compared case
+// insensitively.
+func Find(devices []VirtualDevice, name string) (VirtualDevice, bool) {
+	for _, d := range devices {
+		if strings.EqualFold(d.Name, name) {
+			return d, true
+		}
+	}
+	return VirtualDevice{}, false
+}
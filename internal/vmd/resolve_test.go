@@ -0,0 +1,114 @@
+package vmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveVersions(t *testing.T) {
+	available := []string{"10.0", "12.0", "11.0", "13.0", "14.0", "9.0"}
+
+	tests := []struct {
+		name        string
+		constraints []string
+		want        []string
+		wantErr     string
+	}{
+		{
+			name:        "exact versions",
+			constraints: []string{"12.0", "9.0"},
+			want:        []string{"12.0", "9.0"},
+		},
+		{
+			name:        "exact versions with missing or extra segments",
+			constraints: []string{"14", "9.0.0"},
+			want:        []string{"14.0", "9.0"},
+		},
+		{
+			name:        "latest aliases",
+			constraints: []string{"latest", "LATEST-1", "latest-5"},
+			want:        []string{"14.0", "13.0", "9.0"},
+		},
+		{
+			name:        "ranges",
+			constraints: []string{">=11 <13", "<= 9"},
+			want:        []string{"12.0", "11.0", "9.0"},
+		},
+		{
+			name:        "wildcards and duplicates",
+			constraints: []string{"13.x", "latest-1", "=13"},
+			want:        []string{"13.0"},
+		},
+		{
+			name:        "unknown wildcard",
+			constraints: []string{"1.*"},
+			wantErr:     `version "1.*" is not available, choose from: 14.0, 13.0, 12.0, 11.0, 10.0, 9.0`,
+		},
+		{
+			name:        "unknown version",
+			constraints: []string{"15.0"},
+			wantErr:     `version "15.0" is not available, choose from: 14.0, 13.0, 12.0, 11.0, 10.0, 9.0`,
+		},
+		{
+			name:        "latest out of range",
+			constraints: []string{"latest-6"},
+			wantErr:     `version "latest-6" is not available, only 6 versions exist: 14.0, 13.0, 12.0, 11.0, 10.0, 9.0`,
+		},
+		{
+			name:        "invalid range",
+			constraints: []string{">=11 !12"},
+			wantErr:     `invalid version range ">=11 !12"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveVersions(available, tt.constraints)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("ResolveVersions() error = %v, wantErr %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveVersions() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"16.4", "16.4", 0},
+		{"16", "16.0", 0},
+		{"9.0", "10.0", -1},
+		{"17.0", "16.4.1", 1},
+		{"16.4.1", "16.4", 1},
+		{"beta", "alpha", 1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestIsConstraint(t *testing.T) {
+	for v, want := range map[string]bool{
+		"14.0":      false,
+		"latest":    true,
+		"latest-2":  true,
+		">=13":      true,
+		"16.x":      true,
+		"latest-ab": false,
+	} {
+		if got := IsConstraint(v); got != want {
+			t.Errorf("IsConstraint(%q) = %t, want %t", v, got, want)
+		}
+	}
+}
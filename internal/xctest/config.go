@@ -115,12 +115,19 @@ type Suite struct {
 	NetworkProfile           string                    `yaml:"networkProfile,omitempty" json:"networkProfile,omitempty"`
 	NetworkConditions        *config.NetworkConditions `yaml:"networkConditions,omitempty" json:"networkConditions,omitempty"`
 	PassThreshold            int                       `yaml:"passThreshold,omitempty" json:"-"`
+	Priority                 int                       `yaml:"priority,omitempty" json:"-"`
+	DependsOn                []string                  `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry               config.SmartRetry         `yaml:"smartRetry,omitempty" json:"-"`
 	Shard                    string                    `yaml:"shard,omitempty" json:"-"`
 	TestListFile             string                    `yaml:"testListFile,omitempty" json:"-"`
 	Env                      map[string]string         `yaml:"env,omitempty" json:"-"`
 }
 
+// Dependencies returns the suites that the suite depends on.
+func (s Suite) Dependencies() config.SuiteDependencies {
+	return config.SuiteDependencies{Name: s.Name, DependsOn: s.DependsOn}
+}
+
 // IOS constant
 const IOS = "iOS"
 
@@ -215,6 +222,18 @@ func Validate(p Project) error {
 		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
 	}
 
+	if err := config.ValidateRetryPolicy(p.Sauce.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := config.ValidateFailFast(p.Sauce.FailFast); err != nil {
+		return err
+	}
+
+	if err := config.ValidateSuiteDependencies(p.Suites); err != nil {
+		return err
+	}
+
 	if len(p.Suites) == 0 {
 		return errors.New(msg.EmptySuite)
 	}
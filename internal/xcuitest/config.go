@@ -115,10 +115,20 @@ type Suite struct {
 	NetworkProfile     string                    `yaml:"networkProfile,omitempty" json:"networkProfile,omitempty"`
 	NetworkConditions  *config.NetworkConditions `yaml:"networkConditions,omitempty" json:"networkConditions,omitempty"`
 	PassThreshold      int                       `yaml:"passThreshold,omitempty" json:"-"`
+	Priority           int                       `yaml:"priority,omitempty" json:"-"`
+	DependsOn          []string                  `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry         config.SmartRetry         `yaml:"smartRetry,omitempty" json:"-"`
 	Shard              string                    `yaml:"shard,omitempty" json:"-"`
 	TestListFile       string                    `yaml:"testListFile,omitempty" json:"-"`
-	Env                map[string]string         `yaml:"env,omitempty" json:"-"`
+	TestPlan           string                    `yaml:"testPlan,omitempty" json:"-"`
+	// TestPlanConfigurations limits the tests of the TestPlan to the given configurations.
+	TestPlanConfigurations []string          `yaml:"testPlanConfigurations,omitempty" json:"-"`
+	Env                    map[string]string `yaml:"env,omitempty" json:"-"`
+}
+
+// Dependencies returns the suites that the suite depends on.
+func (s Suite) Dependencies() config.SuiteDependencies {
+	return config.SuiteDependencies{Name: s.Name, DependsOn: s.DependsOn}
 }
 
 // IOS constant
@@ -215,6 +225,18 @@ func Validate(p Project) error {
 		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
 	}
 
+	if err := config.ValidateRetryPolicy(p.Sauce.RetryPolicy); err != nil {
+		return err
+	}
+
+	if err := config.ValidateFailFast(p.Sauce.FailFast); err != nil {
+		return err
+	}
+
+	if err := config.ValidateSuiteDependencies(p.Suites); err != nil {
+		return err
+	}
+
 	if len(p.Suites) == 0 {
 		return errors.New(msg.EmptySuite)
 	}
@@ -268,6 +290,16 @@ func Validate(p Project) error {
 			return fmt.Errorf(msg.InvalidPassThreshold)
 		}
 		config.ValidateSmartRetry(suite.SmartRetry)
+
+		if suite.Shard == "class" && suite.TestPlan == "" {
+			return fmt.Errorf("suite '%s' requires a testPlan to shard by class", suite.Name)
+		}
+		if suite.Shard == "testList" && suite.TestPlan != "" {
+			return fmt.Errorf("suite '%s' cannot shard by testList when a testPlan is set", suite.Name)
+		}
+		if len(suite.TestPlanConfigurations) > 0 && suite.TestPlan == "" {
+			return fmt.Errorf("suite '%s' requires a testPlan to select testPlanConfigurations", suite.Name)
+		}
 	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
@@ -308,11 +340,17 @@ func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
 	return res
 }
 
-// ShardSuites applies sharding by provided testListFile.
+// ShardSuites applies sharding by provided testListFile or testPlan.
 func ShardSuites(p *Project) error {
 	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Shard == "concurrency" {
+		if s.TestPlan != "" {
+			shardedSuites, err := shardByTestPlan(s, p.Sauce.Concurrency)
+			if err != nil {
+				return fmt.Errorf("failed to get tests from testPlan(%q): %v", s.TestPlan, err)
+			}
+			suites = append(suites, shardedSuites...)
+		} else if s.Shard == "concurrency" {
 			shardedSuites, err := shardByConcurrency(s, p.Sauce.Concurrency)
 			if err != nil {
 				return fmt.Errorf("failed to get tests from testListFile(%q): %v", s.TestListFile, err)
@@ -333,8 +371,8 @@ func ShardSuites(p *Project) error {
 	return nil
 }
 
-func shardByConcurrency(suite Suite, ccy int) ([]Suite, error) {
-	readFile, err := os.Open(suite.TestListFile)
+func readTestListFile(name string) ([]string, error) {
+	readFile, err := os.Open(name)
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +391,19 @@ func shardByConcurrency(suite Suite, ccy int) ([]Suite, error) {
 	if len(tests) == 0 {
 		return nil, errors.New("empty file")
 	}
+	return tests, nil
+}
+
+func shardByConcurrency(suite Suite, ccy int) ([]Suite, error) {
+	tests, err := readTestListFile(suite.TestListFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return splitByConcurrency(suite, tests, ccy), nil
+}
 
+func splitByConcurrency(suite Suite, tests []string, ccy int) []Suite {
 	buckets := concurrency.BinPack(tests, ccy)
 	var suites []Suite
 	for i, b := range buckets {
@@ -362,29 +412,15 @@ func shardByConcurrency(suite Suite, ccy int) ([]Suite, error) {
 		currSuite.TestOptions.Class = b
 		suites = append(suites, currSuite)
 	}
-	return suites, nil
+	return suites
 }
 
 func shardByTestList(suite Suite) ([]Suite, error) {
-	readFile, err := os.Open(suite.TestListFile)
+	tests, err := readTestListFile(suite.TestListFile)
 	if err != nil {
 		return nil, err
 	}
-	defer readFile.Close()
 
-	fileScanner := bufio.NewScanner(readFile)
-	fileScanner.Split(bufio.ScanLines)
-	var tests []string
-	for fileScanner.Scan() {
-		text := strings.TrimSpace(fileScanner.Text())
-		if text == "" {
-			continue
-		}
-		tests = append(tests, text)
-	}
-	if len(tests) == 0 {
-		return nil, errors.New("empty file")
-	}
 	var suites []Suite
 	for _, t := range tests {
 		currSuite := suite
@@ -395,6 +431,153 @@ func shardByTestList(suite Suite) ([]Suite, error) {
 	return suites, nil
 }
 
+// shardByTestPlan enumerates the tests of the suite's test plan and splits
+// them according to the suite's shard setting. Without sharding, the suite
+// runs all tests of the test plan.
+func shardByTestPlan(suite Suite, ccy int) ([]Suite, error) {
+	plan, err := ReadTestPlan(suite.TestPlan, suite.TestPlanConfigurations)
+	if err != nil {
+		return nil, err
+	}
+
+	tests := filterTests(plan.Tests, toTestIdentifiers(suite.TestOptions.Class), toTestIdentifiers(suite.TestOptions.NotClass))
+	if len(tests) == 0 {
+		return nil, errors.New(msg.ShardingConfigurationNoMatchingTests)
+	}
+
+	// VMD expects slash-separated test identifiers, whereas RDC expects the
+	// target and class to be dot-separated.
+	simulator := len(suite.Simulators) > 0
+	format := func(ids []string) []string {
+		var res []string
+		for _, id := range ids {
+			res = append(res, formatTestIdentifier(id, simulator))
+		}
+		return res
+	}
+
+	// Test plans list whole targets by their name only, unless specific tests
+	// are selected. Such targets can't be split any further and are sharded as
+	// a whole.
+	var targets []string
+	for _, t := range tests {
+		if !strings.Contains(t, "/") {
+			targets = append(targets, t)
+		}
+	}
+	if suite.Shard == "class" && len(targets) == len(tests) {
+		return nil, fmt.Errorf("test plan only selects whole test targets (%s), which can't be sharded by class; select the tests in the test plan or the classes via testOptions.class", strings.Join(targets, ", "))
+	}
+	if (suite.Shard == "class" || suite.Shard == "concurrency") && len(targets) > 0 {
+		log.Warn().Str("suite", suite.Name).Strs("targets", targets).
+			Msg("The test plan selects whole test targets, which can't be split by class. Each of them runs in a single shard.")
+	}
+
+	suite.TestOptions.NotClass = append(append([]string{}, suite.TestOptions.NotClass...), format(plan.Skipped)...)
+	if suite.TestOptions.TestLanguage == "" {
+		suite.TestOptions.TestLanguage = plan.Language
+	}
+	if suite.TestOptions.TestRegion == "" {
+		suite.TestOptions.TestRegion = plan.Region
+	}
+
+	switch suite.Shard {
+	case "concurrency":
+		return splitByConcurrency(suite, format(tests), ccy), nil
+	case "class":
+		var classes []string
+		byClass := map[string][]string{}
+		for _, t := range tests {
+			class := testClass(t)
+			if _, ok := byClass[class]; !ok {
+				classes = append(classes, class)
+			}
+			byClass[class] = append(byClass[class], t)
+		}
+
+		var suites []Suite
+		for _, c := range classes {
+			currSuite := suite
+			currSuite.Name = fmt.Sprintf("%s - %s", suite.Name, formatTestIdentifier(c, simulator))
+			currSuite.TestOptions.Class = format(byClass[c])
+			suites = append(suites, currSuite)
+		}
+		return suites, nil
+	}
+
+	suite.TestOptions.Class = format(tests)
+	return []Suite{suite}, nil
+}
+
+// filterTests returns the tests that are within the scope of the given class
+// filters and outside the scope of the notClass filters. A class filter that is
+// more specific than a test is returned in its place.
+func filterTests(tests, class, notClass []string) []string {
+	excluded := func(id string) bool {
+		for _, n := range notClass {
+			if withinTest(id, n) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var res []string
+	seen := map[string]bool{}
+	add := func(id string) {
+		if !seen[id] && !excluded(id) {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+
+	for _, t := range tests {
+		if len(class) == 0 {
+			add(t)
+			continue
+		}
+		for _, c := range class {
+			if withinTest(t, c) {
+				add(t)
+			} else if withinTest(c, t) {
+				add(c)
+			}
+		}
+	}
+	return res
+}
+
+// withinTest returns true if the test identifier id equals or is part of scope.
+func withinTest(id, scope string) bool {
+	return id == scope || strings.HasPrefix(id, scope+"/")
+}
+
+// testClass returns the "<target>/<class>" part of the test identifier.
+func testClass(id string) string {
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) < 2 {
+		return id
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+// toTestIdentifiers converts class filters, which may either be dot- or
+// slash-separated, to slash-separated test identifiers.
+func toTestIdentifiers(filters []string) []string {
+	var ids []string
+	for _, f := range filters {
+		ids = append(ids, strings.ReplaceAll(f, ".", "/"))
+	}
+	return ids
+}
+
+func formatTestIdentifier(id string, simulator bool) string {
+	if simulator {
+		return id
+	}
+	return strings.Replace(id, "/", ".", 1)
+}
+
 func GetShardTypes(suites []Suite) []string {
 	var set = map[string]bool{}
 	for _, s := range suites {
@@ -0,0 +1,270 @@
+package xcuitest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/saucelabs/saucectl/internal/plist"
+)
+
+// TestPlan represents the tests that are selected by an .xctestrun or
+// .xctestplan file.
+// Test identifiers are slash-separated, e.g. "<target>", "<target>/<class>"
+// or "<target>/<class>/<method>".
+type TestPlan struct {
+	Tests    []string
+	Skipped  []string
+	Language string
+	Region   string
+}
+
+// ReadTestPlan reads the tests from the given .xctestrun or .xctestplan file.
+// If configurations are given, only the matching test plan configurations are
+// taken into account.
+func ReadTestPlan(name string, configurations []string) (TestPlan, error) {
+	switch filepath.Ext(name) {
+	case ".xctestrun":
+		return readXCTestRun(name, configurations)
+	case ".xctestplan":
+		return readXCTestPlan(name, configurations)
+	}
+	return TestPlan{}, fmt.Errorf("unsupported test plan %q, make sure extension is one of the following: .xctestrun, .xctestplan", name)
+}
+
+// testTarget represents the test selection of a single test target.
+type testTarget struct {
+	name    string
+	only    []string
+	skipped []string
+}
+
+func readXCTestRun(name string, configurations []string) (TestPlan, error) {
+	v, err := plist.DecodeFile(name)
+	if err != nil {
+		return TestPlan{}, err
+	}
+	root, ok := v.(map[string]interface{})
+	if !ok {
+		return TestPlan{}, fmt.Errorf("invalid xctestrun file %q", name)
+	}
+
+	var targets []testTarget
+	if cfgs, ok := root["TestConfigurations"].([]interface{}); ok {
+		var names []string
+		for _, c := range cfgs {
+			cfg, _ := c.(map[string]interface{})
+			cfgName, _ := cfg["Name"].(string)
+			names = append(names, cfgName)
+			if len(configurations) > 0 && !slices.Contains(configurations, cfgName) {
+				continue
+			}
+
+			tts, _ := cfg["TestTargets"].([]interface{})
+			for _, tt := range tts {
+				target, _ := tt.(map[string]interface{})
+				targets = append(targets, xctestrunTarget("", target))
+			}
+		}
+		if err := checkConfigurations(configurations, names); err != nil {
+			return TestPlan{}, err
+		}
+	} else {
+		// Format version 1 lists the test targets at the root level.
+		if err := checkConfigurations(configurations, nil); err != nil {
+			return TestPlan{}, err
+		}
+		for k, v := range root {
+			target, ok := v.(map[string]interface{})
+			if !ok || strings.HasPrefix(k, "__") {
+				continue
+			}
+			targets = append(targets, xctestrunTarget(k, target))
+		}
+	}
+
+	return newTestPlan(targets), nil
+}
+
+func xctestrunTarget(name string, target map[string]interface{}) testTarget {
+	if n, ok := target["BlueprintName"].(string); ok && n != "" {
+		name = n
+	}
+	return testTarget{
+		name:    name,
+		only:    stringValues(target["OnlyTestIdentifiers"]),
+		skipped: stringValues(target["SkipTestIdentifiers"]),
+	}
+}
+
+// xctestplan represents the parts of an .xctestplan file that are relevant for
+// enumerating tests.
+type xctestplan struct {
+	Configurations []struct {
+		Name    string            `json:"name"`
+		Options xctestplanOptions `json:"options"`
+	} `json:"configurations"`
+	DefaultOptions xctestplanOptions `json:"defaultOptions"`
+	TestTargets    []struct {
+		Enabled       *bool           `json:"enabled"`
+		SelectedTests []string        `json:"selectedTests"`
+		SkippedTests  json.RawMessage `json:"skippedTests"`
+		Target        struct {
+			Name string `json:"name"`
+		} `json:"target"`
+	} `json:"testTargets"`
+}
+
+type xctestplanOptions struct {
+	Language string `json:"language"`
+	Region   string `json:"region"`
+}
+
+func readXCTestPlan(name string, configurations []string) (TestPlan, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return TestPlan{}, err
+	}
+	var tp xctestplan
+	if err := json.Unmarshal(b, &tp); err != nil {
+		return TestPlan{}, fmt.Errorf("invalid xctestplan file %q: %w", name, err)
+	}
+
+	var names []string
+	var selected []xctestplanOptions
+	for _, c := range tp.Configurations {
+		names = append(names, c.Name)
+		if len(configurations) == 0 || slices.Contains(configurations, c.Name) {
+			selected = append(selected, c.Options)
+		}
+	}
+	if err := checkConfigurations(configurations, names); err != nil {
+		return TestPlan{}, err
+	}
+
+	var targets []testTarget
+	for _, t := range tp.TestTargets {
+		if t.Enabled != nil && !*t.Enabled {
+			continue
+		}
+		skipped, err := skippedTests(t.SkippedTests)
+		if err != nil {
+			return TestPlan{}, fmt.Errorf("invalid xctestplan file %q: %w", name, err)
+		}
+		targets = append(targets, testTarget{
+			name:    t.Target.Name,
+			only:    t.SelectedTests,
+			skipped: skipped,
+		})
+	}
+
+	p := newTestPlan(targets)
+	p.Language = tp.DefaultOptions.Language
+	p.Region = tp.DefaultOptions.Region
+	// Options of a configuration only apply if it's the only one that runs.
+	if len(selected) == 1 {
+		if selected[0].Language != "" {
+			p.Language = selected[0].Language
+		}
+		if selected[0].Region != "" {
+			p.Region = selected[0].Region
+		}
+	}
+
+	return p, nil
+}
+
+// skippedTests reads the skipped tests of a test plan target, which are either
+// a list of identifiers or, since Xcode 15, grouped by suite.
+func skippedTests(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list, nil
+	}
+
+	var grouped struct {
+		Suites []struct {
+			Name          string   `json:"name"`
+			TestFunctions []string `json:"testFunctions"`
+		} `json:"suites"`
+	}
+	if err := json.Unmarshal(raw, &grouped); err != nil {
+		return nil, err
+	}
+	for _, s := range grouped.Suites {
+		if len(s.TestFunctions) == 0 {
+			list = append(list, s.Name)
+		}
+		for _, f := range s.TestFunctions {
+			list = append(list, s.Name+"/"+f)
+		}
+	}
+	return list, nil
+}
+
+func checkConfigurations(configurations, available []string) error {
+	for _, c := range configurations {
+		if !slices.Contains(available, c) {
+			return fmt.Errorf("test plan configuration %q not found", c)
+		}
+	}
+	return nil
+}
+
+func newTestPlan(targets []testTarget) TestPlan {
+	tests := map[string]bool{}
+	skipped := map[string]bool{}
+	for _, t := range targets {
+		if t.name == "" {
+			continue
+		}
+		if len(t.only) == 0 {
+			tests[t.name] = true
+		}
+		for _, id := range t.only {
+			tests[testIdentifier(t.name, id)] = true
+		}
+		for _, id := range t.skipped {
+			skipped[testIdentifier(t.name, id)] = true
+		}
+	}
+
+	return TestPlan{
+		Tests:   sortedKeys(tests),
+		Skipped: sortedKeys(skipped),
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// testIdentifier prefixes the test identifier with its target and strips the
+// parentheses that test plans add to test methods, e.g. "testLogin()".
+func testIdentifier(target, id string) string {
+	return target + "/" + strings.TrimSuffix(id, "()")
+}
+
+func stringValues(v interface{}) []string {
+	items, _ := v.([]interface{})
+	var values []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
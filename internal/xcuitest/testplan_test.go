@@ -0,0 +1,318 @@
+package xcuitest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+const xctestrunV2 = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>TestConfigurations</key>
+	<array>
+		<dict>
+			<key>Name</key>
+			<string>English</string>
+			<key>TestTargets</key>
+			<array>
+				<dict>
+					<key>BlueprintName</key>
+					<string>UITests</string>
+					<key>OnlyTestIdentifiers</key>
+					<array>
+						<string>LoginTests</string>
+						<string>CartTests/testAdd</string>
+						<string>CartTests/testRemove</string>
+					</array>
+					<key>SkipTestIdentifiers</key>
+					<array>
+						<string>LoginTests/testFlaky</string>
+					</array>
+				</dict>
+			</array>
+		</dict>
+		<dict>
+			<key>Name</key>
+			<string>German</string>
+			<key>TestTargets</key>
+			<array>
+				<dict>
+					<key>BlueprintName</key>
+					<string>SmokeTests</string>
+				</dict>
+			</array>
+		</dict>
+	</array>
+	<key>__xctestrun_metadata__</key>
+	<dict>
+		<key>FormatVersion</key>
+		<integer>2</integer>
+	</dict>
+</dict>
+</plist>`
+
+const xctestrunV1 = `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>UITests</key>
+	<dict>
+		<key>OnlyTestIdentifiers</key>
+		<array>
+			<string>LoginTests/testLogin</string>
+		</array>
+	</dict>
+	<key>__xctestrun_metadata__</key>
+	<dict>
+		<key>FormatVersion</key>
+		<integer>1</integer>
+	</dict>
+</dict>
+</plist>`
+
+const xctestplanJSON = `{
+  "configurations" : [
+    {
+      "id" : "1",
+      "name" : "English",
+      "options" : {}
+    },
+    {
+      "id" : "2",
+      "name" : "German",
+      "options" : {
+        "language" : "de",
+        "region" : "DE"
+      }
+    }
+  ],
+  "defaultOptions" : {
+    "language" : "en",
+    "region" : "US"
+  },
+  "testTargets" : [
+    {
+      "selectedTests" : [
+        "LoginTests\/testLogin()",
+        "LoginTests\/testLogout()"
+      ],
+      "target" : {
+        "containerPath" : "container:App.xcodeproj",
+        "identifier" : "1",
+        "name" : "UITests"
+      }
+    },
+    {
+      "skippedTests" : {
+        "suites" : [
+          {
+            "name" : "CheckoutTests",
+            "testFunctions" : [
+              "testPay()"
+            ]
+          }
+        ]
+      },
+      "target" : {
+        "name" : "SmokeTests"
+      }
+    },
+    {
+      "enabled" : false,
+      "target" : {
+        "name" : "DisabledTests"
+      }
+    }
+  ],
+  "version" : 1
+}`
+
+func TestReadTestPlan(t *testing.T) {
+	dir := fs.NewDir(t, "testplan",
+		fs.WithFile("v2.xctestrun", xctestrunV2),
+		fs.WithFile("v1.xctestrun", xctestrunV1),
+		fs.WithFile("App.xctestplan", xctestplanJSON),
+		fs.WithFile("tests.txt", "LoginTests"),
+	)
+	defer dir.Remove()
+
+	testCases := []struct {
+		name           string
+		file           string
+		configurations []string
+		want           TestPlan
+		wantErr        string
+	}{
+		{
+			name: "xctestrun with all configurations",
+			file: "v2.xctestrun",
+			want: TestPlan{
+				Tests:   []string{"SmokeTests", "UITests/CartTests/testAdd", "UITests/CartTests/testRemove", "UITests/LoginTests"},
+				Skipped: []string{"UITests/LoginTests/testFlaky"},
+			},
+		},
+		{
+			name:           "xctestrun with selected configuration",
+			file:           "v2.xctestrun",
+			configurations: []string{"German"},
+			want: TestPlan{
+				Tests: []string{"SmokeTests"},
+			},
+		},
+		{
+			name:           "xctestrun with unknown configuration",
+			file:           "v2.xctestrun",
+			configurations: []string{"French"},
+			wantErr:        `test plan configuration "French" not found`,
+		},
+		{
+			name: "xctestrun format version 1",
+			file: "v1.xctestrun",
+			want: TestPlan{
+				Tests: []string{"UITests/LoginTests/testLogin"},
+			},
+		},
+		{
+			name: "xctestplan with all configurations",
+			file: "App.xctestplan",
+			want: TestPlan{
+				Tests:    []string{"SmokeTests", "UITests/LoginTests/testLogin", "UITests/LoginTests/testLogout"},
+				Skipped:  []string{"SmokeTests/CheckoutTests/testPay"},
+				Language: "en",
+				Region:   "US",
+			},
+		},
+		{
+			name:           "xctestplan with selected configuration",
+			file:           "App.xctestplan",
+			configurations: []string{"German"},
+			want: TestPlan{
+				Tests:    []string{"SmokeTests", "UITests/LoginTests/testLogin", "UITests/LoginTests/testLogout"},
+				Skipped:  []string{"SmokeTests/CheckoutTests/testPay"},
+				Language: "de",
+				Region:   "DE",
+			},
+		},
+		{
+			name:    "unsupported file",
+			file:    "tests.txt",
+			wantErr: `unsupported test plan "` + dir.Join("tests.txt") + `", make sure extension is one of the following: .xctestrun, .xctestplan`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ReadTestPlan(filepath.Join(dir.Path(), tc.file), tc.configurations)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestShardSuites_TestPlan(t *testing.T) {
+	dir := fs.NewDir(t, "testplan",
+		fs.WithFile("v2.xctestrun", xctestrunV2),
+	)
+	defer dir.Remove()
+	testPlan := dir.Join("v2.xctestrun")
+
+	testCases := []struct {
+		name    string
+		suite   Suite
+		want    []Suite
+		wantErr string
+	}{
+		{
+			name:  "without sharding",
+			suite: Suite{Name: "plan", TestPlan: testPlan, TestPlanConfigurations: []string{"English"}},
+			want: []Suite{
+				{
+					Name: "plan", TestPlan: testPlan, TestPlanConfigurations: []string{"English"},
+					TestOptions: TestOptions{
+						Class:    []string{"UITests.CartTests/testAdd", "UITests.CartTests/testRemove", "UITests.LoginTests"},
+						NotClass: []string{"UITests.LoginTests/testFlaky"},
+					},
+				},
+			},
+		},
+		{
+			name:  "by class",
+			suite: Suite{Name: "plan", Shard: "class", TestPlan: testPlan},
+			want: []Suite{
+				{
+					Name: "plan - SmokeTests", Shard: "class", TestPlan: testPlan,
+					TestOptions: TestOptions{Class: []string{"SmokeTests"}, NotClass: []string{"UITests.LoginTests/testFlaky"}},
+				},
+				{
+					Name: "plan - UITests.CartTests", Shard: "class", TestPlan: testPlan,
+					TestOptions: TestOptions{Class: []string{"UITests.CartTests/testAdd", "UITests.CartTests/testRemove"}, NotClass: []string{"UITests.LoginTests/testFlaky"}},
+				},
+				{
+					Name: "plan - UITests.LoginTests", Shard: "class", TestPlan: testPlan,
+					TestOptions: TestOptions{Class: []string{"UITests.LoginTests"}, NotClass: []string{"UITests.LoginTests/testFlaky"}},
+				},
+			},
+		},
+		{
+			name: "by concurrency on simulators with filters",
+			suite: Suite{
+				Name: "plan", Shard: "concurrency", TestPlan: testPlan,
+				Simulators: []config.Simulator{{Name: "iPhone Simulator"}},
+				TestOptions: TestOptions{
+					Class:    []string{"UITests.CartTests", "SmokeTests/PaymentTests"},
+					NotClass: []string{"UITests/CartTests/testRemove"},
+				},
+			},
+			want: []Suite{
+				{
+					Name: "plan - 1/2", Shard: "concurrency", TestPlan: testPlan,
+					Simulators: []config.Simulator{{Name: "iPhone Simulator"}},
+					TestOptions: TestOptions{
+						Class:    []string{"SmokeTests/PaymentTests"},
+						NotClass: []string{"UITests/CartTests/testRemove", "UITests/LoginTests/testFlaky"},
+					},
+				},
+				{
+					Name: "plan - 2/2", Shard: "concurrency", TestPlan: testPlan,
+					Simulators: []config.Simulator{{Name: "iPhone Simulator"}},
+					TestOptions: TestOptions{
+						Class:    []string{"UITests/CartTests/testAdd"},
+						NotClass: []string{"UITests/CartTests/testRemove", "UITests/LoginTests/testFlaky"},
+					},
+				},
+			},
+		},
+		{
+			name:    "by class with whole targets only",
+			suite:   Suite{Name: "plan", Shard: "class", TestPlan: testPlan, TestPlanConfigurations: []string{"German"}},
+			wantErr: `failed to get tests from testPlan("` + testPlan + `"): test plan only selects whole test targets (SmokeTests), which can't be sharded by class; select the tests in the test plan or the classes via testOptions.class`,
+		},
+		{
+			name:    "no matching tests",
+			suite:   Suite{Name: "plan", TestPlan: testPlan, TestOptions: TestOptions{Class: []string{"UITests.ProfileTests"}}},
+			wantErr: `failed to get tests from testPlan("` + testPlan + `"): sharding configuration resulted in no matching tests`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := Project{
+				Sauce:  config.SauceConfig{Concurrency: 2},
+				Suites: []Suite{tc.suite},
+			}
+			err := ShardSuites(&p)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, p.Suites)
+		})
+	}
+}